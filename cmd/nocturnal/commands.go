@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"golang.org/x/exp/slog"
+
+	"github.com/observerly/dusk/pkg/dusk"
+	"github.com/observerly/nocturnal/internal/app"
+	"github.com/observerly/nocturnal/internal/config"
+	logging "github.com/observerly/nocturnal/internal/logger"
+	"github.com/observerly/nocturnal/pkg/moon"
+	"github.com/observerly/nocturnal/pkg/sun"
+	"github.com/observerly/nocturnal/pkg/transit"
+	"github.com/observerly/nocturnal/pkg/twilight"
+)
+
+// The header of the table of the rise, maximum and set of a body:
+var POSITION_HEADER = []string{"event", "time", "alt", "az", "ra", "dec"}
+
+// nocturnal sun, as GET /api/v2/sun:
+func runSun(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	fs, f := newFlagSet("sun", stderr)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	o, location, err := f.observer()
+
+	if err != nil {
+		return err
+	}
+
+	res, err := sun.GetSolarTransit(ctx, o)
+
+	if err != nil {
+		return err
+	}
+
+	t := table{header: POSITION_HEADER}
+
+	for _, event := range []struct {
+		name       string
+		properties sun.Properties
+	}{{"rise", res.Rise}, {"maximum", res.Maximum}, {"set", res.Set}} {
+		p := event.properties
+
+		t.append(event.name, formatTime(p.UTC, location), formatFloat(p.Altitude), formatFloat(p.Azimuth), formatFloat(p.RightAscension), formatFloat(p.Declination))
+	}
+
+	return write(stdout, f.output, res, t)
+}
+
+// nocturnal moon, as GET /api/v2/moon:
+func runMoon(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	fs, f := newFlagSet("moon", stderr)
+
+	next := fs.Bool("next", false, "Search forward for the next rise, maximum and set, rather than those on the day of --datetime")
+
+	days := fs.Int("days", moon.MAX_SEARCH_DAYS, "Number of days to search forward with --next")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	o, location, err := f.observer()
+
+	if err != nil {
+		return err
+	}
+
+	var res moon.Response
+
+	if *next {
+		res, err = moon.SearchLunarTransit(ctx, o, *days)
+	} else {
+		res, err = moon.GetLunarTransit(ctx, o)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	t := table{header: append(append([]string{}, POSITION_HEADER...), "illumination")}
+
+	for _, event := range []struct {
+		name       string
+		properties *moon.Properties
+	}{{"rise", res.Rise}, {"maximum", res.Maximum}, {"set", res.Set}} {
+		p := event.properties
+
+		// The event does not occur on the day (or within the days searched):
+		if p == nil {
+			t.append(event.name, NONE, NONE, NONE, NONE, NONE, NONE)
+			continue
+		}
+
+		t.append(event.name, formatTime(p.UTC, location), formatFloat(p.Altitude), formatFloat(p.Azimuth), formatFloat(p.RightAscension), formatFloat(p.Declination), formatFloat(p.Illumination))
+	}
+
+	return write(stdout, f.output, res, t)
+}
+
+// nocturnal twilight, as GET /api/v2/twilight:
+func runTwilight(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	fs, f := newFlagSet("twilight", stderr)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	o, location, err := f.observer()
+
+	if err != nil {
+		return err
+	}
+
+	res, err := twilight.GetTwilightPeriods(ctx, o)
+
+	if err != nil {
+		return err
+	}
+
+	t := table{header: []string{"twilight", "from", "until", "duration", "horizon"}}
+
+	for _, period := range []struct {
+		name   string
+		period twilight.Period
+	}{{"civil", res.Civil}, {"nautical", res.Nautical}, {"astronomical", res.Astronomical}} {
+		p := period.period
+
+		t.append(period.name, formatTime(p.From, location), formatTime(p.Until, location), formatFloat(p.Duration), strconv.Itoa(p.Horizon))
+	}
+
+	return write(stdout, f.output, res, t)
+}
+
+// nocturnal transit, as GET /api/v2/transit:
+func runTransit(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	fs, f := newFlagSet("transit", stderr)
+
+	ra := fs.Float64("ra", 0, "Right ascension of the target (in degrees)")
+
+	dec := fs.Float64("dec", 0, "Declination of the target (in degrees)")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *ra < 0 || *ra >= 360 {
+		return fmt.Errorf("ra: %v must be a number between 0 and 360", *ra)
+	}
+
+	if *dec < -90 || *dec > 90 {
+		return fmt.Errorf("dec: %v must be a number between -90 and 90", *dec)
+	}
+
+	o, location, err := f.observer()
+
+	if err != nil {
+		return err
+	}
+
+	res, err := transit.GetObjectTransit(ctx, o, dusk.EquatorialCoordinate{RightAscension: *ra, Declination: *dec})
+
+	if err != nil {
+		return err
+	}
+
+	// The path of the target is only output as JSON:
+	t := table{header: append(append([]string{}, POSITION_HEADER...), "separation")}
+
+	for _, event := range []struct {
+		name       string
+		properties *transit.Properties
+	}{{"rise", res.Rise}, {"maximum", res.Maximum}, {"set", res.Set}} {
+		p := event.properties
+
+		// The event does not occur on the day, e.g., for a circumpolar target:
+		if p == nil {
+			t.append(event.name, NONE, NONE, NONE, NONE, NONE, NONE)
+			continue
+		}
+
+		t.append(event.name, formatTime(p.UTC, location), formatFloat(p.Altitude), formatFloat(p.Azimuth), formatFloat(p.RightAscension), formatFloat(p.Declination), formatFloat(p.Separation))
+	}
+
+	return write(stdout, f.output, res, t)
+}
+
+// nocturnal night, from sunset to sunrise:
+func runNight(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	fs, f := newFlagSet("night", stderr)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	o, location, err := f.observer()
+
+	if err != nil {
+		return err
+	}
+
+	res, err := GetNight(ctx, o)
+
+	if err != nil {
+		return err
+	}
+
+	t := table{header: []string{"event", "time"}}
+
+	for _, event := range res.Events() {
+		t.append(event.name, formatTime(event.datetime, location))
+	}
+
+	return write(stdout, f.output, res, t)
+}
+
+// nocturnal serve, as the nocturnal server:
+func runServe(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	fs := flag.NewFlagSet("nocturnal serve", flag.ContinueOnError)
+
+	fs.SetOutput(stderr)
+
+	port := fs.String("port", "", "Port to listen on, e.g., :8103. Overrides the configured port.")
+
+	file := fs.String("config", os.Getenv("CONFIG_FILE"), "Path to a YAML config file. Default is the CONFIG_FILE environment variable.")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// Load the configuration from the config file (if any) and the environment:
+	cfg, err := config.Load(*file)
+
+	if err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Create the structured JSON logger, and use it for any remaining standard library logs:
+	logger, _ := logging.NewFromLevel(stdout, cfg.Log.Level)
+
+	slog.SetDefault(logger)
+
+	return app.Run(ctx, cfg, logger, *port)
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/observerly/nocturnal/pkg/observer"
+)
+
+// The layouts of a --datetime without an offset, in the --tz of the observer:
+var DATETIME_LAYOUTS = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// observerFlags are the flags of the observer (and output format) shared by every computing subcommand:
+type observerFlags struct {
+	latitude  float64
+	longitude float64
+	elevation float64
+	datetime  string
+	timezone  string
+	output    string
+}
+
+// newFlagSet returns the flag set of the subcommand, with the flags of the observer:
+func newFlagSet(name string, stderr io.Writer) (*flag.FlagSet, *observerFlags) {
+	fs := flag.NewFlagSet("nocturnal "+name, flag.ContinueOnError)
+
+	fs.SetOutput(stderr)
+
+	f := &observerFlags{}
+
+	fs.Float64Var(&f.latitude, "lat", 0, "Latitude of the observer (in degrees north of the equator)")
+	fs.Float64Var(&f.longitude, "lon", 0, "Longitude of the observer (in degrees east of the Greenwich meridian)")
+	fs.Float64Var(&f.elevation, "elevation", 0, "Elevation of the observer (in metres above sea level)")
+	fs.StringVar(&f.datetime, "datetime", "", "Datetime of the observation, as RFC3339 (e.g., 2021-05-14T00:00:00Z), or a local date (e.g., 2021-05-14) or datetime (e.g., 2021-05-14T21:00) in --tz. Default is now.")
+	fs.StringVar(&f.timezone, "tz", "", "IANA timezone of the local times, e.g., Pacific/Honolulu. Default is the timezone at the coordinates.")
+	fs.StringVar(&f.output, "output", OUTPUT_TABLE, "Output format: table, json or csv")
+
+	return fs, f
+}
+
+// parseDatetime parses the datetime as RFC3339 (in its own offset, as the API does), or as a local date or datetime
+// in the location:
+func parseDatetime(d string, location *time.Location) (time.Time, error) {
+	if datetime, err := time.Parse(time.RFC3339, d); err == nil {
+		return datetime, nil
+	}
+
+	for _, layout := range DATETIME_LAYOUTS {
+		if datetime, err := time.ParseInLocation(layout, d, location); err == nil {
+			return datetime, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("datetime: %q must be RFC3339, or a date (e.g., 2021-05-14) or datetime (e.g., 2021-05-14T21:00)", d)
+}
+
+// observer returns the observer of the flags, with the location that local times are output in:
+func (f *observerFlags) observer() (observer.Observer, *time.Location, error) {
+	if err := validateOutput(f.output); err != nil {
+		return observer.Observer{}, nil, err
+	}
+
+	o := observer.New(time.Time{}, f.longitude, f.latitude, f.elevation)
+
+	if err := o.Validate(); err != nil {
+		return observer.Observer{}, nil, err
+	}
+
+	location, err := o.Location()
+
+	if f.timezone != "" {
+		location, err = time.LoadLocation(f.timezone)
+	}
+
+	if err != nil {
+		return observer.Observer{}, nil, fmt.Errorf("tz: %w", err)
+	}
+
+	// The API parses the datetime as RFC3339, i.e., to the whole second:
+	o.Datetime = time.Now().In(location).Truncate(time.Second)
+
+	if f.datetime != "" {
+		o.Datetime, err = parseDatetime(f.datetime, location)
+	}
+
+	return o, location, err
+}
@@ -0,0 +1,92 @@
+// Command nocturnal computes the ephemerides of the Nocturnal API offline, from the same computation code that the
+// API responds with, e.g., for observers in the field without connectivity:
+//
+//	nocturnal night --lat 19.798484 --lon -155.468094 --datetime 2021-05-14
+//
+// It can also serve the API itself, as the nocturnal server does:
+//
+//	nocturnal serve --port :8103
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// command is a subcommand of the CLI, run with its arguments (after the name of the subcommand):
+type command struct {
+	name        string
+	description string
+	run         func(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error
+}
+
+var commands = []command{
+	{"sun", "The rise, maximum and set of the Sun", runSun},
+	{"moon", "The rise, maximum and set of the Moon", runMoon},
+	{"twilight", "The civil, nautical and astronomical twilight", runTwilight},
+	{"transit", "The rise, maximum and set of a target at --ra and --dec", runTransit},
+	{"night", "Every event of the night, from sunset to sunrise", runNight},
+	{"serve", "Serve the HTTP (and gRPC) API", runServe},
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: nocturnal <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.description)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'nocturnal <command> --help' for the flags of a command.")
+}
+
+// run runs the subcommand named by the first argument:
+func run(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	if len(args) == 0 {
+		usage(stderr)
+		return errors.New("missing command")
+	}
+
+	switch args[0] {
+	case "help", "-h", "-help", "--help":
+		usage(stdout)
+		return nil
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd.run(ctx, args[1:], stdout, stderr)
+		}
+	}
+
+	usage(stderr)
+
+	return fmt.Errorf("unknown command %q", args[0])
+}
+
+func main() {
+	// Stop (e.g., drain the server) on SIGTERM or SIGINT, before exiting:
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+
+	err := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+
+	stop()
+
+	// The usage of the command has already been printed for a request for help:
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "nocturnal: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/observerly/dusk/pkg/dusk"
+	"github.com/observerly/nocturnal/pkg/moon"
+	"github.com/observerly/nocturnal/pkg/observer"
+	"github.com/observerly/nocturnal/pkg/sun"
+	"github.com/observerly/nocturnal/pkg/transit"
+	"github.com/observerly/nocturnal/pkg/twilight"
+	"github.com/stretchr/testify/assert"
+)
+
+var o = observer.New(time.Date(2021, 5, 14, 0, 0, 0, 0, time.UTC), -155.468094, 19.798484, 0)
+
+var args = []string{"--lat", "19.798484", "--lon", "-155.468094", "--datetime", "2021-05-14T00:00:00Z"}
+
+// runCommand runs the command with the arguments, returning its output:
+func runCommand(t *testing.T, command ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	err := run(context.Background(), command, &stdout, &stderr)
+
+	return stdout.String(), err
+}
+
+func TestRunUnknownCommand(t *testing.T) {
+	_, err := runCommand(t, "sunset")
+
+	assert.EqualError(t, err, `unknown command "sunset"`)
+
+	_, err = runCommand(t)
+
+	assert.NotNil(t, err)
+
+	out, err := runCommand(t, "help")
+
+	// Assert that the usage lists every command:
+	assert.Nil(t, err)
+
+	for _, cmd := range commands {
+		assert.Contains(t, out, cmd.name)
+	}
+}
+
+func TestRunSunJSON(t *testing.T) {
+	out, err := runCommand(t, append([]string{"sun", "--output", "json"}, args...)...)
+
+	assert.Nil(t, err)
+
+	res, _ := sun.GetSolarTransit(context.Background(), o)
+
+	expected, _ := json.Marshal(res)
+
+	// Assert that the JSON output is exactly the response of the API:
+	assert.JSONEq(t, string(expected), out)
+}
+
+func TestRunMoonJSON(t *testing.T) {
+	out, err := runCommand(t, append([]string{"moon", "--output", "json"}, args...)...)
+
+	assert.Nil(t, err)
+
+	res, _ := moon.GetLunarTransit(context.Background(), o)
+
+	expected, _ := json.Marshal(res)
+
+	assert.JSONEq(t, string(expected), out)
+
+	out, err = runCommand(t, append([]string{"moon", "--output", "json", "--next", "--days", "7"}, args...)...)
+
+	assert.Nil(t, err)
+
+	res, _ = moon.SearchLunarTransit(context.Background(), o, 7)
+
+	expected, _ = json.Marshal(res)
+
+	assert.JSONEq(t, string(expected), out)
+
+	_, err = runCommand(t, append([]string{"moon", "--next", "--days", "31"}, args...)...)
+
+	assert.NotNil(t, err)
+}
+
+func TestRunTransitJSON(t *testing.T) {
+	out, err := runCommand(t, append([]string{"transit", "--output", "json", "--ra", "88.792958", "--dec", "7.407064"}, args...)...)
+
+	assert.Nil(t, err)
+
+	res, _ := transit.GetObjectTransit(context.Background(), o, dusk.EquatorialCoordinate{RightAscension: 88.792958, Declination: 7.407064})
+
+	expected, _ := json.Marshal(res)
+
+	assert.JSONEq(t, string(expected), out)
+
+	_, err = runCommand(t, append([]string{"transit", "--ra", "360"}, args...)...)
+
+	assert.NotNil(t, err)
+}
+
+func TestRunTwilightCSV(t *testing.T) {
+	out, err := runCommand(t, append([]string{"twilight", "--output", "csv"}, args...)...)
+
+	assert.Nil(t, err)
+
+	records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+
+	assert.Nil(t, err)
+
+	res, _ := twilight.GetTwilightPeriods(context.Background(), o)
+
+	location, _ := time.LoadLocation("Pacific/Honolulu")
+
+	// Assert that the CSV output has a header, and a row for each twilight in the timezone at the coordinates:
+	assert.Equal(t, []string{"twilight", "from", "until", "duration", "horizon"}, records[0])
+	assert.Equal(t, []string{"civil", formatTime(res.Civil.From, location), formatTime(res.Civil.Until, location), formatFloat(res.Civil.Duration), "-6"}, records[1])
+	assert.Len(t, records, 4)
+}
+
+func TestRunSunTable(t *testing.T) {
+	out, err := runCommand(t, append([]string{"sun", "--tz", "UTC"}, args...)...)
+
+	assert.Nil(t, err)
+
+	res, _ := sun.GetSolarTransit(context.Background(), o)
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+
+	// Assert that the table has a header, and a row for each event in the --tz:
+	assert.Len(t, lines, 4)
+	assert.True(t, strings.HasPrefix(lines[0], "EVENT"))
+	assert.Contains(t, lines[1], res.Rise.UTC.Format(time.RFC3339))
+}
+
+func TestRunInvalidObserver(t *testing.T) {
+	_, err := runCommand(t, "sun", "--lat", "91")
+
+	assert.EqualError(t, err, "latitude: 91 must be a number between -90 and 90")
+
+	_, err = runCommand(t, "sun", "--tz", "Mars/Olympus_Mons")
+
+	assert.NotNil(t, err)
+
+	_, err = runCommand(t, "sun", "--datetime", "yesterday")
+
+	assert.NotNil(t, err)
+
+	_, err = runCommand(t, "sun", "--output", "xml")
+
+	assert.NotNil(t, err)
+}
+
+func TestParseDatetime(t *testing.T) {
+	location, _ := time.LoadLocation("Pacific/Honolulu")
+
+	datetime, err := parseDatetime("2021-05-14T00:00:00Z", location)
+
+	// Assert that an RFC3339 datetime keeps its own offset:
+	assert.Nil(t, err)
+	assert.Equal(t, time.UTC, datetime.Location())
+
+	datetime, err = parseDatetime("2021-05-14T21:00", location)
+
+	// Assert that a local datetime is in the location:
+	assert.Nil(t, err)
+	assert.Equal(t, "2021-05-14T21:00:00-10:00", datetime.Format(time.RFC3339))
+
+	datetime, err = parseDatetime("2021-05-14", location)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "2021-05-14T00:00:00-10:00", datetime.Format(time.RFC3339))
+}
+
+func TestGetNight(t *testing.T) {
+	location, _ := time.LoadLocation("Pacific/Honolulu")
+
+	n, err := GetNight(context.Background(), observer.New(time.Date(2021, 5, 14, 0, 0, 0, 0, location), -155.468094, 19.798484, 0))
+
+	assert.Nil(t, err)
+
+	// Assert that the night is from the sunset of the local day to the sunrise of the next:
+	assert.Equal(t, "2021-05-14", n.Sunset.UTC.In(location).Format("2006-01-02"))
+	assert.Equal(t, "2021-05-15", n.Sunrise.UTC.In(location).Format("2006-01-02"))
+	assert.True(t, n.Sunset.UTC.Before(n.Civil.From))
+	assert.True(t, n.Civil.Until.Before(n.Sunrise.UTC))
+
+	events := n.Events()
+
+	// Assert that the events are in chronological order, from sunset to sunrise:
+	assert.Equal(t, "sunset", events[0].name)
+	assert.Equal(t, "sunrise", events[len(events)-1].name)
+
+	for i := 1; i < len(events); i++ {
+		assert.False(t, events[i].datetime.Before(events[i-1].datetime))
+	}
+
+	// Assert that the Moon sets during the night (the Moon set at 21:42 local time):
+	assert.Nil(t, n.Moonrise)
+	assert.NotNil(t, n.Moonset)
+	assert.Greater(t, n.Moon.Illumination, 0.0)
+}
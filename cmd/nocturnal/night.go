@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/observerly/nocturnal/pkg/moon"
+	"github.com/observerly/nocturnal/pkg/observer"
+	"github.com/observerly/nocturnal/pkg/sun"
+	"github.com/observerly/nocturnal/pkg/twilight"
+)
+
+type Night struct {
+	Observer observer.Observer `json:"observer"`
+	// The position of the Sun at its set, at the start of the night:
+	Sunset sun.Properties `json:"sunset"`
+	// The position of the Sun at its rise, at the end of the night:
+	Sunrise sun.Properties `json:"sunrise"`
+	// The twilight periods of the night:
+	Civil        twilight.Period `json:"civil"`
+	Nautical     twilight.Period `json:"nautical"`
+	Astronomical twilight.Period `json:"astronomical"`
+	// The properties of the Moon at its rise and set, or nil if it does not rise or set during the night:
+	Moonrise *moon.Properties `json:"moonrise"`
+	Moonset  *moon.Properties `json:"moonset"`
+	// The properties of the Moon at the middle of the night, e.g., its illumination:
+	Moon moon.Properties `json:"moon"`
+}
+
+// event is an event of the night, for the table and CSV outputs:
+type event struct {
+	name     string
+	datetime time.Time
+}
+
+// GetNight returns every event of the night of the observer's datetime, from sunset to sunrise, composed from the
+// twilight, solar and lunar computations of the API:
+func GetNight(ctx context.Context, o observer.Observer) (Night, error) {
+	t, err := twilight.GetTwilightPeriods(ctx, o)
+
+	if err != nil {
+		return Night{}, err
+	}
+
+	sunset, err := getSunset(ctx, o, t.Civil.From)
+
+	if err != nil {
+		return Night{}, err
+	}
+
+	sunrise, err := getSunrise(ctx, o, t.Civil.Until)
+
+	if err != nil {
+		return Night{}, err
+	}
+
+	// The next rise and set of the Moon after sunset, within a day:
+	m, err := moon.SearchLunarTransit(ctx, observer.New(sunset.LCT, o.Longitude, o.Latitude, o.Elevation), 1)
+
+	if err != nil {
+		return Night{}, err
+	}
+
+	middle := sunset.LCT.Add(sunrise.LCT.Sub(sunset.LCT) / 2)
+
+	return Night{
+		Observer:     o,
+		Sunset:       sunset,
+		Sunrise:      sunrise,
+		Civil:        t.Civil,
+		Nautical:     t.Nautical,
+		Astronomical: t.Astronomical,
+		Moonrise:     getDuringNight(m.Rise, sunrise.UTC),
+		Moonset:      getDuringNight(m.Set, sunrise.UTC),
+		Moon:         moon.GetStandardLunarProperties(middle, o.Longitude, o.Latitude, o.Elevation),
+	}, nil
+}
+
+// getSolarTransits returns the rise, maximum and set of the Sun on the day before, the day of, and the day after the
+// datetime, N.B. the day that dusk solves for is not necessarily the local day of the datetime:
+func getSolarTransits(ctx context.Context, o observer.Observer, datetime time.Time) ([]sun.Response, error) {
+	transits := []sun.Response{}
+
+	for _, days := range []int{-1, 0, 1} {
+		res, err := sun.GetSolarTransit(ctx, observer.New(datetime.AddDate(0, 0, days), o.Longitude, o.Latitude, o.Elevation))
+
+		if err != nil {
+			return nil, err
+		}
+
+		transits = append(transits, res)
+	}
+
+	return transits, nil
+}
+
+// getSunset returns the position of the Sun at its last set before civil dusk:
+func getSunset(ctx context.Context, o observer.Observer, dusk time.Time) (sun.Properties, error) {
+	transits, err := getSolarTransits(ctx, o, dusk)
+
+	if err != nil {
+		return sun.Properties{}, err
+	}
+
+	for i := len(transits) - 1; i >= 0; i-- {
+		if !transits[i].Set.UTC.After(dusk) {
+			return transits[i].Set, nil
+		}
+	}
+
+	return sun.Properties{}, fmt.Errorf("the Sun does not set before %s", dusk.Format(time.RFC3339))
+}
+
+// getSunrise returns the position of the Sun at its first rise after civil dawn:
+func getSunrise(ctx context.Context, o observer.Observer, dawn time.Time) (sun.Properties, error) {
+	transits, err := getSolarTransits(ctx, o, dawn)
+
+	if err != nil {
+		return sun.Properties{}, err
+	}
+
+	for _, transit := range transits {
+		if !transit.Rise.UTC.Before(dawn) {
+			return transit.Rise, nil
+		}
+	}
+
+	return sun.Properties{}, fmt.Errorf("the Sun does not rise after %s", dawn.Format(time.RFC3339))
+}
+
+// getDuringNight returns the properties of the Moon if it is before sunrise, or otherwise nil:
+func getDuringNight(p *moon.Properties, sunrise time.Time) *moon.Properties {
+	if p == nil || !p.UTC.Before(sunrise) {
+		return nil
+	}
+
+	return p
+}
+
+// Events returns the events of the night, in chronological order:
+func (n Night) Events() []event {
+	events := []event{
+		{"sunset", n.Sunset.UTC},
+		{"civil dusk", n.Civil.From},
+		{"nautical dusk", n.Nautical.From},
+		{"astronomical dusk", n.Astronomical.From},
+		{"astronomical dawn", n.Astronomical.Until},
+		{"nautical dawn", n.Nautical.Until},
+		{"civil dawn", n.Civil.Until},
+		{"sunrise", n.Sunrise.UTC},
+	}
+
+	if n.Moonrise != nil {
+		events = append(events, event{"moonrise", n.Moonrise.UTC})
+	}
+
+	if n.Moonset != nil {
+		events = append(events, event{"moonset", n.Moonset.UTC})
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].datetime.Before(events[j].datetime)
+	})
+
+	return events
+}
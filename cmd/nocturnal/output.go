@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	OUTPUT_TABLE = "table"
+	OUTPUT_JSON  = "json"
+	OUTPUT_CSV   = "csv"
+)
+
+// The placeholder of an event that does not occur, e.g., a moonrise:
+const NONE = "-"
+
+func validateOutput(output string) error {
+	switch output {
+	case OUTPUT_TABLE, OUTPUT_JSON, OUTPUT_CSV:
+		return nil
+	default:
+		return fmt.Errorf("output: %q must be one of table, json or csv", output)
+	}
+}
+
+// table is the tabular form of a response, for the table and CSV outputs:
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+func (t *table) append(row ...string) {
+	t.rows = append(t.rows, row)
+}
+
+// formatTime formats the datetime as RFC3339, in the location:
+func formatTime(datetime time.Time, location *time.Location) string {
+	return datetime.In(location).Format(time.RFC3339)
+}
+
+// formatFloat formats the float to 6 decimal places (i.e., to ~0.004 arcseconds, for degrees):
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', 6, 64)
+}
+
+// write writes the response in the output format, where the JSON output is exactly the response of the API, and the
+// table and CSV outputs are its tabular form:
+func write(w io.Writer, output string, response any, t table) error {
+	switch output {
+	case OUTPUT_JSON:
+		encoder := json.NewEncoder(w)
+
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(response)
+	case OUTPUT_CSV:
+		writer := csv.NewWriter(w)
+
+		if err := writer.Write(t.header); err != nil {
+			return err
+		}
+
+		if err := writer.WriteAll(t.rows); err != nil {
+			return err
+		}
+
+		return writer.Error()
+	default:
+		writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+		fmt.Fprintln(writer, strings.ToUpper(strings.Join(t.header, "\t")))
+
+		for _, row := range t.rows {
+			fmt.Fprintln(writer, strings.Join(row, "\t"))
+		}
+
+		return writer.Flush()
+	}
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"golang.org/x/exp/slog"
+
+	"github.com/observerly/nocturnal/internal/config"
+	"github.com/observerly/nocturnal/internal/router"
+	"github.com/observerly/nocturnal/internal/rpc"
+	"github.com/observerly/nocturnal/internal/server"
+	"github.com/observerly/nocturnal/internal/tracing"
+)
+
+// Run serves the HTTP API (on the address, or the configured address if empty), and the gRPC API alongside it (if
+// enabled), until the context is done, when both servers are drained and any buffered spans are flushed:
+func Run(ctx context.Context, cfg *config.Config, logger *slog.Logger, addr string) error {
+	ctx, stop := context.WithCancel(ctx)
+
+	defer stop()
+
+	// Setup OpenTelemetry tracing, exporting spans to the configured exporters (if any):
+	shutdown, err := tracing.Setup(ctx, cfg.Tracing)
+
+	if err != nil {
+		return fmt.Errorf("tracing setup failed: %w", err)
+	}
+
+	r := router.New(cfg, router.Dependencies{Logger: logger})
+
+	options := server.Options{
+		Addr:              cfg.Addr(),
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+		ShutdownTimeout:   cfg.Server.ShutdownTimeout,
+		MaxHeaderBytes:    cfg.Limits.MaxHeaderBytes,
+	}
+
+	if addr != "" {
+		options.Addr = addr
+	}
+
+	srv := server.New(r, options)
+
+	// Serve the gRPC API on its own port alongside the HTTP API (if enabled), draining both on shutdown:
+	grpcErrs := make(chan error, 1)
+
+	if cfg.GRPC.Port != 0 {
+		grpcSrv := rpc.New(logger)
+
+		logger.Info("Listening and serving gRPC", "addr", cfg.GRPCAddr())
+
+		go func() {
+			err := rpc.ListenAndServe(ctx, grpcSrv, cfg.GRPCAddr(), options.ShutdownTimeout)
+
+			// Take the HTTP server down with the gRPC server, should it fail:
+			if err != nil {
+				stop()
+			}
+
+			grpcErrs <- err
+		}()
+	} else {
+		grpcErrs <- nil
+	}
+
+	logger.Info("Listening and serving HTTP", "addr", options.Addr)
+
+	// Listen on port
+	err = server.ListenAndServe(ctx, srv, options.ShutdownTimeout)
+
+	// Take the gRPC server down with the HTTP server, should it fail:
+	stop()
+
+	if err := errors.Join(err, <-grpcErrs); err != nil {
+		return fmt.Errorf("server failed: %w", err)
+	}
+
+	// Flush any buffered spans to the exporters, within the shutdown timeout:
+	flushCtx, cancel := context.WithTimeout(context.Background(), options.ShutdownTimeout)
+
+	defer cancel()
+
+	if err := shutdown(flushCtx); err != nil {
+		logger.Error("Tracing shutdown failed", "error", err)
+	}
+
+	logger.Info("Server shut down gracefully")
+
+	return nil
+}
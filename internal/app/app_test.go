@@ -0,0 +1,37 @@
+package app
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/observerly/nocturnal/internal/config"
+	logging "github.com/observerly/nocturnal/internal/logger"
+)
+
+func TestRun(t *testing.T) {
+	logger, _ := logging.NewFromLevel(io.Discard, "info")
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	errs := make(chan error, 1)
+
+	go func() {
+		errs <- Run(ctx, config.Default(), logger, "127.0.0.1:0")
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+
+	cancel()
+
+	// Assert that the servers shut down gracefully once the context is done:
+	select {
+	case err := <-errs:
+		assert.Nil(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after the context was done")
+	}
+}
@@ -0,0 +1,42 @@
+package cache
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// Store is implemented by cache backends, e.g., the in-memory LRU, or a shared Redis-compatible store (where Get and
+// Set map directly onto the GET and SET ... EX commands), so that cached responses can be shared between instances.
+type Store interface {
+	// Get returns the value for the key, and whether or not it exists (and has not yet expired):
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores the value for the key, to expire after the ttl:
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+type Stats struct {
+	Hits   uint64 `json:"hits"`
+	Misses uint64 `json:"misses"`
+}
+
+// Metrics counts the cache hits and misses, and is safe for concurrent use:
+type Metrics struct {
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+func (m *Metrics) Hit() {
+	m.hits.Add(1)
+}
+
+func (m *Metrics) Miss() {
+	m.misses.Add(1)
+}
+
+func (m *Metrics) Stats() Stats {
+	return Stats{
+		Hits:   m.hits.Load(),
+		Misses: m.misses.Load(),
+	}
+}
@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var ctx = context.Background()
+
+func TestLRUGetSet(t *testing.T) {
+	lru := NewLRU(2)
+
+	err := lru.Set(ctx, "/api/v2/moon", []byte("{}"), time.Minute)
+	assert.Nil(t, err)
+
+	value, exists, err := lru.Get(ctx, "/api/v2/moon")
+
+	// Assert we get back the value we stored:
+	assert.Nil(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, []byte("{}"), value)
+}
+
+func TestLRUGetMissing(t *testing.T) {
+	lru := NewLRU(2)
+
+	value, exists, err := lru.Get(ctx, "/api/v2/moon")
+
+	// Assert we get nothing back for a key we never stored:
+	assert.Nil(t, err)
+	assert.False(t, exists)
+	assert.Nil(t, value)
+}
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	lru := NewLRU(2)
+
+	_ = lru.Set(ctx, "/api/v2/moon", []byte("moon"), time.Minute)
+	_ = lru.Set(ctx, "/api/v2/sun", []byte("sun"), time.Minute)
+
+	// Use the Moon, so that the Sun becomes the least recently used:
+	_, _, _ = lru.Get(ctx, "/api/v2/moon")
+
+	_ = lru.Set(ctx, "/api/v2/transit", []byte("transit"), time.Minute)
+
+	_, exists, _ := lru.Get(ctx, "/api/v2/sun")
+	assert.False(t, exists)
+
+	_, exists, _ = lru.Get(ctx, "/api/v2/moon")
+	assert.True(t, exists)
+
+	_, exists, _ = lru.Get(ctx, "/api/v2/transit")
+	assert.True(t, exists)
+
+	assert.Equal(t, 2, lru.Len())
+}
+
+func TestLRUExpires(t *testing.T) {
+	lru := NewLRU(2)
+
+	now := time.Date(2021, 5, 14, 0, 0, 0, 0, time.UTC)
+
+	lru.now = func() time.Time { return now }
+
+	_ = lru.Set(ctx, "/api/v2/moon", []byte("moon"), time.Minute)
+
+	// Move the clock on beyond the ttl of the entry:
+	now = now.Add(2 * time.Minute)
+
+	_, exists, _ := lru.Get(ctx, "/api/v2/moon")
+	assert.False(t, exists)
+	assert.Equal(t, 0, lru.Len())
+}
+
+func TestMetricsStats(t *testing.T) {
+	metrics := &Metrics{}
+
+	metrics.Hit()
+	metrics.Miss()
+	metrics.Miss()
+
+	assert.Equal(t, Stats{Hits: 1, Misses: 2}, metrics.Stats())
+}
@@ -0,0 +1,846 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/observerly/nocturnal/internal/logger"
+)
+
+type ServerConfig struct {
+	// The TCP port to listen on:
+	Port int `yaml:"port"`
+	// The maximum duration for reading the entire request, including the body:
+	ReadTimeout time.Duration `yaml:"readTimeout"`
+	// The maximum duration for reading the request headers:
+	ReadHeaderTimeout time.Duration `yaml:"readHeaderTimeout"`
+	// The maximum duration before timing out writes of the response:
+	WriteTimeout time.Duration `yaml:"writeTimeout"`
+	// The maximum duration to wait for the next request when keep-alives are enabled:
+	IdleTimeout time.Duration `yaml:"idleTimeout"`
+	// The maximum duration to wait for in-flight requests to drain on shutdown:
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout"`
+}
+
+type GRPCConfig struct {
+	// The TCP port for the gRPC server to listen on, alongside the HTTP server (0 disables the gRPC server):
+	Port int `yaml:"port"`
+}
+
+type CORSConfig struct {
+	// The origins that are allowed to make cross-origin requests, either exactly (e.g., "https://observerly.com"), as a
+	// wildcard subdomain pattern (e.g., "https://*.observerly.com"), or "*" for any origin (without credentials):
+	AllowOrigins []string `yaml:"allowOrigins"`
+	// The methods that are allowed in cross-origin requests:
+	AllowMethods []string `yaml:"allowMethods"`
+	// The request headers that are allowed in cross-origin requests:
+	AllowHeaders []string `yaml:"allowHeaders"`
+	// The response headers that cross-origin requests are allowed to read:
+	ExposeHeaders []string `yaml:"exposeHeaders"`
+	// Whether cross-origin requests may include credentials (e.g., cookies):
+	AllowCredentials bool `yaml:"allowCredentials"`
+	// How long the results of a preflight request may be cached for by the client:
+	MaxAge time.Duration `yaml:"maxAge"`
+}
+
+type HSTSConfig struct {
+	// How long browsers should only connect over HTTPS, where 0 omits the Strict-Transport-Security header:
+	MaxAge time.Duration `yaml:"maxAge"`
+	// Whether the policy also applies to every subdomain:
+	IncludeSubDomains bool `yaml:"includeSubDomains"`
+	// Whether to consent to inclusion in the browsers' HSTS preload lists (requiring a maxAge of at least a year and
+	// includeSubDomains):
+	Preload bool `yaml:"preload"`
+}
+
+type HelmetRouteConfig struct {
+	// The Content-Security-Policy directives for the route, replacing the default policy when set:
+	ContentSecurityPolicy map[string][]string `yaml:"contentSecurityPolicy"`
+	// The headers to override for the route, where an empty value removes the header:
+	Headers map[string]string `yaml:"headers"`
+}
+
+type HelmetConfig struct {
+	// The Content-Security-Policy directives and their sources, e.g., default-src: ["'self'"]:
+	ContentSecurityPolicy map[string][]string `yaml:"contentSecurityPolicy"`
+	HSTS                  HSTSConfig          `yaml:"hsts"`
+	// The Permissions-Policy features and their allowed origins, where a feature without origins is disabled:
+	PermissionsPolicy map[string][]string `yaml:"permissionsPolicy"`
+	// The headers to override (or add), where an empty value removes the header:
+	Headers map[string]string `yaml:"headers"`
+	// The overrides for the routes beginning with each path prefix, e.g., "/docs":
+	Routes map[string]HelmetRouteConfig `yaml:"routes"`
+}
+
+type SentryConfig struct {
+	// The Sentry DSN, Sentry is only initialised in release mode when the DSN is set:
+	DSN string `yaml:"dsn"`
+	// The fraction of transactions that are sampled for performance monitoring, between [0, 1]:
+	TracesSampleRate float64 `yaml:"tracesSampleRate"`
+}
+
+type TracingConfig struct {
+	// The exporters that sampled spans are sent to, i.e., any of "otlp" and "sentry", where none disables tracing:
+	Exporters []string `yaml:"exporters"`
+	// The OTLP/HTTP collector endpoint, as host:port (e.g., "localhost:4318"):
+	Endpoint string `yaml:"endpoint"`
+	// Whether to connect to the OTLP/HTTP collector without TLS:
+	Insecure bool `yaml:"insecure"`
+	// The fraction of traces that are sampled (unless the caller has already sampled the trace), between [0, 1]:
+	SampleRate float64 `yaml:"sampleRate"`
+	// The service name that every span is reported under:
+	ServiceName string `yaml:"serviceName"`
+}
+
+// IsEnabled determines whether spans are exported to any exporter:
+func (t TracingConfig) IsEnabled() bool {
+	return len(t.Exporters) > 0
+}
+
+// HasExporter determines whether spans are exported to the named exporter, e.g., "sentry":
+func (t TracingConfig) HasExporter(name string) bool {
+	for _, exporter := range t.Exporters {
+		if exporter == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+type CacheConfig struct {
+	// The maximum number of responses held in the cache, where 0 disables the cache:
+	Size int `yaml:"size"`
+	// How long a response is cached for, by both the cache and by clients:
+	TTL time.Duration `yaml:"ttl"`
+	// The number of decimal places that the observer's latitude and longitude are rounded to:
+	CoordinatePrecision int `yaml:"coordinatePrecision"`
+	// The duration that the observer's datetime is rounded to:
+	DatetimePrecision time.Duration `yaml:"datetimePrecision"`
+}
+
+type RateLimitTier struct {
+	// The sustained number of requests allowed per client per minute, where 0 is unlimited:
+	RequestsPerMinute int `yaml:"requestsPerMinute"`
+	// The number of requests a client may make in a single burst (defaulting to the requests per minute when 0):
+	Burst int `yaml:"burst"`
+}
+
+type RateLimitConfig struct {
+	// The sustained number of requests allowed per anonymous client (by IP address) per minute, where 0 is unlimited:
+	RequestsPerMinute int `yaml:"requestsPerMinute"`
+	// The number of requests an anonymous client may make in a single burst:
+	Burst int `yaml:"burst"`
+	// The request header that clients present their API key in:
+	APIKeyHeader string `yaml:"apiKeyHeader"`
+	// The IP addresses or CIDR ranges of proxies (e.g., load balancers) trusted to set the X-Forwarded-For header:
+	TrustedProxies []string `yaml:"trustedProxies"`
+	// The named tiers of limits, e.g., "partner":
+	Tiers map[string]RateLimitTier `yaml:"tiers"`
+	// The API keys, and the name of the tier that each belongs to:
+	APIKeys map[string]string `yaml:"apiKeys"`
+}
+
+// IsEnabled determines whether any client is rate limited, or any API keys are configured:
+func (rl RateLimitConfig) IsEnabled() bool {
+	return rl.RequestsPerMinute > 0 || len(rl.APIKeys) > 0
+}
+
+type DeprecationConfig struct {
+	// When the version was deprecated, sent as the date of the Deprecation header:
+	DeprecatedAt time.Time `yaml:"deprecatedAt"`
+	// When the version will stop being served (if scheduled), announced in the Sunset header:
+	Sunset time.Time `yaml:"sunset"`
+	// When the version stops being served (if ever), after which it responds with a 410 Gone:
+	Cutoff time.Time `yaml:"cutoff"`
+	// The URL of the migration guide to the successor version (if any):
+	Documentation string `yaml:"documentation"`
+}
+
+type LimitsConfig struct {
+	// The maximum number of bytes the server will read parsing the request line and headers:
+	MaxHeaderBytes int `yaml:"maxHeaderBytes"`
+}
+
+type LogConfig struct {
+	// The minimum level of the structured logs, i.e., one of "debug", "info", "warn" or "error":
+	Level string `yaml:"level"`
+}
+
+type Config struct {
+	// The gin mode, i.e., one of "debug", "release" or "test":
+	Mode string `yaml:"mode"`
+	// The latest version of the API, e.g., "v2":
+	APIVersion string          `yaml:"apiVersion"`
+	Server     ServerConfig    `yaml:"server"`
+	GRPC       GRPCConfig      `yaml:"grpc"`
+	CORS       CORSConfig      `yaml:"cors"`
+	Helmet     HelmetConfig    `yaml:"helmet"`
+	Sentry     SentryConfig    `yaml:"sentry"`
+	Tracing    TracingConfig   `yaml:"tracing"`
+	Cache      CacheConfig     `yaml:"cache"`
+	RateLimit  RateLimitConfig `yaml:"rateLimit"`
+	// The deprecation policy of each version of the API that is not the latest, e.g., "v1":
+	Deprecation map[string]DeprecationConfig `yaml:"deprecation"`
+	Limits      LimitsConfig                 `yaml:"limits"`
+	Log         LogConfig                    `yaml:"log"`
+}
+
+// Default returns the default configuration, which every config file and environment variable overrides:
+func Default() *Config {
+	return &Config{
+		Mode:       "debug",
+		APIVersion: "v2",
+		Server: ServerConfig{
+			Port:              8103,
+			ReadTimeout:       15 * time.Second,
+			ReadHeaderTimeout: 5 * time.Second,
+			WriteTimeout:      60 * time.Second,
+			IdleTimeout:       120 * time.Second,
+			// N.B. Cloud Run sends SIGKILL 10 seconds after SIGTERM, so the shutdown timeout is just within that:
+			ShutdownTimeout: 9 * time.Second,
+		},
+		CORS: CORSConfig{
+			AllowOrigins: []string{
+				"https://observerly.com",
+				"https://app.observerly.com",
+				"https://vega.observerly.com",
+				"http://localhost:3001",
+			},
+			AllowMethods:     []string{"GET", "OPTIONS"},
+			AllowHeaders:     []string{"Origin", "Accept", "Content-Type", "Authorization", "Cache-Control", "X-Requested-With", "X-API-Key", "X-Request-ID"},
+			ExposeHeaders:    []string{"Cache-Control", "Content-Length", "Content-Type", "ETag", "X-Cache", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After", "X-Request-ID", "Deprecation", "Sunset", "Link"},
+			AllowCredentials: true,
+			MaxAge:           24 * time.Hour,
+		},
+		Helmet: HelmetConfig{
+			ContentSecurityPolicy: map[string][]string{
+				"default-src": {"'self'"},
+			},
+			HSTS: HSTSConfig{
+				MaxAge:            60 * 24 * time.Hour,
+				IncludeSubDomains: true,
+				Preload:           false,
+			},
+			PermissionsPolicy: map[string][]string{
+				"camera":      {},
+				"geolocation": {},
+				"microphone":  {},
+			},
+			Headers: map[string]string{},
+			Routes:  map[string]HelmetRouteConfig{},
+		},
+		Sentry: SentryConfig{
+			TracesSampleRate: 1.0,
+		},
+		Tracing: TracingConfig{
+			Exporters:   []string{},
+			Endpoint:    "localhost:4318",
+			Insecure:    false,
+			SampleRate:  0.1,
+			ServiceName: "nocturnal",
+		},
+		Cache: CacheConfig{
+			Size:                1024,
+			TTL:                 time.Minute,
+			CoordinatePrecision: 4,
+			DatetimePrecision:   time.Minute,
+		},
+		RateLimit: RateLimitConfig{
+			RequestsPerMinute: 0,
+			Burst:             0,
+			APIKeyHeader:      "X-API-Key",
+			TrustedProxies:    []string{},
+			Tiers:             map[string]RateLimitTier{},
+			APIKeys:           map[string]string{},
+		},
+		Deprecation: map[string]DeprecationConfig{
+			"v1": {
+				DeprecatedAt: time.Date(2023, time.February, 14, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		Limits: LimitsConfig{
+			MaxHeaderBytes: 1 << 20,
+		},
+		Log: LogConfig{
+			Level: "info",
+		},
+	}
+}
+
+// Load returns the default configuration, overridden by the YAML config file at the path (if any), and then by any
+// environment variables, and validates the result.
+func Load(path string) (*Config, error) {
+	cfg := Default()
+
+	if path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := cfg.loadEnv(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+func (cfg *Config) loadFile(path string) error {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	decoder := yaml.NewDecoder(f)
+
+	// Reject unknown keys, so that typos in the config file are not silently ignored:
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(cfg); err != nil {
+		return fmt.Errorf("config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func lookupString(key string, value *string) {
+	if v, exists := os.LookupEnv(key); exists {
+		*value = v
+	}
+}
+
+func lookupInt(key string, value *int) error {
+	v, exists := os.LookupEnv(key)
+
+	if !exists {
+		return nil
+	}
+
+	i, err := strconv.Atoi(v)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+
+	*value = i
+
+	return nil
+}
+
+func lookupBool(key string, value *bool) error {
+	v, exists := os.LookupEnv(key)
+
+	if !exists {
+		return nil
+	}
+
+	b, err := strconv.ParseBool(v)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+
+	*value = b
+
+	return nil
+}
+
+func lookupFloat(key string, value *float64) error {
+	v, exists := os.LookupEnv(key)
+
+	if !exists {
+		return nil
+	}
+
+	f, err := strconv.ParseFloat(v, 64)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+
+	*value = f
+
+	return nil
+}
+
+func lookupDuration(key string, value *time.Duration) error {
+	v, exists := os.LookupEnv(key)
+
+	if !exists {
+		return nil
+	}
+
+	d, err := time.ParseDuration(v)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+
+	*value = d
+
+	return nil
+}
+
+func lookupTime(key string, value *time.Time) error {
+	v, exists := os.LookupEnv(key)
+
+	if !exists {
+		return nil
+	}
+
+	t, err := time.Parse(time.RFC3339, v)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+
+	*value = t
+
+	return nil
+}
+
+func lookupList(key string, value *[]string) {
+	v, exists := os.LookupEnv(key)
+
+	if !exists {
+		return
+	}
+
+	list := []string{}
+
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+
+	*value = list
+}
+
+// lookupTiers parses a comma separated list of tiers, of the form name:requestsPerMinute[:burst]:
+func lookupTiers(key string, value *map[string]RateLimitTier) error {
+	list := []string{}
+
+	lookupList(key, &list)
+
+	for _, item := range list {
+		parts := strings.Split(item, ":")
+
+		if len(parts) < 2 || len(parts) > 3 {
+			return fmt.Errorf("%s: %q must be of the form name:requestsPerMinute[:burst]", key, item)
+		}
+
+		tier := RateLimitTier{}
+
+		var err error
+
+		if tier.RequestsPerMinute, err = strconv.Atoi(parts[1]); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+
+		if len(parts) == 3 {
+			if tier.Burst, err = strconv.Atoi(parts[2]); err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+		}
+
+		if *value == nil {
+			*value = map[string]RateLimitTier{}
+		}
+
+		(*value)[parts[0]] = tier
+	}
+
+	return nil
+}
+
+// lookupAPIKeys parses a comma separated list of API keys, of the form key:tier:
+func lookupAPIKeys(key string, value *map[string]string) error {
+	list := []string{}
+
+	lookupList(key, &list)
+
+	for _, item := range list {
+		apiKey, tier, found := strings.Cut(item, ":")
+
+		if !found || apiKey == "" || tier == "" {
+			return fmt.Errorf("%s: entries must be of the form key:tier", key)
+		}
+
+		if *value == nil {
+			*value = map[string]string{}
+		}
+
+		(*value)[apiKey] = tier
+	}
+
+	return nil
+}
+
+// lookupDirectives parses a semicolon separated list of Content-Security-Policy directives, e.g.,
+// "default-src 'self'; img-src 'self' data:":
+func lookupDirectives(key string, value *map[string][]string) error {
+	v, exists := os.LookupEnv(key)
+
+	if !exists {
+		return nil
+	}
+
+	directives := map[string][]string{}
+
+	for _, directive := range strings.Split(v, ";") {
+		fields := strings.Fields(directive)
+
+		if len(fields) == 0 {
+			continue
+		}
+
+		if _, exists := directives[fields[0]]; exists {
+			return fmt.Errorf("%s: the directive %q is repeated", key, fields[0])
+		}
+
+		directives[fields[0]] = fields[1:]
+	}
+
+	*value = directives
+
+	return nil
+}
+
+// lookupDeprecation overrides the deprecation policy of the v1 API, the only deprecated version:
+func (cfg *Config) lookupDeprecation() error {
+	if cfg.Deprecation == nil {
+		cfg.Deprecation = map[string]DeprecationConfig{}
+	}
+
+	v1 := cfg.Deprecation["v1"]
+
+	lookupString("API_V1_DOCUMENTATION", &v1.Documentation)
+
+	err := errors.Join(
+		lookupTime("API_V1_DEPRECATED_AT", &v1.DeprecatedAt),
+		lookupTime("API_V1_SUNSET", &v1.Sunset),
+		lookupTime("API_V1_CUTOFF", &v1.Cutoff),
+	)
+
+	cfg.Deprecation["v1"] = v1
+
+	return err
+}
+
+func (cfg *Config) loadEnv() error {
+	lookupString("GIN_MODE", &cfg.Mode)
+	lookupString("API_VERSION_LATEST", &cfg.APIVersion)
+	lookupString("SENTRY_DSN", &cfg.Sentry.DSN)
+	lookupString("LOG_LEVEL", &cfg.Log.Level)
+	lookupList("TRACING_EXPORTERS", &cfg.Tracing.Exporters)
+	lookupString("TRACING_ENDPOINT", &cfg.Tracing.Endpoint)
+	lookupString("TRACING_SERVICE_NAME", &cfg.Tracing.ServiceName)
+	lookupList("CORS_ALLOW_ORIGINS", &cfg.CORS.AllowOrigins)
+	lookupList("CORS_ALLOW_METHODS", &cfg.CORS.AllowMethods)
+	lookupList("CORS_ALLOW_HEADERS", &cfg.CORS.AllowHeaders)
+	lookupList("CORS_EXPOSE_HEADERS", &cfg.CORS.ExposeHeaders)
+	lookupString("RATE_LIMIT_API_KEY_HEADER", &cfg.RateLimit.APIKeyHeader)
+	lookupList("RATE_LIMIT_TRUSTED_PROXIES", &cfg.RateLimit.TrustedProxies)
+
+	return errors.Join(
+		lookupInt("PORT", &cfg.Server.Port),
+		lookupInt("GRPC_PORT", &cfg.GRPC.Port),
+		lookupBool("CORS_ALLOW_CREDENTIALS", &cfg.CORS.AllowCredentials),
+		lookupDuration("CORS_MAX_AGE", &cfg.CORS.MaxAge),
+		lookupDuration("HELMET_HSTS_MAX_AGE", &cfg.Helmet.HSTS.MaxAge),
+		lookupBool("HELMET_HSTS_INCLUDE_SUBDOMAINS", &cfg.Helmet.HSTS.IncludeSubDomains),
+		lookupBool("HELMET_HSTS_PRELOAD", &cfg.Helmet.HSTS.Preload),
+		lookupDirectives("HELMET_CONTENT_SECURITY_POLICY", &cfg.Helmet.ContentSecurityPolicy),
+		lookupDuration("SERVER_READ_TIMEOUT", &cfg.Server.ReadTimeout),
+		lookupDuration("SERVER_READ_HEADER_TIMEOUT", &cfg.Server.ReadHeaderTimeout),
+		lookupDuration("SERVER_WRITE_TIMEOUT", &cfg.Server.WriteTimeout),
+		lookupDuration("SERVER_IDLE_TIMEOUT", &cfg.Server.IdleTimeout),
+		lookupDuration("SERVER_SHUTDOWN_TIMEOUT", &cfg.Server.ShutdownTimeout),
+		lookupFloat("SENTRY_TRACES_SAMPLE_RATE", &cfg.Sentry.TracesSampleRate),
+		lookupBool("TRACING_INSECURE", &cfg.Tracing.Insecure),
+		lookupFloat("TRACING_SAMPLE_RATE", &cfg.Tracing.SampleRate),
+		lookupInt("CACHE_SIZE", &cfg.Cache.Size),
+		lookupDuration("CACHE_TTL", &cfg.Cache.TTL),
+		lookupInt("CACHE_COORDINATE_PRECISION", &cfg.Cache.CoordinatePrecision),
+		lookupDuration("CACHE_DATETIME_PRECISION", &cfg.Cache.DatetimePrecision),
+		lookupInt("RATE_LIMIT_REQUESTS_PER_MINUTE", &cfg.RateLimit.RequestsPerMinute),
+		lookupInt("RATE_LIMIT_BURST", &cfg.RateLimit.Burst),
+		lookupTiers("RATE_LIMIT_TIERS", &cfg.RateLimit.Tiers),
+		lookupAPIKeys("RATE_LIMIT_API_KEYS", &cfg.RateLimit.APIKeys),
+		cfg.lookupDeprecation(),
+		lookupInt("MAX_HEADER_BYTES", &cfg.Limits.MaxHeaderBytes),
+	)
+}
+
+var apiVersionRegex = regexp.MustCompile(`^v\d+$`)
+
+func validateOrigin(origin string) error {
+	// Any origin is allowed:
+	if origin == "*" {
+		return nil
+	}
+
+	u, err := url.Parse(origin)
+
+	if err != nil {
+		return fmt.Errorf("cors.allowOrigins: %w", err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.Path != "" || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		return fmt.Errorf("cors.allowOrigins: %q must be of the form scheme://host[:port]", origin)
+	}
+
+	// A wildcard is only allowed as the leftmost label of the host, e.g., "https://*.observerly.com":
+	if strings.Contains(u.Host, "*") && (!strings.HasPrefix(u.Host, "*.") || strings.Count(u.Host, "*") > 1) {
+		return fmt.Errorf("cors.allowOrigins: %q may only contain a wildcard as the leftmost label of the host", origin)
+	}
+
+	return nil
+}
+
+func validateProxy(proxy string) error {
+	if net.ParseIP(proxy) != nil {
+		return nil
+	}
+
+	if _, _, err := net.ParseCIDR(proxy); err != nil {
+		return fmt.Errorf("rateLimit.trustedProxies: %q must be an IP address or CIDR range", proxy)
+	}
+
+	return nil
+}
+
+// The minimum HSTS max-age required for inclusion in the browsers' preload lists, i.e., a year:
+const HSTS_PRELOAD_MIN_MAX_AGE time.Duration = 365 * 24 * time.Hour
+
+var directiveRegex = regexp.MustCompile(`^[a-z][a-z-]*$`)
+
+func validateDirectives(name string, directives map[string][]string) []error {
+	errs := []error{}
+
+	for directive := range directives {
+		if !directiveRegex.MatchString(directive) {
+			errs = append(errs, fmt.Errorf("%s: %q must be a lowercase directive name, e.g., default-src", name, directive))
+		}
+	}
+
+	return errs
+}
+
+func (h HelmetConfig) validate() []error {
+	errs := validateDirectives("helmet.contentSecurityPolicy", h.ContentSecurityPolicy)
+
+	errs = append(errs, validateDirectives("helmet.permissionsPolicy", h.PermissionsPolicy)...)
+
+	if h.HSTS.MaxAge < 0 {
+		errs = append(errs, fmt.Errorf("helmet.hsts.maxAge: %v must not be negative", h.HSTS.MaxAge))
+	}
+
+	if h.HSTS.Preload && (h.HSTS.MaxAge < HSTS_PRELOAD_MIN_MAX_AGE || !h.HSTS.IncludeSubDomains) {
+		errs = append(errs, fmt.Errorf("helmet.hsts.preload: requires a maxAge of at least %v and includeSubDomains", HSTS_PRELOAD_MIN_MAX_AGE))
+	}
+
+	for prefix, route := range h.Routes {
+		if !strings.HasPrefix(prefix, "/") {
+			errs = append(errs, fmt.Errorf("helmet.routes: %q must be a path prefix beginning with /", prefix))
+		}
+
+		errs = append(errs, validateDirectives(fmt.Sprintf("helmet.routes.%s.contentSecurityPolicy", prefix), route.ContentSecurityPolicy)...)
+	}
+
+	return errs
+}
+
+func (d DeprecationConfig) validate(version string, latest string) []error {
+	errs := []error{}
+
+	if !apiVersionRegex.MatchString(version) {
+		errs = append(errs, fmt.Errorf("deprecation: %q must be of the form v1, v2, etc", version))
+	}
+
+	if version == latest && !d.Cutoff.IsZero() {
+		errs = append(errs, fmt.Errorf("deprecation.%s.cutoff: the latest version of the API must not be cut off", version))
+	}
+
+	if !d.Sunset.IsZero() && d.Sunset.Before(d.DeprecatedAt) {
+		errs = append(errs, fmt.Errorf("deprecation.%s.sunset: %v must not be before deprecatedAt", version, d.Sunset))
+	}
+
+	// The version must not stop being served before the sunset date that has been announced to clients:
+	if !d.Cutoff.IsZero() && d.Cutoff.Before(d.Sunset) {
+		errs = append(errs, fmt.Errorf("deprecation.%s.cutoff: %v must not be before the sunset", version, d.Cutoff))
+	}
+
+	if d.Documentation != "" {
+		if u, err := url.Parse(d.Documentation); err != nil || !u.IsAbs() {
+			errs = append(errs, fmt.Errorf("deprecation.%s.documentation: %q must be an absolute URL", version, d.Documentation))
+		}
+	}
+
+	return errs
+}
+
+// Validate returns every problem with the configuration (joined), or nil if it is valid:
+func (cfg *Config) Validate() error {
+	errs := []error{}
+
+	if cfg.Mode != "debug" && cfg.Mode != "release" && cfg.Mode != "test" {
+		errs = append(errs, fmt.Errorf("mode: %q must be one of debug, release or test", cfg.Mode))
+	}
+
+	if !apiVersionRegex.MatchString(cfg.APIVersion) {
+		errs = append(errs, fmt.Errorf("apiVersion: %q must be of the form v1, v2, etc", cfg.APIVersion))
+	}
+
+	if cfg.Server.Port < 1 || cfg.Server.Port > 65535 {
+		errs = append(errs, fmt.Errorf("server.port: %d must be between 1 and 65535", cfg.Server.Port))
+	}
+
+	if cfg.GRPC.Port < 0 || cfg.GRPC.Port > 65535 {
+		errs = append(errs, fmt.Errorf("grpc.port: %d must be between 0 and 65535", cfg.GRPC.Port))
+	}
+
+	if cfg.GRPC.Port != 0 && cfg.GRPC.Port == cfg.Server.Port {
+		errs = append(errs, fmt.Errorf("grpc.port: %d must not be the same as server.port", cfg.GRPC.Port))
+	}
+
+	for name, timeout := range map[string]time.Duration{
+		"server.readTimeout":       cfg.Server.ReadTimeout,
+		"server.readHeaderTimeout": cfg.Server.ReadHeaderTimeout,
+		"server.writeTimeout":      cfg.Server.WriteTimeout,
+		"server.idleTimeout":       cfg.Server.IdleTimeout,
+		"server.shutdownTimeout":   cfg.Server.ShutdownTimeout,
+	} {
+		if timeout <= 0 {
+			errs = append(errs, fmt.Errorf("%s: %v must be positive", name, timeout))
+		}
+	}
+
+	for _, origin := range cfg.CORS.AllowOrigins {
+		if err := validateOrigin(origin); err != nil {
+			errs = append(errs, err)
+		}
+
+		// A wildcard origin with credentials would let every site make credentialed requests:
+		if origin == "*" && cfg.CORS.AllowCredentials {
+			errs = append(errs, fmt.Errorf("cors.allowOrigins: %q must not be used with cors.allowCredentials", origin))
+		}
+	}
+
+	if cfg.CORS.MaxAge < 0 {
+		errs = append(errs, fmt.Errorf("cors.maxAge: %v must not be negative", cfg.CORS.MaxAge))
+	}
+
+	errs = append(errs, cfg.Helmet.validate()...)
+
+	if cfg.Sentry.TracesSampleRate < 0 || cfg.Sentry.TracesSampleRate > 1 {
+		errs = append(errs, fmt.Errorf("sentry.tracesSampleRate: %v must be between 0 and 1", cfg.Sentry.TracesSampleRate))
+	}
+
+	for _, exporter := range cfg.Tracing.Exporters {
+		if exporter != "otlp" && exporter != "sentry" {
+			errs = append(errs, fmt.Errorf("tracing.exporters: %q must be one of otlp or sentry", exporter))
+		}
+	}
+
+	if cfg.Tracing.HasExporter("otlp") && cfg.Tracing.Endpoint == "" {
+		errs = append(errs, errors.New("tracing.endpoint: must not be empty when the otlp exporter is enabled"))
+	}
+
+	if cfg.Tracing.SampleRate < 0 || cfg.Tracing.SampleRate > 1 {
+		errs = append(errs, fmt.Errorf("tracing.sampleRate: %v must be between 0 and 1", cfg.Tracing.SampleRate))
+	}
+
+	if cfg.Tracing.IsEnabled() && cfg.Tracing.ServiceName == "" {
+		errs = append(errs, errors.New("tracing.serviceName: must not be empty when tracing is enabled"))
+	}
+
+	if cfg.Cache.Size < 0 {
+		errs = append(errs, fmt.Errorf("cache.size: %d must not be negative", cfg.Cache.Size))
+	}
+
+	if cfg.Cache.Size > 0 && cfg.Cache.TTL <= 0 {
+		errs = append(errs, fmt.Errorf("cache.ttl: %v must be positive when the cache is enabled", cfg.Cache.TTL))
+	}
+
+	if cfg.Cache.CoordinatePrecision < 0 || cfg.Cache.CoordinatePrecision > 15 {
+		errs = append(errs, fmt.Errorf("cache.coordinatePrecision: %d must be between 0 and 15", cfg.Cache.CoordinatePrecision))
+	}
+
+	if cfg.Cache.DatetimePrecision < 0 {
+		errs = append(errs, fmt.Errorf("cache.datetimePrecision: %v must not be negative", cfg.Cache.DatetimePrecision))
+	}
+
+	if cfg.RateLimit.RequestsPerMinute < 0 {
+		errs = append(errs, fmt.Errorf("rateLimit.requestsPerMinute: %d must not be negative", cfg.RateLimit.RequestsPerMinute))
+	}
+
+	if cfg.RateLimit.Burst < 0 {
+		errs = append(errs, fmt.Errorf("rateLimit.burst: %d must not be negative", cfg.RateLimit.Burst))
+	}
+
+	if cfg.RateLimit.APIKeyHeader == "" {
+		errs = append(errs, errors.New("rateLimit.apiKeyHeader: must not be empty"))
+	}
+
+	for _, proxy := range cfg.RateLimit.TrustedProxies {
+		if err := validateProxy(proxy); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for name, tier := range cfg.RateLimit.Tiers {
+		if tier.RequestsPerMinute < 0 || tier.Burst < 0 {
+			errs = append(errs, fmt.Errorf("rateLimit.tiers.%s: requestsPerMinute and burst must not be negative", name))
+		}
+	}
+
+	for _, tier := range cfg.RateLimit.APIKeys {
+		if _, exists := cfg.RateLimit.Tiers[tier]; !exists {
+			// N.B. the API key itself is never included in the error, as it may be logged:
+			errs = append(errs, fmt.Errorf("rateLimit.apiKeys: the tier %q is not defined in rateLimit.tiers", tier))
+		}
+	}
+
+	for version, d := range cfg.Deprecation {
+		errs = append(errs, d.validate(version, cfg.APIVersion)...)
+	}
+
+	if cfg.Limits.MaxHeaderBytes < 0 {
+		errs = append(errs, fmt.Errorf("limits.maxHeaderBytes: %d must not be negative", cfg.Limits.MaxHeaderBytes))
+	}
+
+	if _, err := logger.ParseLevel(cfg.Log.Level); err != nil {
+		errs = append(errs, fmt.Errorf("log.level: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Addr returns the TCP address for the server to listen on, e.g., ":8103":
+func (cfg *Config) Addr() string {
+	return fmt.Sprintf(":%d", cfg.Server.Port)
+}
+
+// GRPCAddr returns the TCP address for the gRPC server to listen on, e.g., ":9103":
+func (cfg *Config) GRPCAddr() string {
+	return fmt.Sprintf(":%d", cfg.GRPC.Port)
+}
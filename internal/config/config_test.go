@@ -0,0 +1,345 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeConfigFile(t *testing.T, contents string) string {
+	path := filepath.Join(t.TempDir(), "config.yml")
+
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("Couldn't write config file: %v\n", err)
+	}
+
+	return path
+}
+
+func TestDefault(t *testing.T) {
+	cfg := Default()
+
+	assert.Nil(t, cfg.Validate())
+	assert.Equal(t, ":8103", cfg.Addr())
+	assert.Equal(t, "v2", cfg.APIVersion)
+	assert.Equal(t, 1024, cfg.Cache.Size)
+}
+
+func TestLoadWithoutFile(t *testing.T) {
+	cfg, err := Load("")
+
+	assert.Nil(t, err)
+	assert.Equal(t, Default().Server, cfg.Server)
+}
+
+func TestLoadFile(t *testing.T) {
+	path := writeConfigFile(t, `
+mode: release
+server:
+  port: 8080
+  writeTimeout: 2m
+cors:
+  allowOrigins:
+    - https://observerly.com
+cache:
+  size: 0
+sentry:
+  tracesSampleRate: 0.25
+`)
+
+	cfg, err := Load(path)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "release", cfg.Mode)
+	assert.Equal(t, ":8080", cfg.Addr())
+	assert.Equal(t, 2*time.Minute, cfg.Server.WriteTimeout)
+	assert.Equal(t, []string{"https://observerly.com"}, cfg.CORS.AllowOrigins)
+	assert.Equal(t, 0, cfg.Cache.Size)
+	assert.Equal(t, 0.25, cfg.Sentry.TracesSampleRate)
+
+	// Assert that anything not in the file retains its default:
+	assert.Equal(t, 15*time.Second, cfg.Server.ReadTimeout)
+	assert.Equal(t, "v2", cfg.APIVersion)
+}
+
+func TestLoadFileUnknownKey(t *testing.T) {
+	path := writeConfigFile(t, `
+server:
+  prot: 8080
+`)
+
+	_, err := Load(path)
+
+	assert.ErrorContains(t, err, "field prot not found")
+}
+
+func TestLoadFileMissing(t *testing.T) {
+	_, err := Load(filepath.Join(t.TempDir(), "missing.yml"))
+
+	assert.NotNil(t, err)
+}
+
+func TestLoadEnvOverridesFile(t *testing.T) {
+	path := writeConfigFile(t, `
+server:
+  port: 8080
+cache:
+  ttl: 5m
+`)
+
+	t.Setenv("PORT", "9000")
+	t.Setenv("CACHE_TTL", "30s")
+	t.Setenv("CORS_ALLOW_ORIGINS", "https://observerly.com, http://localhost:3000")
+	t.Setenv("API_VERSION_LATEST", "v3")
+
+	cfg, err := Load(path)
+
+	assert.Nil(t, err)
+	assert.Equal(t, ":9000", cfg.Addr())
+	assert.Equal(t, 30*time.Second, cfg.Cache.TTL)
+	assert.Equal(t, []string{"https://observerly.com", "http://localhost:3000"}, cfg.CORS.AllowOrigins)
+	assert.Equal(t, "v3", cfg.APIVersion)
+}
+
+func TestLoadEnvInvalid(t *testing.T) {
+	t.Setenv("CACHE_SIZE", "lots")
+
+	_, err := Load("")
+
+	assert.ErrorContains(t, err, "CACHE_SIZE")
+}
+
+func TestValidate(t *testing.T) {
+	cfg := Default()
+
+	cfg.Mode = "production"
+	cfg.Server.Port = 0
+	cfg.Server.WriteTimeout = 0
+	cfg.CORS.AllowOrigins = []string{"observerly.com"}
+	cfg.Sentry.TracesSampleRate = 1.5
+	cfg.RateLimit.Burst = -1
+
+	err := cfg.Validate()
+
+	// Assert that every problem is reported at once:
+	assert.ErrorContains(t, err, "mode")
+	assert.ErrorContains(t, err, "server.port")
+	assert.ErrorContains(t, err, "server.writeTimeout")
+	assert.ErrorContains(t, err, "cors.allowOrigins")
+	assert.ErrorContains(t, err, "sentry.tracesSampleRate")
+	assert.ErrorContains(t, err, "rateLimit.burst")
+}
+
+func TestLoadExampleFile(t *testing.T) {
+	cfg, err := Load(filepath.Join("..", "..", "config.example.yml"))
+
+	// Assert that the example config file documents the defaults:
+	assert.Nil(t, err)
+	assert.Equal(t, Default().Server, cfg.Server)
+	assert.Equal(t, Default().CORS, cfg.CORS)
+	assert.Equal(t, Default().Cache, cfg.Cache)
+	assert.Equal(t, Default().Tracing, cfg.Tracing)
+	assert.Equal(t, Default().Helmet, cfg.Helmet)
+}
+
+func TestValidateOrigins(t *testing.T) {
+	cfg := Default()
+
+	cfg.CORS.AllowOrigins = []string{"*", "https://*.observerly.com", "http://localhost:3001"}
+	cfg.CORS.AllowCredentials = false
+
+	assert.Nil(t, cfg.Validate())
+
+	for _, origin := range []string{"https://app.*.observerly.com", "https://observerly.com/", "*.observerly.com", "https://**.observerly.com"} {
+		cfg.CORS.AllowOrigins = []string{origin}
+
+		assert.ErrorContains(t, cfg.Validate(), "cors.allowOrigins", origin)
+	}
+}
+
+func TestValidateWildcardOriginWithCredentials(t *testing.T) {
+	cfg := Default()
+
+	cfg.CORS.AllowOrigins = []string{"*"}
+	cfg.CORS.AllowCredentials = true
+
+	// Assert that any origin cannot be allowed to make credentialed requests:
+	assert.ErrorContains(t, cfg.Validate(), "cors.allowCredentials")
+}
+
+func TestLoadEnvRateLimit(t *testing.T) {
+	t.Setenv("RATE_LIMIT_REQUESTS_PER_MINUTE", "60")
+	t.Setenv("RATE_LIMIT_TRUSTED_PROXIES", "10.0.0.0/8, 192.0.2.1")
+	t.Setenv("RATE_LIMIT_TIERS", "partner:600:100,internal:0")
+	t.Setenv("RATE_LIMIT_API_KEYS", "abc:partner,def:internal")
+
+	cfg, err := Load("")
+
+	assert.Nil(t, err)
+	assert.True(t, cfg.RateLimit.IsEnabled())
+	assert.Equal(t, []string{"10.0.0.0/8", "192.0.2.1"}, cfg.RateLimit.TrustedProxies)
+	assert.Equal(t, map[string]RateLimitTier{"partner": {RequestsPerMinute: 600, Burst: 100}, "internal": {}}, cfg.RateLimit.Tiers)
+	assert.Equal(t, map[string]string{"abc": "partner", "def": "internal"}, cfg.RateLimit.APIKeys)
+}
+
+func TestValidateRateLimit(t *testing.T) {
+	cfg := Default()
+
+	cfg.RateLimit.TrustedProxies = []string{"10.0.0.0/33"}
+	cfg.RateLimit.APIKeys = map[string]string{"secret-api-key": "gold"}
+
+	err := cfg.Validate()
+
+	assert.ErrorContains(t, err, "rateLimit.trustedProxies")
+	assert.ErrorContains(t, err, `the tier "gold" is not defined`)
+
+	// Assert that the API key is never included in the error:
+	assert.NotContains(t, err.Error(), "secret-api-key")
+}
+
+func TestLoadEnvTracing(t *testing.T) {
+	t.Setenv("TRACING_EXPORTERS", "otlp, sentry")
+	t.Setenv("TRACING_ENDPOINT", "collector:4318")
+	t.Setenv("TRACING_INSECURE", "true")
+	t.Setenv("TRACING_SAMPLE_RATE", "0.5")
+
+	cfg, err := Load("")
+
+	assert.Nil(t, err)
+	assert.True(t, cfg.Tracing.IsEnabled())
+	assert.True(t, cfg.Tracing.HasExporter("sentry"))
+	assert.Equal(t, "collector:4318", cfg.Tracing.Endpoint)
+	assert.True(t, cfg.Tracing.Insecure)
+	assert.Equal(t, 0.5, cfg.Tracing.SampleRate)
+}
+
+func TestValidateTracing(t *testing.T) {
+	cfg := Default()
+
+	assert.False(t, cfg.Tracing.IsEnabled())
+
+	cfg.Tracing.Exporters = []string{"otlp", "jaeger"}
+	cfg.Tracing.Endpoint = ""
+	cfg.Tracing.SampleRate = 1.5
+
+	err := cfg.Validate()
+
+	assert.ErrorContains(t, err, `tracing.exporters: "jaeger"`)
+	assert.ErrorContains(t, err, "tracing.endpoint")
+	assert.ErrorContains(t, err, "tracing.sampleRate")
+}
+
+func TestLoadEnvHelmet(t *testing.T) {
+	t.Setenv("HELMET_CONTENT_SECURITY_POLICY", "default-src 'self'; img-src 'self' data:; upgrade-insecure-requests")
+	t.Setenv("HELMET_HSTS_MAX_AGE", "8760h")
+	t.Setenv("HELMET_HSTS_PRELOAD", "true")
+
+	cfg, err := Load("")
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]string{"default-src": {"'self'"}, "img-src": {"'self'", "data:"}, "upgrade-insecure-requests": {}}, cfg.Helmet.ContentSecurityPolicy)
+	assert.Equal(t, HSTSConfig{MaxAge: 8760 * time.Hour, IncludeSubDomains: true, Preload: true}, cfg.Helmet.HSTS)
+}
+
+func TestLoadFileHelmetRoutes(t *testing.T) {
+	path := writeConfigFile(t, `
+helmet:
+  headers:
+    X-Frame-Options: SAMEORIGIN
+  routes:
+    /docs:
+      contentSecurityPolicy:
+        default-src: ["'self'"]
+        style-src: ["'self'", "'unsafe-inline'"]
+`)
+
+	cfg, err := Load(path)
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"X-Frame-Options": "SAMEORIGIN"}, cfg.Helmet.Headers)
+	assert.Equal(t, []string{"'self'", "'unsafe-inline'"}, cfg.Helmet.Routes["/docs"].ContentSecurityPolicy["style-src"])
+}
+
+func TestValidateHelmet(t *testing.T) {
+	cfg := Default()
+
+	cfg.Helmet.HSTS.Preload = true
+	cfg.Helmet.ContentSecurityPolicy["Default-Src"] = []string{"'self'"}
+	cfg.Helmet.Routes = map[string]HelmetRouteConfig{"docs": {}}
+
+	err := cfg.Validate()
+
+	assert.ErrorContains(t, err, "helmet.hsts.preload")
+	assert.ErrorContains(t, err, `helmet.contentSecurityPolicy: "Default-Src"`)
+	assert.ErrorContains(t, err, `helmet.routes: "docs"`)
+}
+
+func TestLoadEnvDeprecation(t *testing.T) {
+	t.Setenv("API_V1_SUNSET", "2027-01-01T00:00:00Z")
+	t.Setenv("API_V1_CUTOFF", "2027-04-01T00:00:00Z")
+	t.Setenv("API_V1_DOCUMENTATION", "https://nocturnal.observerly.com/docs/migration")
+
+	cfg, err := Load("")
+
+	assert.Nil(t, err)
+	assert.Equal(t, DeprecationConfig{
+		DeprecatedAt:  time.Date(2023, time.February, 14, 0, 0, 0, 0, time.UTC),
+		Sunset:        time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC),
+		Cutoff:        time.Date(2027, time.April, 1, 0, 0, 0, 0, time.UTC),
+		Documentation: "https://nocturnal.observerly.com/docs/migration",
+	}, cfg.Deprecation["v1"])
+
+	t.Setenv("API_V1_CUTOFF", "tomorrow")
+
+	_, err = Load("")
+
+	assert.ErrorContains(t, err, "API_V1_CUTOFF")
+}
+
+func TestValidateDeprecation(t *testing.T) {
+	cfg := Default()
+
+	cfg.Deprecation["v1"] = DeprecationConfig{
+		DeprecatedAt:  time.Date(2023, time.February, 14, 0, 0, 0, 0, time.UTC),
+		Sunset:        time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC),
+		Cutoff:        time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC),
+		Documentation: "/docs/migration",
+	}
+
+	cfg.Deprecation["v2"] = DeprecationConfig{
+		Cutoff: time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	err := cfg.Validate()
+
+	assert.ErrorContains(t, err, "deprecation.v1.cutoff")
+	assert.ErrorContains(t, err, "deprecation.v1.documentation")
+	assert.ErrorContains(t, err, "deprecation.v2.cutoff: the latest version")
+}
+
+func TestLoadEnvGRPC(t *testing.T) {
+	t.Setenv("GRPC_PORT", "9103")
+
+	cfg, err := Load("")
+
+	assert.Nil(t, err)
+	assert.Equal(t, ":9103", cfg.GRPCAddr())
+}
+
+func TestValidateGRPC(t *testing.T) {
+	cfg := Default()
+
+	// Assert that the gRPC server is disabled by default:
+	assert.Equal(t, 0, cfg.GRPC.Port)
+
+	cfg.GRPC.Port = cfg.Server.Port
+
+	assert.ErrorContains(t, cfg.Validate(), "grpc.port")
+
+	cfg.GRPC.Port = 70000
+
+	assert.ErrorContains(t, cfg.Validate(), "grpc.port")
+}
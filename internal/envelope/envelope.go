@@ -0,0 +1,44 @@
+package envelope
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/observerly/nocturnal/pkg/computation"
+)
+
+// The gin context key that the request ID is stored under:
+const REQUEST_ID_KEY string = "requestId"
+
+// The message of every internal server error, which never reveals the error itself to the client:
+const INTERNAL_SERVER_ERROR_MESSAGE string = "internal server error"
+
+// GetRequestID returns the ID of the request, as set by the RequestIDMiddleware:
+func GetRequestID(c *gin.Context) string {
+	return c.GetString(REQUEST_ID_KEY)
+}
+
+// NewError returns the error envelope for the message, including the ID of the request so that it can be correlated
+// with the logs (and any Sentry events) of the request:
+func NewError(c *gin.Context, message string) gin.H {
+	return gin.H{
+		"error":     message,
+		"requestId": GetRequestID(c),
+	}
+}
+
+// WriteComputationError responds with the error of a typed function, i.e., a 400 with its message if it is due to an
+// invalid argument, or otherwise a 500 (e.g., if the timezone of the observer fails to load), where the error is
+// recorded on the context for the logs rather than revealed to the client:
+func WriteComputationError(c *gin.Context, err error) {
+	if errors.Is(err, computation.ErrInvalidArgument) {
+		c.JSON(http.StatusBadRequest, NewError(c, err.Error()))
+		return
+	}
+
+	_ = c.Error(err)
+
+	c.JSON(http.StatusInternalServerError, NewError(c, INTERNAL_SERVER_ERROR_MESSAGE))
+}
@@ -0,0 +1,47 @@
+package envelope
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/observerly/nocturnal/pkg/computation"
+)
+
+func TestNewError(t *testing.T) {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+
+	c.Set(REQUEST_ID_KEY, "abc-123")
+
+	assert.Equal(t, "abc-123", GetRequestID(c))
+	assert.Equal(t, gin.H{"error": "invalid syntax", "requestId": "abc-123"}, NewError(c, "invalid syntax"))
+}
+
+func TestWriteComputationErrorInvalidArgument(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	c, _ := gin.CreateTestContext(w)
+
+	WriteComputationError(c, computation.InvalidArgument("days must be between 1 and %d", 30))
+
+	// Assert that an invalid argument is a 400, with the reason:
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"error":"days must be between 1 and 30","requestId":""}`, w.Body.String())
+}
+
+func TestWriteComputationErrorInternal(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	c, _ := gin.CreateTestContext(w)
+
+	WriteComputationError(c, errors.New("unknown time zone Pacific/Atlantis"))
+
+	// Assert that any other error is a 500, which is recorded for the logs but not revealed to the client:
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.JSONEq(t, `{"error":"internal server error","requestId":""}`, w.Body.String())
+	assert.Equal(t, "unknown time zone Pacific/Atlantis", c.Errors.Last().Error())
+}
@@ -0,0 +1,160 @@
+package moon
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/observerly/dusk/pkg/dusk"
+	"github.com/observerly/nocturnal/internal/envelope"
+	"github.com/observerly/nocturnal/internal/query"
+	"github.com/observerly/nocturnal/internal/utils"
+	"github.com/observerly/nocturnal/pkg/computation"
+	"github.com/observerly/nocturnal/pkg/events"
+	"github.com/observerly/nocturnal/pkg/moon"
+	"github.com/observerly/nocturnal/pkg/observer"
+)
+
+// GET /moon
+func GetMoonDeprecatedV1(c *gin.Context) {
+	d, lon, lat := query.GetDefaultObserverParams(c)
+
+	datetime, _ := utils.ParseDatetimeRFC3339(d)
+
+	longitude, _ := strconv.ParseFloat(lon, 64)
+
+	latitude, _ := strconv.ParseFloat(lat, 64)
+
+	ec := dusk.GetLunarEclipticPosition(datetime)
+
+	eq := dusk.GetLunarEquatorialPosition(datetime)
+
+	hz := dusk.ConvertEquatorialCoordinateToHorizontal(datetime, longitude, latitude, eq)
+
+	ph := dusk.GetLunarPhase(datetime, longitude, ec)
+
+	span := computation.Start(c.Request.Context(), "lunar_rise_set")
+
+	rs, _ := dusk.GetMoonriseMoonsetTimes(datetime, longitude, latitude)
+
+	span.End()
+
+	observer := gin.H{
+		"datetime":  datetime,
+		"longitude": longitude,
+		"latitude":  latitude,
+	}
+
+	position := gin.H{
+		"alt": hz.Altitude,
+		"az":  hz.Azimuth,
+		"ra":  eq.RightAscension,
+		"dec": eq.Declination,
+	}
+
+	phase := gin.H{
+		"age":          ph.Days,
+		"angle":        ph.Angle,
+		"d":            ph.Age,
+		"fraction":     ph.Fraction,
+		"illumination": ph.Illumination,
+	}
+
+	transit := gin.H{}
+
+	if rs.Rise.IsZero() {
+		transit["rise"] = nil
+	} else {
+		transit["rise"] = rs.Rise.Format(time.RFC3339)
+	}
+
+	if rs.Set.IsZero() {
+		transit["set"] = nil
+	} else {
+		transit["set"] = rs.Set.Format(time.RFC3339)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"observer": observer,
+		"position": position,
+		"phase":    phase,
+		"transit":  transit,
+	})
+}
+
+// GET /moon v2
+func GetMoon(c *gin.Context) {
+	o := query.GetObserver(c)
+
+	// Parse whether to search forward for the next events, rather than those of the local day, from the request query:
+	next, err := strconv.ParseBool(c.DefaultQuery("next", "false"))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, envelope.NewError(c, err.Error()))
+		return
+	}
+
+	if next {
+		// Parse the number of days to search forward from the request query:
+		days, err := strconv.Atoi(c.DefaultQuery("days", strconv.Itoa(moon.MAX_SEARCH_DAYS)))
+
+		if err != nil {
+			c.JSON(http.StatusBadRequest, envelope.NewError(c, err.Error()))
+			return
+		}
+
+		res, err := moon.SearchLunarTransit(c.Request.Context(), o, days)
+
+		if err != nil {
+			envelope.WriteComputationError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, res)
+		return
+	}
+
+	res, err := moon.GetLunarTransit(c.Request.Context(), o)
+
+	if err != nil {
+		envelope.WriteComputationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GET /moon/libration v2
+func GetMoonLibration(c *gin.Context) {
+	c.JSON(http.StatusOK, moon.GetLibration(c.Request.Context(), query.GetObserver(c)))
+}
+
+// GET /moon v3
+func GetMoonV3(c *gin.Context) {
+	datetime, longitude, latitude, elevation, err := query.ParseObserverParams(c)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, envelope.NewError(c, err.Error()))
+		return
+	}
+
+	location, err := observer.GetLocation(longitude, latitude)
+
+	if err != nil {
+		envelope.WriteComputationError(c, err)
+		return
+	}
+
+	evs, err := moon.GetLunarEvents(c.Request.Context(), datetime, longitude, latitude, elevation, location)
+
+	if err != nil {
+		envelope.WriteComputationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, events.Response{
+		Observer: events.NewObserver(datetime, longitude, latitude, elevation, location),
+		Bodies:   []events.Body{events.NewBody("moon", evs...)},
+	})
+}
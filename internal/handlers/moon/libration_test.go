@@ -0,0 +1,88 @@
+package moon
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func SetupLibrationRouter() *gin.Engine {
+	mode := os.Getenv("GIN_MODE")
+
+	if mode == "release" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	// Create gin router
+	r := gin.Default()
+
+	r.GET("/api/v2/moon/libration", GetMoonLibration)
+
+	return r
+}
+
+// Setup the Gin API router:
+var lbr = SetupLibrationRouter()
+
+// Perform a GET request with that handler (for the worked example 53.a of Meeus, Astronomical Algorithms):
+var lbw = performLuneRequest(lbr, "GET", "/api/v2/moon/libration?datetime=1992-04-12T00:00:00.000Z&longitude=-155.468094&latitude=19.798484")
+
+// The tolerance accounts for the truncated lunar series and the omission of the physical libration:
+var tolerance = 0.2
+
+func TestLibrationRouteStatusCode(t *testing.T) {
+	// Assert we encoded correctly, the request gives a 200:
+	assert.Equal(t, http.StatusOK, lbw.Code)
+}
+
+func TestGetLibrationRouteLibration(t *testing.T) {
+	// Build our expected libration section of body
+	libration := gin.H{
+		"UTC":         "1992-04-12T00:00:00Z",
+		"latitude":    4.194,
+		"longitude":   -1.206,
+		"axisAngle":   15.08,
+		"limbAngle":   285.0,
+		"colongitude": 22.10,
+	}
+
+	// Convert the JSON response:
+	err := json.Unmarshal(lbw.Body.Bytes(), &response)
+
+	// Obtain the Universal Time of the libration and test whether or not it exists:
+	UTC, exists := response["libration"]["UTC"]
+	assert.True(t, exists)
+
+	// Obtain the libration in latitude and test whether or not it exists:
+	latitude, exists := response["libration"]["latitude"]
+	assert.True(t, exists)
+
+	// Obtain the libration in longitude and test whether or not it exists:
+	longitude, exists := response["libration"]["longitude"]
+	assert.True(t, exists)
+
+	// Obtain the position angle of the axis and test whether or not it exists:
+	axisAngle, exists := response["libration"]["axisAngle"]
+	assert.True(t, exists)
+
+	// Obtain the position angle of the bright limb and test whether or not it exists:
+	limbAngle, exists := response["libration"]["limbAngle"]
+	assert.True(t, exists)
+
+	// Obtain the selenographic colongitude and test whether or not it exists:
+	colongitude, exists := response["libration"]["colongitude"]
+	assert.True(t, exists)
+
+	// Assert on the correctness of the response:
+	assert.Nil(t, err)
+	assert.Equal(t, UTC, libration["UTC"])
+	assert.InDelta(t, latitude, libration["latitude"], tolerance)
+	assert.InDelta(t, longitude, libration["longitude"], tolerance)
+	assert.InDelta(t, axisAngle, libration["axisAngle"], tolerance)
+	assert.InDelta(t, limbAngle, libration["limbAngle"], tolerance)
+	assert.InDelta(t, colongitude, libration["colongitude"], tolerance)
+}
@@ -78,17 +78,17 @@ func TestGetMoonRouteRise(t *testing.T) {
 	// Build our expected rise section of body
 	rise := gin.H{
 		"LCT":          "2021-05-14T07:57:00-10:00",
-		"R":            0.45726609910544613,
+		"R":            0.4697353633201601,
 		"UTC":          "2021-05-14T17:57:00Z",
-		"X":            35.82232848697485,
-		"age":          2.142546923777335,
-		"alt":          0.18326186176169362,
-		"angle":        148.6700291081809,
-		"az":           63.73505782242811,
-		"dec":          24.673459614318453,
-		"fraction":     0.07255347501615561,
-		"illumination": 7.290652119635177,
-		"ra":           85.78031360389217,
+		"X":            37.17174693250755,
+		"age":          2.1638338989002683,
+		"alt":          0.09305867924297329,
+		"angle":        148.51081867650072,
+		"az":           63.55618474528337,
+		"dec":          24.805966424990554,
+		"fraction":     0.0732743199136986,
+		"illumination": 7.363059593509441,
+		"ra":           85.94574336741032,
 	}
 
 	// Convert the JSON response:
@@ -158,21 +158,69 @@ func TestGetMoonRouteRise(t *testing.T) {
 	assert.InDelta(t, ra, rise["ra"], precision)
 }
 
+func TestGetMoonRouteMaximum(t *testing.T) {
+	// Build our expected maximum section of body
+	maximum := gin.H{
+		"LCT":          "2021-05-14T14:48:00-10:00",
+		"UTC":          "2021-05-15T00:48:00Z",
+		"X":            1.004331159888655,
+		"alt":          84.63865582057248,
+		"az":           1.7616966665570823,
+		"illumination": 8.995742694221637,
+	}
+
+	// Convert the JSON response:
+	err := json.Unmarshal(lw.Body.Bytes(), &response)
+
+	// Obtain the Local Civil Time of the maximum and test whether or not it exists:
+	LCT, exists := response["maximum"]["LCT"]
+	assert.True(t, exists)
+
+	// Obtain the Universal Time of the maximum and test whether or not it exists:
+	UTC, exists := response["maximum"]["UTC"]
+	assert.True(t, exists)
+
+	// Obtain the airmass (X) of the maximum and test whether or not it exists:
+	X, exists := response["maximum"]["X"]
+	assert.True(t, exists)
+
+	// Obtain the altitude of the maximum and test whether or not it exists:
+	alt, exists := response["maximum"]["alt"]
+	assert.True(t, exists)
+
+	// Obtain the azimuth of the maximum and test whether or not it exists:
+	az, exists := response["maximum"]["az"]
+	assert.True(t, exists)
+
+	// Obtain the illumination of the maximum and test whether or not it exists:
+	illumination, exists := response["maximum"]["illumination"]
+	assert.True(t, exists)
+
+	// Assert on the correctness of the response:
+	assert.Nil(t, err)
+	assert.Equal(t, LCT, maximum["LCT"])
+	assert.Equal(t, UTC, maximum["UTC"])
+	assert.InDelta(t, X, maximum["X"], precision)
+	assert.InDelta(t, alt, maximum["alt"], precision)
+	assert.InDelta(t, az, maximum["az"], precision)
+	assert.InDelta(t, illumination, maximum["illumination"], precision)
+}
+
 func TestGetMoonRouteSet(t *testing.T) {
 	// Build our expected set section of body
 	set := gin.H{
 		"LCT":          "2021-05-14T21:42:00-10:00",
-		"R":            nil,
+		"R":            0.477067375571056,
 		"UTC":          "2021-05-15T07:42:00Z",
-		"X":            nil,
-		"age":          3.151614688448534,
-		"alt":          -0.1253140634039632,
-		"angle":        141.7714490563052,
-		"az":           62.95233340505419,
-		"dec":          25.284322055858368,
-		"fraction":     0.10672372913810846,
-		"illumination": 10.722568110680397,
-		"ra":           93.35313596587092,
+		"X":            38.00925589388086,
+		"age":          3.1721917248811664,
+		"alt":          0.04136661293157597,
+		"angle":        141.64206400738024,
+		"az":           62.87788254179477,
+		"dec":          25.415865565616475,
+		"fraction":     0.10742053324641215,
+		"illumination": 10.792536745072972,
+		"ra":           93.49319972181705,
 	}
 
 	// Convert the JSON response:
@@ -228,10 +276,10 @@ func TestGetMoonRouteSet(t *testing.T) {
 
 	// Assert on the correctness of the response:
 	assert.Nil(t, err)
-	assert.Equal(t, R, set["R"])
+	assert.InDelta(t, R, set["R"], precision)
 	assert.Equal(t, LCT, set["LCT"])
 	assert.Equal(t, UTC, set["UTC"])
-	assert.Equal(t, X, set["X"])
+	assert.InDelta(t, X, set["X"], precision)
 	assert.InDelta(t, age, set["age"], precision)
 	assert.InDelta(t, alt, set["alt"], precision)
 	assert.InDelta(t, angle, set["angle"], precision)
@@ -241,3 +289,120 @@ func TestGetMoonRouteSet(t *testing.T) {
 	assert.InDelta(t, illumination, set["illumination"], precision)
 	assert.InDelta(t, ra, set["ra"], precision)
 }
+
+func TestGetMoonRouteRiseTopocentric(t *testing.T) {
+	// Build our expected topocentric properties of the rise section of body
+	rise := gin.H{
+		"diameter": 0.4928644975846603,
+		"distance": 403902.706150004,
+		"topocentric": gin.H{
+			"alt":      -0.8113021112619615,
+			"az":       63.554455785202784,
+			"dec":      24.467968982271714,
+			"distance": 403948.73127026996,
+			"ra":       86.86858139350153,
+		},
+	}
+
+	// Convert the JSON response:
+	err := json.Unmarshal(lw.Body.Bytes(), &response)
+
+	// Obtain the apparent angular diameter of the rise and test whether or not it exists:
+	diameter, exists := response["rise"]["diameter"]
+	assert.True(t, exists)
+
+	// Obtain the geocentric distance of the rise and test whether or not it exists:
+	distance, exists := response["rise"]["distance"]
+	assert.True(t, exists)
+
+	// Obtain the topocentric position of the rise and test whether or not it exists:
+	topocentric, exists := response["rise"]["topocentric"].(map[string]interface{})
+	assert.True(t, exists)
+
+	// Assert on the correctness of the response:
+	assert.Nil(t, err)
+	assert.InDelta(t, diameter, rise["diameter"], precision)
+	assert.InDelta(t, distance, rise["distance"], precision)
+
+	for key, value := range rise["topocentric"].(gin.H) {
+		assert.InDelta(t, topocentric[key], value, precision)
+	}
+}
+
+func TestGetMoonRouteRiseTopocentricParallax(t *testing.T) {
+	// Convert the JSON response:
+	err := json.Unmarshal(lw.Body.Bytes(), &response)
+
+	assert.Nil(t, err)
+
+	topocentric := response["rise"]["topocentric"].(map[string]interface{})
+
+	// The lunar parallax should always depress the topocentric altitude by no more than ~1°:
+	depression := response["rise"]["alt"].(float64) - topocentric["alt"].(float64)
+
+	assert.Greater(t, depression, 0.0)
+	assert.Less(t, depression, 1.0)
+}
+
+// Perform a GET request with that handler, searching forward for the next events.
+var nw = performLuneRequest(lr, "GET", "/api/v2/moon?datetime=2021-05-14T00:00:00.000Z&longitude=-155.468094&latitude=19.798484&next=true")
+
+// Perform a GET request with that handler, searching forward for the next events at high latitude (Svalbard).
+var sw = performLuneRequest(lr, "GET", "/api/v2/moon?datetime=2021-05-14T00:00:00.000Z&longitude=18.0&latitude=78.2&next=true")
+
+func TestGetMoonRouteNext(t *testing.T) {
+	// Build our expected next events section of body
+	next := gin.H{
+		"rise":    "2021-05-14T17:57:00Z",
+		"maximum": "2021-05-15T00:48:00Z",
+		"set":     "2021-05-14T06:49:00Z",
+	}
+
+	// Convert the JSON response:
+	err := json.Unmarshal(nw.Body.Bytes(), &response)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, nw.Code)
+
+	for event, datetime := range next {
+		// Obtain the Universal Time of the event and test whether or not it exists:
+		UTC, exists := response[event]["UTC"]
+		assert.True(t, exists)
+		assert.Equal(t, UTC, datetime)
+	}
+
+	// Obtain the altitude of the upper culmination and test whether or not it exists:
+	alt, exists := response["maximum"]["alt"]
+	assert.True(t, exists)
+	assert.InDelta(t, alt, 84.63865582057248, precision)
+}
+
+func TestGetMoonRouteNextSpansMultipleDays(t *testing.T) {
+	// Build our expected next events section of body, the Moon is circumpolar until it first sets a week later
+	next := gin.H{
+		"rise":    "2021-05-21T09:21:00Z",
+		"maximum": "2021-05-14T12:55:00Z",
+		"set":     "2021-05-21T03:55:00Z",
+	}
+
+	// Convert the JSON response:
+	err := json.Unmarshal(sw.Body.Bytes(), &response)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, sw.Code)
+
+	for event, datetime := range next {
+		// Obtain the Universal Time of the event and test whether or not it exists:
+		UTC, exists := response[event]["UTC"]
+		assert.True(t, exists)
+		assert.Equal(t, UTC, datetime)
+	}
+}
+
+func TestGetMoonRouteNextSearchHorizonTooLong(t *testing.T) {
+	// Perform a GET request with that handler, with a search horizon beyond the maximum.
+	w := performLuneRequest(lr, "GET", "/api/v2/moon?datetime=2021-05-14T00:00:00.000Z&longitude=-155.468094&latitude=19.798484&next=true&days=365")
+
+	// Assert we rejected the request, the request gives a 400:
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,64 @@
+package occultation
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/observerly/dusk/pkg/dusk"
+	"github.com/observerly/nocturnal/internal/envelope"
+	"github.com/observerly/nocturnal/internal/query"
+	"github.com/observerly/nocturnal/pkg/occultation"
+)
+
+// GET /occultation v2
+func GetOccultation(c *gin.Context) {
+	// Parse the number of days to search from the request query:
+	days, err := strconv.Atoi(c.DefaultQuery("days", strconv.Itoa(1)))
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, envelope.NewError(c, err.Error()))
+		return
+	}
+
+	// A named target (e.g., a planet or a star of the catalogue) takes precedence over the ra and dec:
+	if name, exists := c.GetQuery("target"); exists {
+		res, err := occultation.GetNamedOccultations(c.Request.Context(), query.GetObserver(c), name, days)
+
+		if err != nil {
+			envelope.WriteComputationError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, res)
+		return
+	}
+
+	// Parse the Right Ascension from the request query:
+	ra, err := strconv.ParseFloat(c.DefaultQuery("ra", strconv.Itoa(0)), 64)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, envelope.NewError(c, "ra: must be a number of degrees between 0 and 360"))
+		return
+	}
+
+	// Parse the Declination from the request query:
+	dec, err := strconv.ParseFloat(c.DefaultQuery("dec", strconv.Itoa(0)), 64)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, envelope.NewError(c, "dec: must be a number of degrees between -90 and 90"))
+		return
+	}
+
+	res, err := occultation.GetOccultations(c.Request.Context(), query.GetObserver(c), dusk.EquatorialCoordinate{
+		RightAscension: ra,
+		Declination:    dec,
+	}, days)
+
+	if err != nil {
+		envelope.WriteComputationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
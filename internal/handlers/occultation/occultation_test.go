@@ -0,0 +1,206 @@
+package occultation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func SetupOccultationRouter() *gin.Engine {
+	mode := os.Getenv("GIN_MODE")
+
+	if mode == "release" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	// Create gin router
+	r := gin.Default()
+
+	r.GET("/api/v2/occultation", GetOccultation)
+
+	return r
+}
+
+// Setup the Gin API router:
+var r = SetupOccultationRouter()
+
+// Setup the base response struct:
+var response struct {
+	Observer     map[string]interface{}   `json:"observer"`
+	Target       map[string]interface{}   `json:"target"`
+	Occultations []map[string]interface{} `json:"occultations"`
+}
+
+func performRequest(r http.Handler, method, path string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+// Perform a GET request with that handler, for a target 0.1° north of the Moon's topocentric centre at 06:00 UTC:
+var w = performRequest(r, "GET", "/api/v2/occultation?datetime=2021-05-14T00:00:00.000Z&longitude=-155.468094&latitude=19.798484&ra=78.55984348656054&dec=23.81131183003557")
+
+// Perform a GET request with that handler, for a target that the Moon can never occult:
+var x = performRequest(r, "GET", "/api/v2/occultation?datetime=2021-05-14T00:00:00.000Z&longitude=-155.468094&latitude=19.798484&ra=88.792958&dec=77.407064&days=31")
+
+// Perform a GET request with that handler, for a search window that is too long:
+var y = performRequest(r, "GET", "/api/v2/occultation?datetime=2021-05-14T00:00:00.000Z&longitude=-155.468094&latitude=19.798484&ra=88.792958&dec=7.407064&days=365")
+
+// Perform a GET request with that handler, for Mars (which the Moon occulted over North America on 2022-12-08):
+var m = performRequest(r, "GET", "/api/v2/occultation?datetime=2022-12-07T00:00:00.000Z&longitude=-104.9903&latitude=39.7392&target=Mars&days=3")
+
+func TestOccultationRouteStatusCode(t *testing.T) {
+	// Assert we encoded correctly, the request gives a 200:
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestGetOccultationRouteObserver(t *testing.T) {
+	// Build our expected observer section of body
+	observer := gin.H{
+		"datetime":  "2021-05-14T00:00:00Z",
+		"latitude":  19.798484,
+		"longitude": -155.468094,
+	}
+
+	// Convert the JSON response:
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+
+	// Grab the observer & whether or not it exists
+	datetime, exists := response.Observer["datetime"]
+	assert.True(t, exists)
+
+	// Grab the observer & whether or not it exists
+	latitude, exists := response.Observer["latitude"]
+	assert.True(t, exists)
+
+	// Grab the observer & whether or not it exists
+	longitude, exists := response.Observer["longitude"]
+	assert.True(t, exists)
+
+	// Assert on the correctness of the response:
+	assert.Nil(t, err)
+	assert.Equal(t, datetime, observer["datetime"])
+	assert.Equal(t, latitude, observer["latitude"])
+	assert.Equal(t, longitude, observer["longitude"])
+}
+
+func TestGetOccultationRouteOccultation(t *testing.T) {
+	// Build our expected disappearance and reappearance sections of body
+	disappearance := gin.H{
+		"LCT": "2021-05-13T19:31:00-10:00",
+		"UTC": "2021-05-14T05:31:00Z",
+	}
+
+	reappearance := gin.H{
+		"LCT": "2021-05-13T20:27:07-10:00",
+		"UTC": "2021-05-14T06:27:07Z",
+	}
+
+	// Convert the JSON response:
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+
+	// Assert that there is exactly one occultation in the search window:
+	assert.Nil(t, err)
+	assert.Len(t, response.Occultations, 1)
+
+	occultation := response.Occultations[0]
+
+	// Obtain the disappearance and test whether or not it exists:
+	d, exists := occultation["disappearance"].(map[string]interface{})
+	assert.True(t, exists)
+
+	// Obtain the reappearance and test whether or not it exists:
+	r, exists := occultation["reappearance"].(map[string]interface{})
+	assert.True(t, exists)
+
+	// Assert on the correctness of the response:
+	assert.Equal(t, d["LCT"], disappearance["LCT"])
+	assert.Equal(t, d["UTC"], disappearance["UTC"])
+	assert.Equal(t, r["LCT"], reappearance["LCT"])
+	assert.Equal(t, r["UTC"], reappearance["UTC"])
+
+	// The Moon moves eastwards, so the target disappears on the eastern limb and reappears on the western limb:
+	assert.Greater(t, d["angle"], 0.0)
+	assert.Less(t, d["angle"], 180.0)
+	assert.Greater(t, r["angle"], 180.0)
+	assert.Less(t, r["angle"], 360.0)
+
+	// Assert on the minimum separation from the centre of the Moon:
+	assert.InDelta(t, occultation["separation"], 0.1, 0.001)
+
+	// Assert that the target is above the horizon (shortly after sunset) at both contacts:
+	assert.Greater(t, d["alt"], 0.0)
+	assert.Greater(t, r["alt"], 0.0)
+	assert.Equal(t, true, occultation["visible"])
+}
+
+func TestGetOccultationRouteNamedTarget(t *testing.T) {
+	// Convert the JSON response:
+	err := json.Unmarshal(m.Body.Bytes(), &response)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, m.Code)
+
+	// Assert that the target is named, with its position at the observer's datetime:
+	assert.Equal(t, "mars", response.Target["name"])
+	assert.InDelta(t, 75.17, response.Target["ra"], 0.01)
+	assert.InDelta(t, 24.99, response.Target["dec"], 0.01)
+
+	assert.Len(t, response.Occultations, 1)
+
+	occultation := response.Occultations[0]
+
+	d := occultation["disappearance"].(map[string]interface{})
+
+	r := occultation["reappearance"].(map[string]interface{})
+
+	// Assert on the disappearance and reappearance of Mars, as seen from Denver in the evening of 2022-12-07:
+	assert.Equal(t, "2022-12-07T19:53:38-07:00", d["LCT"])
+	assert.Equal(t, "2022-12-07T20:56:25-07:00", r["LCT"])
+	assert.Equal(t, true, occultation["visible"])
+}
+
+func TestGetOccultationRouteInvalidTarget(t *testing.T) {
+	for _, tc := range []struct {
+		query string
+		error string
+	}{
+		{"ra=360&dec=0", "ra: must be a number of degrees between 0 and 360"},
+		{"ra=-1&dec=0", "ra: must be a number of degrees between 0 and 360"},
+		{"ra=east&dec=0", "ra: must be a number of degrees between 0 and 360"},
+		{"ra=0&dec=90.5", "dec: must be a number of degrees between -90 and 90"},
+		{"target=vulcan", `target: "vulcan" must be a planet`},
+	} {
+		w := performRequest(r, "GET", "/api/v2/occultation?datetime=2021-05-14T00:00:00.000Z&longitude=-155.468094&latitude=19.798484&"+tc.query)
+
+		var e struct {
+			Error string `json:"error"`
+		}
+
+		// Assert we rejected the request, the request gives a 400 with the reason:
+		assert.Equal(t, http.StatusBadRequest, w.Code, tc.query)
+		assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &e))
+		assert.Contains(t, e.Error, tc.error, tc.query)
+	}
+}
+
+func TestGetOccultationRouteNoOccultation(t *testing.T) {
+	// Convert the JSON response:
+	err := json.Unmarshal(x.Body.Bytes(), &response)
+
+	// Assert on the correctness of the response:
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, x.Code)
+	assert.Len(t, response.Occultations, 0)
+}
+
+func TestGetOccultationRouteSearchWindowTooLong(t *testing.T) {
+	// Assert we rejected the request, the request gives a 400:
+	assert.Equal(t, http.StatusBadRequest, y.Code)
+}
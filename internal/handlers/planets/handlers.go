@@ -0,0 +1,47 @@
+package planets
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/observerly/nocturnal/internal/envelope"
+	"github.com/observerly/nocturnal/internal/query"
+	"github.com/observerly/nocturnal/pkg/events"
+	"github.com/observerly/nocturnal/pkg/observer"
+	"github.com/observerly/nocturnal/pkg/planets"
+)
+
+// GET /planets v3, the rise, maximum and set of every planet (or only the planet given by the "planet" query):
+func GetPlanets(c *gin.Context) {
+	datetime, longitude, latitude, elevation, err := query.ParseObserverParams(c)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, envelope.NewError(c, err.Error()))
+		return
+	}
+
+	names := []string{}
+
+	if name, exists := c.GetQuery("planet"); exists {
+		names = append(names, name)
+	}
+
+	location, err := observer.GetLocation(longitude, latitude)
+
+	if err != nil {
+		envelope.WriteComputationError(c, err)
+		return
+	}
+
+	bodies, err := planets.GetPlanetaryEvents(c.Request.Context(), datetime, names, longitude, latitude, location)
+
+	if err != nil {
+		envelope.WriteComputationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, events.Response{
+		Observer: events.NewObserver(datetime, longitude, latitude, elevation, location),
+		Bodies:   bodies,
+	})
+}
@@ -0,0 +1,38 @@
+package planets
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPlanets(t *testing.T) {
+	// Set gin mode to test mode:
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+
+	r.GET("/api/v3/planets", GetPlanets)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v3/planets?datetime=2021-05-14T00:00:00-10:00&longitude=-155.468094&latitude=19.798484&planet=Mars", nil)
+
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"name":"mars"`)
+	assert.Contains(t, w.Body.String(), `"type":"rise"`)
+	assert.Contains(t, w.Body.String(), `"timezone":"Pacific/Honolulu"`)
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v3/planets?planet=pluto", nil)
+
+	w = httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,111 @@
+package sun
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/observerly/dusk/pkg/dusk"
+	"github.com/observerly/nocturnal/internal/envelope"
+	"github.com/observerly/nocturnal/internal/query"
+	"github.com/observerly/nocturnal/internal/utils"
+	"github.com/observerly/nocturnal/pkg/computation"
+	"github.com/observerly/nocturnal/pkg/events"
+	"github.com/observerly/nocturnal/pkg/observer"
+	"github.com/observerly/nocturnal/pkg/sun"
+)
+
+// GET Sun
+func GetSunDeprecatedV1(c *gin.Context) {
+	d, lon, lat := query.GetDefaultObserverParams(c)
+
+	datetime, _ := utils.ParseDatetimeRFC3339(d)
+
+	longitude, _ := strconv.ParseFloat(lon, 64)
+
+	latitude, _ := strconv.ParseFloat(lat, 64)
+
+	eq := dusk.GetSolarEquatorialPosition(datetime)
+
+	hz := dusk.ConvertEquatorialCoordinateToHorizontal(datetime, longitude, latitude, eq)
+
+	span := computation.Start(c.Request.Context(), "solar_rise_set")
+
+	rstoday, _ := dusk.GetSunriseSunsetTimes(datetime, 0, longitude, latitude, 0)
+
+	rstomorrow, _ := dusk.GetSunriseSunsetTimes(datetime.Add(time.Hour*24), 0, longitude, latitude, 0)
+
+	span.End()
+
+	observer := gin.H{
+		"datetime":  datetime,
+		"longitude": longitude,
+		"latitude":  latitude,
+	}
+
+	position := gin.H{
+		"alt": hz.Altitude,
+		"az":  hz.Azimuth,
+		"ra":  eq.RightAscension,
+		"dec": eq.Declination,
+	}
+
+	transit := gin.H{
+		"rise": rstoday.Rise.Format(time.RFC3339),
+		"set":  rstoday.Set.Format(time.RFC3339),
+	}
+
+	tomorrow := gin.H{
+		"rise": rstomorrow.Rise.Format(time.RFC3339),
+		"set":  rstomorrow.Set.Format(time.RFC3339),
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"observer": observer,
+		"position": position,
+		"transit":  transit,
+		"tomorrow": tomorrow,
+	})
+}
+
+// GET /sun v2
+func GetSun(c *gin.Context) {
+	res, err := sun.GetSolarTransit(c.Request.Context(), query.GetObserver(c))
+
+	if err != nil {
+		envelope.WriteComputationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GET /sun v3
+func GetSunV3(c *gin.Context) {
+	datetime, longitude, latitude, elevation, err := query.ParseObserverParams(c)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, envelope.NewError(c, err.Error()))
+		return
+	}
+
+	location, err := observer.GetLocation(longitude, latitude)
+
+	if err != nil {
+		envelope.WriteComputationError(c, err)
+		return
+	}
+
+	evs, err := sun.GetSolarEvents(c.Request.Context(), datetime, longitude, latitude, location)
+
+	if err != nil {
+		envelope.WriteComputationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, events.Response{
+		Observer: events.NewObserver(datetime, longitude, latitude, elevation, location),
+		Bodies:   []events.Body{events.NewBody("sun", evs...)},
+	})
+}
@@ -122,6 +122,70 @@ func TestGetSunRouteRise(t *testing.T) {
 	assert.InDelta(t, ra, rise["ra"], precision)
 }
 
+func TestGetSunRouteMaximum(t *testing.T) {
+	// Build our expected maximum section of body
+	maximum := gin.H{
+		"LCT": "2021-05-14T12:18:18-10:00",
+		"UTC": "2021-05-14T22:18:18Z",
+		"alt": 89.05753502404762,
+		"az":  179.97220865980813,
+		"dec": 18.856019134273545,
+		"ra":  51.9828435538231,
+		"R":   0.00024719885065677144,
+		"X":   1.0001250800806323,
+	}
+
+	// Convert the JSON response:
+	err := json.Unmarshal(sw.Body.Bytes(), &response)
+
+	// Obtain the Local Civil Time of the maximum and test whether or not it exists:
+	LCT, exists := response["maximum"]["LCT"]
+	assert.True(t, exists)
+
+	// Obtain the Universal Time of the maximum and test whether or not it exists:
+	UTC, exists := response["maximum"]["UTC"]
+	assert.True(t, exists)
+
+	// Obtain the altitude of the maximum and test whether or not it exists:
+	alt, exists := response["maximum"]["alt"]
+	assert.True(t, exists)
+
+	// Obtain the azimuth of the maximum and test whether or not it exists:
+	az, exists := response["maximum"]["az"]
+	assert.True(t, exists)
+
+	// Obtain the declination of the maximum and test whether or not it exists:
+	dec, exists := response["maximum"]["dec"]
+	assert.True(t, exists)
+
+	// Obtain the right ascension of the maximum and test whether or not it exists:
+	ra, exists := response["maximum"]["ra"]
+	assert.True(t, exists)
+
+	// Assert on the correctness of the response:
+	assert.Nil(t, err)
+	assert.Equal(t, LCT, maximum["LCT"])
+	assert.Equal(t, UTC, maximum["UTC"])
+	assert.InDelta(t, alt, maximum["alt"], precision)
+	assert.InDelta(t, az, maximum["az"], precision)
+	assert.InDelta(t, dec, maximum["dec"], precision)
+	assert.InDelta(t, ra, maximum["ra"], precision)
+
+	// Obtain the atmospheric refraction of the maximum and test whether or not it exists:
+	R, exists := response["maximum"]["R"]
+	assert.True(t, exists)
+	assert.InDelta(t, R, maximum["R"], precision)
+
+	// Obtain the relative air mass of the maximum and test whether or not it exists:
+	X, exists := response["maximum"]["X"]
+	assert.True(t, exists)
+	assert.InDelta(t, X, maximum["X"], precision)
+
+	// Assert that the refraction and air mass are null when the Sun is below the horizon, i.e., at its set:
+	assert.Nil(t, response["set"]["R"])
+	assert.Nil(t, response["set"]["X"])
+}
+
 func TestGetSunRouteSet(t *testing.T) {
 	// Build our expected set section of body
 	set := gin.H{
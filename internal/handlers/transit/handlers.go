@@ -0,0 +1,180 @@
+package transit
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/observerly/dusk/pkg/dusk"
+	"github.com/observerly/nocturnal/internal/envelope"
+	"github.com/observerly/nocturnal/internal/query"
+	"github.com/observerly/nocturnal/internal/utils"
+	"github.com/observerly/nocturnal/pkg/events"
+	"github.com/observerly/nocturnal/pkg/observer"
+	"github.com/observerly/nocturnal/pkg/transit"
+)
+
+// formatEventDatetime returns the local civil time of the event as RFC3339, or nil if the event does not occur:
+func formatEventDatetime(p *transit.Properties) *string {
+	if p == nil {
+		return nil
+	}
+
+	return utils.FormatDatetimeRFC3339(&p.LCT)
+}
+
+// GET /transit
+func GetTransitDeprecatedV1(c *gin.Context) {
+	d, lon, lat := query.GetDefaultObserverParams(c)
+
+	ra := c.DefaultQuery("ra", strconv.Itoa(0))
+
+	dec := c.DefaultQuery("dec", strconv.Itoa(0))
+
+	datetime, _ := utils.ParseDatetimeRFC3339(d)
+
+	longitude, _ := strconv.ParseFloat(lon, 64)
+
+	latitude, _ := strconv.ParseFloat(lat, 64)
+
+	rightAscension, _ := strconv.ParseFloat(ra, 64)
+
+	declination, _ := strconv.ParseFloat(dec, 64)
+
+	eq := dusk.EquatorialCoordinate{RightAscension: rightAscension, Declination: declination}
+
+	hz := dusk.ConvertEquatorialCoordinateToHorizontal(datetime, longitude, latitude, eq)
+
+	mec := dusk.GetLunarEclipticPosition(datetime)
+
+	meq := dusk.GetLunarEquatorialPosition(datetime)
+
+	mph := dusk.GetLunarPhase(datetime, longitude, mec)
+
+	tr, _ := transit.GetObjectTransit(c.Request.Context(), observer.New(datetime, longitude, latitude, 0), eq)
+
+	airmass := dusk.GetRelativeAirMass(hz.Altitude)
+
+	refraction := dusk.GetAtmosphericRefraction(hz.Altitude)
+
+	separation := dusk.GetAngularSeparation(dusk.Coordinate{Latitude: eq.Declination, Longitude: eq.RightAscension}, dusk.Coordinate{Latitude: meq.Declination, Longitude: meq.RightAscension})
+
+	observer := gin.H{
+		"datetime":  datetime,
+		"longitude": longitude,
+		"latitude":  latitude,
+	}
+
+	phase := gin.H{
+		"age":          mph.Days,
+		"angle":        mph.Angle,
+		"d":            mph.Age,
+		"fraction":     mph.Fraction,
+		"illumination": mph.Illumination,
+		"separation":   separation,
+	}
+
+	position := gin.H{
+		"alt": hz.Altitude,
+		"az":  hz.Azimuth,
+		"ra":  rightAscension,
+		"dec": declination,
+		"R":   refraction,
+		"X":   airmass,
+	}
+
+	properties := gin.H{
+		"maximum": formatEventDatetime(tr.Maximum),
+		"rise":    formatEventDatetime(tr.Rise),
+		"set":     formatEventDatetime(tr.Set),
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"phase":      phase,
+		"observer":   observer,
+		"position":   position,
+		"properties": properties,
+		"path":       tr.Path,
+	})
+}
+
+// GET /transit v2
+func GetTransit(c *gin.Context) {
+	// Parse the Right Ascension from the request query:
+	ra, err := strconv.ParseFloat(c.DefaultQuery("ra", strconv.Itoa(0)), 64)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, envelope.NewError(c, err.Error()))
+		return
+	}
+
+	// Parse the Declination from the request query:
+	dec, err := strconv.ParseFloat(c.DefaultQuery("dec", strconv.Itoa(0)), 64)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, envelope.NewError(c, err.Error()))
+		return
+	}
+
+	res, err := transit.GetObjectTransit(c.Request.Context(), query.GetObserver(c), dusk.EquatorialCoordinate{
+		RightAscension: ra,
+		Declination:    dec,
+	})
+
+	if err != nil {
+		envelope.WriteComputationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GET /transit v3
+func GetTransitV3(c *gin.Context) {
+	datetime, longitude, latitude, elevation, err := query.ParseObserverParams(c)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, envelope.NewError(c, err.Error()))
+		return
+	}
+
+	// Parse the Right Ascension from the request query:
+	ra, err := strconv.ParseFloat(c.DefaultQuery("ra", strconv.Itoa(0)), 64)
+
+	if err != nil || ra < 0 || ra >= 360 {
+		c.JSON(http.StatusBadRequest, envelope.NewError(c, "ra: must be a number of degrees between 0 and 360"))
+		return
+	}
+
+	// Parse the Declination from the request query:
+	dec, err := strconv.ParseFloat(c.DefaultQuery("dec", strconv.Itoa(0)), 64)
+
+	if err != nil || dec < -90 || dec > 90 {
+		c.JSON(http.StatusBadRequest, envelope.NewError(c, "dec: must be a number of degrees between -90 and 90"))
+		return
+	}
+
+	location, err := observer.GetLocation(longitude, latitude)
+
+	if err != nil {
+		envelope.WriteComputationError(c, err)
+		return
+	}
+
+	eq := dusk.EquatorialCoordinate{
+		RightAscension: ra,
+		Declination:    dec,
+	}
+
+	evs, err := transit.GetTransitEvents(c.Request.Context(), datetime, eq, longitude, latitude, location)
+
+	if err != nil {
+		envelope.WriteComputationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, events.Response{
+		Observer: events.NewObserver(datetime, longitude, latitude, elevation, location),
+		Bodies:   []events.Body{events.NewBody("target", evs...)},
+	})
+}
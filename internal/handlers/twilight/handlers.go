@@ -0,0 +1,53 @@
+package twilight
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/observerly/nocturnal/internal/envelope"
+	"github.com/observerly/nocturnal/internal/query"
+	"github.com/observerly/nocturnal/pkg/events"
+	"github.com/observerly/nocturnal/pkg/observer"
+	"github.com/observerly/nocturnal/pkg/twilight"
+)
+
+// GET /twilight v2
+func GetTwilight(c *gin.Context) {
+	res, err := twilight.GetTwilightPeriods(c.Request.Context(), query.GetObserver(c))
+
+	if err != nil {
+		envelope.WriteComputationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GET /twilight v3, the twilight events of the Sun on the night of the datetime:
+func GetTwilightV3(c *gin.Context) {
+	datetime, longitude, latitude, elevation, err := query.ParseObserverParams(c)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, envelope.NewError(c, err.Error()))
+		return
+	}
+
+	location, err := observer.GetLocation(longitude, latitude)
+
+	if err != nil {
+		envelope.WriteComputationError(c, err)
+		return
+	}
+
+	evs, err := twilight.GetTwilightEvents(c.Request.Context(), datetime, longitude, latitude, location)
+
+	if err != nil {
+		envelope.WriteComputationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, events.Response{
+		Observer: events.NewObserver(datetime, longitude, latitude, elevation, location),
+		Bodies:   []events.Body{events.NewBody("sun", evs...)},
+	})
+}
@@ -0,0 +1,94 @@
+package health
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/observerly/dusk/pkg/dusk"
+
+	"github.com/observerly/nocturnal/pkg/observer"
+)
+
+// The tolerance (in degrees) within which the canary ephemeris must agree with its reference position:
+const EPHEMERIS_TOLERANCE float64 = 0.01
+
+// The canary datetime, and the apparent equatorial position of the Sun at that datetime:
+// @see ex.25.a p.156 of Meeus, Jean. 1991. Astronomical algorithms. Richmond, Va: Willmann-Bell.
+var canaryDatetime = time.Date(1992, 10, 13, 0, 0, 0, 0, time.UTC)
+
+var canaryEquatorialCoordinate = dusk.EquatorialCoordinate{
+	RightAscension: 198.38083,
+	Declination:    -7.78507,
+}
+
+// CheckTimezone determines whether the timezone data is loadable, e.g., for an observer at Mauna Kea, Hawaii:
+func CheckTimezone() error {
+	location, err := observer.GetLocation(-155.468094, 19.798484)
+
+	if err != nil {
+		return err
+	}
+
+	if location.String() != "Pacific/Honolulu" {
+		return fmt.Errorf("expected the timezone Pacific/Honolulu, but got %s", location.String())
+	}
+
+	return nil
+}
+
+// CheckEphemeris determines whether a canary ephemeris (the position of the Sun) computes within tolerance:
+func CheckEphemeris() error {
+	eq := dusk.GetSolarEquatorialPosition(canaryDatetime)
+
+	Δα := math.Abs(eq.RightAscension - canaryEquatorialCoordinate.RightAscension)
+
+	Δδ := math.Abs(eq.Declination - canaryEquatorialCoordinate.Declination)
+
+	if math.IsNaN(Δα) || math.IsNaN(Δδ) || Δα > EPHEMERIS_TOLERANCE || Δδ > EPHEMERIS_TOLERANCE {
+		return fmt.Errorf("expected the canary ephemeris to be within %v° of (%v°, %v°), but got (%v°, %v°)", EPHEMERIS_TOLERANCE, canaryEquatorialCoordinate.RightAscension, canaryEquatorialCoordinate.Declination, eq.RightAscension, eq.Declination)
+	}
+
+	return nil
+}
+
+// GET /healthz
+func GetLiveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status": "ok",
+	})
+}
+
+// GET /readyz
+func GetReadiness(c *gin.Context) {
+	status := http.StatusOK
+
+	checks := gin.H{}
+
+	for name, check := range map[string]func() error{
+		"timezone":  CheckTimezone,
+		"ephemeris": CheckEphemeris,
+	} {
+		if err := check(); err != nil {
+			status = http.StatusServiceUnavailable
+			checks[name] = err.Error()
+		} else {
+			checks[name] = "ok"
+		}
+	}
+
+	if status != http.StatusOK {
+		c.JSON(status, gin.H{
+			"status": "unavailable",
+			"checks": checks,
+		})
+		return
+	}
+
+	c.JSON(status, gin.H{
+		"status": "ok",
+		"checks": checks,
+	})
+}
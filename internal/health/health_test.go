@@ -0,0 +1,65 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func SetupHealthRouter() *gin.Engine {
+	// Set gin mode to test mode:
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+
+	r.GET("/healthz", GetLiveness)
+	r.GET("/readyz", GetReadiness)
+
+	return r
+}
+
+func performRequest(r http.Handler, method, path string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+var r = SetupHealthRouter()
+
+func TestCheckTimezone(t *testing.T) {
+	assert.Nil(t, CheckTimezone())
+}
+
+func TestCheckEphemeris(t *testing.T) {
+	assert.Nil(t, CheckEphemeris())
+}
+
+func TestGetLivenessRoute(t *testing.T) {
+	w := performRequest(r, "GET", "/healthz")
+
+	// Assert we encoded correctly, the request gives a 200:
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	assert.JSONEq(t, `{"status":"ok"}`, w.Body.String())
+}
+
+func TestGetReadinessRoute(t *testing.T) {
+	w := performRequest(r, "GET", "/readyz")
+
+	// Assert we encoded correctly, the request gives a 200:
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+
+	// Assert on the correctness of the response:
+	assert.Nil(t, err)
+	assert.Equal(t, "ok", response["status"])
+	assert.Equal(t, map[string]interface{}{"timezone": "ok", "ephemeris": "ok"}, response["checks"])
+}
@@ -0,0 +1,71 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/exp/slog"
+)
+
+// ParseLevel parses a log level, i.e., one of "debug", "info", "warn" or "error":
+func ParseLevel(level string) (slog.Level, error) {
+	var l slog.Level
+
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return l, fmt.Errorf("unknown log level %q", level)
+	}
+
+	return l, nil
+}
+
+// getSeverity returns the Cloud Logging severity for the level, e.g., "WARNING" rather than "WARN":
+func getSeverity(level slog.Level) string {
+	switch {
+	case level >= slog.LevelError:
+		return "ERROR"
+	case level >= slog.LevelWarn:
+		return "WARNING"
+	case level >= slog.LevelInfo:
+		return "INFO"
+	default:
+		return "DEBUG"
+	}
+}
+
+// replaceAttr renames the standard attributes to the special fields recognised by Cloud Logging:
+// @see https://cloud.google.com/logging/docs/structured-logging#special-payload-fields
+func replaceAttr(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) > 0 {
+		return a
+	}
+
+	switch a.Key {
+	case slog.LevelKey:
+		level, _ := a.Value.Any().(slog.Level)
+		return slog.String("severity", getSeverity(level))
+	case slog.MessageKey:
+		a.Key = "message"
+	}
+
+	return a
+}
+
+// New creates a structured JSON logger, suitable for Cloud Logging, that writes each record as a line to w:
+func New(w io.Writer, level slog.Level) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: replaceAttr,
+	}))
+}
+
+// NewFromLevel creates a structured JSON logger (see New) from the name of the level, e.g., "info":
+func NewFromLevel(w io.Writer, level string) (*slog.Logger, error) {
+	l, err := ParseLevel(strings.TrimSpace(level))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return New(w, l), nil
+}
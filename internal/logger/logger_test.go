@@ -0,0 +1,59 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/exp/slog"
+)
+
+func TestParseLevel(t *testing.T) {
+	level, err := ParseLevel("warn")
+
+	assert.Nil(t, err)
+	assert.Equal(t, slog.LevelWarn, level)
+
+	_, err = ParseLevel("verbose")
+
+	assert.ErrorContains(t, err, "unknown log level")
+}
+
+func TestNew(t *testing.T) {
+	var b bytes.Buffer
+
+	log := New(&b, slog.LevelInfo)
+
+	log.Warn("request", "status", 404)
+
+	// Assert that debug records are not written below the level:
+	log.Debug("ignored")
+
+	var record map[string]interface{}
+
+	err := json.Unmarshal(b.Bytes(), &record)
+
+	// Assert that the record is a single line of JSON with the Cloud Logging fields:
+	assert.Nil(t, err)
+	assert.Equal(t, "WARNING", record["severity"])
+	assert.Equal(t, "request", record["message"])
+	assert.Equal(t, float64(404), record["status"])
+	assert.NotEmpty(t, record["time"])
+}
+
+func TestNewFromLevel(t *testing.T) {
+	var b bytes.Buffer
+
+	log, err := NewFromLevel(&b, "error")
+
+	assert.Nil(t, err)
+
+	log.Info("ignored")
+
+	assert.Empty(t, b.String())
+
+	_, err = NewFromLevel(&b, "verbose")
+
+	assert.NotNil(t, err)
+}
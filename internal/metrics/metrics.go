@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/observerly/nocturnal/internal/cache"
+)
+
+// The namespace that every metric exposed by nocturnal is prefixed with:
+const NAMESPACE string = "nocturnal"
+
+// The total number of HTTP requests, by method, route and status code:
+var RequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: NAMESPACE,
+	Name:      "http_requests_total",
+	Help:      "The total number of HTTP requests, by method, route and status code.",
+}, []string{"method", "route", "status"})
+
+// The latency of HTTP requests (in seconds), by method, route and status code:
+var RequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: NAMESPACE,
+	Name:      "http_request_duration_seconds",
+	Help:      "The latency of HTTP requests (in seconds), by method, route and status code.",
+	Buckets:   prometheus.DefBuckets,
+}, []string{"method", "route", "status"})
+
+// The total number of requests to the routes of deprecated versions of the API, by version and route:
+var DeprecatedRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: NAMESPACE,
+	Name:      "deprecated_requests_total",
+	Help:      "The total number of requests to the routes of deprecated versions of the API, by version and route.",
+}, []string{"version", "route"})
+
+// The time spent (in seconds) in each dusk computation, e.g., rise and set solving or path generation:
+var ComputationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: NAMESPACE,
+	Name:      "computation_duration_seconds",
+	Help:      "The time spent (in seconds) in each dusk computation, e.g., rise and set solving or path generation.",
+	// From 100µs up to ~26s, as a search over many days can take several seconds:
+	Buckets: prometheus.ExponentialBuckets(0.0001, 4, 10),
+}, []string{"computation"})
+
+// ObserveRequest records the count and latency of a completed HTTP request:
+func ObserveRequest(method string, route string, status int, duration time.Duration) {
+	code := strconv.Itoa(status)
+
+	RequestsTotal.WithLabelValues(method, route, code).Inc()
+
+	RequestDuration.WithLabelValues(method, route, code).Observe(duration.Seconds())
+}
+
+// NewComputationTimer starts timing the named dusk computation, which is recorded when ObserveDuration is called:
+func NewComputationTimer(computation string) *prometheus.Timer {
+	return prometheus.NewTimer(ComputationDuration.WithLabelValues(computation))
+}
+
+// newCacheCollectors exposes the hit and miss counters of the response cache:
+func newCacheCollectors(stats *cache.Metrics) []prometheus.Collector {
+	return []prometheus.Collector{
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Name:      "cache_hits_total",
+			Help:      "The total number of responses served from the response cache.",
+		}, func() float64 {
+			return float64(stats.Stats().Hits)
+		}),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: NAMESPACE,
+			Name:      "cache_misses_total",
+			Help:      "The total number of responses not found in the response cache.",
+		}, func() float64 {
+			return float64(stats.Stats().Misses)
+		}),
+	}
+}
+
+// Handler returns the Prometheus exposition handler for the request, computation and (optional) cache metrics, along
+// with the standard Go runtime and process metrics.
+func Handler(stats *cache.Metrics) http.Handler {
+	registry := prometheus.NewRegistry()
+
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		RequestsTotal,
+		RequestDuration,
+		DeprecatedRequestsTotal,
+		ComputationDuration,
+	)
+
+	if stats != nil {
+		registry.MustRegister(newCacheCollectors(stats)...)
+	}
+
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/observerly/nocturnal/internal/cache"
+)
+
+func TestObserveRequest(t *testing.T) {
+	ObserveRequest(http.MethodGet, "/api/v2/moon", http.StatusOK, 10*time.Millisecond)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(RequestsTotal.WithLabelValues(http.MethodGet, "/api/v2/moon", "200")))
+}
+
+func TestNewComputationTimer(t *testing.T) {
+	timer := NewComputationTimer("lunar_rise_set")
+
+	timer.ObserveDuration()
+
+	assert.Equal(t, 1, testutil.CollectAndCount(ComputationDuration, "nocturnal_computation_duration_seconds"))
+}
+
+func TestHandler(t *testing.T) {
+	stats := &cache.Metrics{}
+
+	stats.Hit()
+	stats.Miss()
+	stats.Miss()
+
+	ObserveRequest(http.MethodGet, "/api/v2/sun", http.StatusBadRequest, time.Millisecond)
+
+	DeprecatedRequestsTotal.WithLabelValues("v1", "/api/v1/sun").Inc()
+
+	req, _ := http.NewRequest(http.MethodGet, "/metrics", nil)
+
+	w := httptest.NewRecorder()
+
+	Handler(stats).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "nocturnal_cache_hits_total 1")
+	assert.Contains(t, w.Body.String(), "nocturnal_cache_misses_total 2")
+	assert.Contains(t, w.Body.String(), `nocturnal_http_requests_total{method="GET",route="/api/v2/sun",status="400"} 1`)
+	assert.Contains(t, w.Body.String(), "nocturnal_http_request_duration_seconds_bucket")
+	assert.Contains(t, w.Body.String(), `nocturnal_deprecated_requests_total{route="/api/v1/sun",version="v1"} 1`)
+	assert.Contains(t, w.Body.String(), "go_goroutines")
+}
@@ -0,0 +1,228 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/observerly/nocturnal/internal/cache"
+)
+
+type CacheOptions struct {
+	// How long a response is cached for, by both the Store and by clients (via Cache-Control):
+	TTL time.Duration
+	// The number of decimal places that the observer's latitude and longitude are rounded to:
+	CoordinatePrecision int
+	// The duration that the observer's datetime is rounded to:
+	DatetimePrecision time.Duration
+	// The hit and miss counters for the cache:
+	Metrics *cache.Metrics
+}
+
+// cacheWriter buffers the response body as it is written, so that it can be stored before it is sent:
+type cacheWriter struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (w *cacheWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+func (w *cacheWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func roundCoordinate(value string, precision int) string {
+	coordinate, err := strconv.ParseFloat(value, 64)
+
+	if err != nil {
+		return value
+	}
+
+	scale := math.Pow(10, float64(precision))
+
+	return strconv.FormatFloat(math.Round(coordinate*scale)/scale, 'f', -1, 64)
+}
+
+func roundDatetime(value string, precision time.Duration) string {
+	datetime, err := time.Parse(time.RFC3339, value)
+
+	if err != nil {
+		return value
+	}
+
+	return datetime.Round(precision).Format(time.RFC3339)
+}
+
+// QuantiseObserverQuery rounds the observer's latitude, longitude and datetime (defaulting to now) in the query, so
+// that requests for nearby observers at nearby times share the same (canonical and sorted) query:
+func QuantiseObserverQuery(query url.Values, options CacheOptions) url.Values {
+	quantised := url.Values{}
+
+	for key, values := range query {
+		quantised[key] = append([]string(nil), values...)
+	}
+
+	if !quantised.Has("datetime") {
+		quantised.Set("datetime", time.Now().Format(time.RFC3339))
+	}
+
+	quantised.Set("datetime", roundDatetime(quantised.Get("datetime"), options.DatetimePrecision))
+
+	for _, key := range []string{"latitude", "longitude"} {
+		if quantised.Has(key) {
+			quantised.Set(key, roundCoordinate(quantised.Get(key), options.CoordinatePrecision))
+		}
+	}
+
+	return quantised
+}
+
+func getCacheKey(path string, query url.Values) string {
+	keys := make([]string, 0, len(query))
+
+	for key := range query {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	var b strings.Builder
+
+	b.WriteString(path)
+
+	for i, key := range keys {
+		if i == 0 {
+			b.WriteString("?")
+		} else {
+			b.WriteString("&")
+		}
+
+		b.WriteString(url.QueryEscape(key) + "=" + url.QueryEscape(strings.Join(query[key], ",")))
+	}
+
+	return b.String()
+}
+
+// The Content-Type of a cached response, should the handler not have set one:
+const DEFAULT_CACHE_CONTENT_TYPE = "application/json; charset=utf-8"
+
+// encodeCacheEntry prefixes the body with its Content-Type (on the first line), so that a hit replays the original
+// Content-Type of the response:
+func encodeCacheEntry(contentType string, body []byte) []byte {
+	entry := make([]byte, 0, len(contentType)+1+len(body))
+
+	entry = append(entry, contentType...)
+
+	entry = append(entry, '\n')
+
+	return append(entry, body...)
+}
+
+// decodeCacheEntry splits the cached entry into its Content-Type and body:
+func decodeCacheEntry(entry []byte) (string, []byte, bool) {
+	i := bytes.IndexByte(entry, '\n')
+
+	if i < 0 {
+		return "", nil, false
+	}
+
+	return string(entry[:i]), entry[i+1:], true
+}
+
+func getETag(body []byte) string {
+	sum := sha1.Sum(body)
+	return fmt.Sprintf("\"%s\"", hex.EncodeToString(sum[:]))
+}
+
+// writeCacheableResponse writes the body with the caching headers, or a 304 if the client already has the body:
+func writeCacheableResponse(w gin.ResponseWriter, r *http.Request, contentType string, body []byte, options CacheOptions, status string) {
+	etag := getETag(body)
+
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(options.TTL.Seconds())))
+	w.Header().Set("ETag", etag)
+	w.Header().Set("X-Cache", status)
+
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		w.WriteHeaderNow()
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+// CacheMiddleware serves GET responses from the store, keyed on the endpoint and the quantised observer parameters. The
+// request's query is rewritten with the quantised parameters, so that the response always corresponds to its key.
+func CacheMiddleware(store cache.Store, options CacheOptions) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+
+		query := QuantiseObserverQuery(c.Request.URL.Query(), options)
+
+		c.Request.URL.RawQuery = query.Encode()
+
+		key := getCacheKey(c.Request.URL.Path, query)
+
+		if entry, exists, err := store.Get(c.Request.Context(), key); err == nil && exists {
+			if contentType, body, ok := decodeCacheEntry(entry); ok {
+				if options.Metrics != nil {
+					options.Metrics.Hit()
+				}
+
+				writeCacheableResponse(c.Writer, c.Request, contentType, body, options, "HIT")
+				c.Abort()
+				return
+			}
+		}
+
+		if options.Metrics != nil {
+			options.Metrics.Miss()
+		}
+
+		w := &cacheWriter{ResponseWriter: c.Writer, body: &bytes.Buffer{}}
+
+		c.Writer = w
+
+		// Restore the original writer even if the handler panics, so that the recovery middleware can respond:
+		defer func() {
+			c.Writer = w.ResponseWriter
+		}()
+
+		c.Next()
+
+		body := w.body.Bytes()
+
+		// Only successful responses are cached, e.g., not validation errors:
+		if w.Status() != http.StatusOK || len(body) == 0 {
+			_, _ = w.ResponseWriter.Write(body)
+			return
+		}
+
+		contentType := w.Header().Get("Content-Type")
+
+		if contentType == "" {
+			contentType = DEFAULT_CACHE_CONTENT_TYPE
+		}
+
+		_ = store.Set(c.Request.Context(), key, encodeCacheEntry(contentType, body), options.TTL)
+
+		writeCacheableResponse(w.ResponseWriter, c.Request, contentType, body, options, "MISS")
+	}
+}
@@ -0,0 +1,171 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/observerly/nocturnal/internal/cache"
+)
+
+var options = CacheOptions{
+	TTL:                 time.Minute,
+	CoordinatePrecision: 2,
+	DatetimePrecision:   time.Minute,
+}
+
+func setupCacheRouter(metrics *cache.Metrics, calls *int) *gin.Engine {
+	// Set gin mode to test mode:
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+
+	o := options
+	o.Metrics = metrics
+
+	r.Use(CacheMiddleware(cache.NewLRU(16), o))
+
+	r.GET("/default", func(c *gin.Context) {
+		*calls++
+
+		c.JSON(http.StatusOK, gin.H{
+			"datetime":  c.Query("datetime"),
+			"latitude":  c.Query("latitude"),
+			"longitude": c.Query("longitude"),
+		})
+	})
+
+	r.GET("/text", func(c *gin.Context) {
+		*calls++
+
+		c.String(http.StatusOK, "latitude=%s", c.Query("latitude"))
+	})
+
+	r.GET("/error", func(c *gin.Context) {
+		*calls++
+
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "bad request",
+		})
+	})
+
+	return r
+}
+
+func performCacheRequest(r http.Handler, path string, header http.Header) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodGet, path, nil)
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestQuantiseObserverQuery(t *testing.T) {
+	query, _ := url.ParseQuery("datetime=2021-05-14T00:00:29Z&latitude=19.798484&longitude=-155.468094&ra=88.792958")
+
+	quantised := QuantiseObserverQuery(query, options)
+
+	assert.Equal(t, "2021-05-14T00:00:00Z", quantised.Get("datetime"))
+	assert.Equal(t, "19.8", quantised.Get("latitude"))
+	assert.Equal(t, "-155.47", quantised.Get("longitude"))
+
+	// Assert that other parameters are untouched:
+	assert.Equal(t, "88.792958", quantised.Get("ra"))
+
+	// Assert that the original query is untouched:
+	assert.Equal(t, "19.798484", query.Get("latitude"))
+}
+
+func TestQuantiseObserverQueryDefaultsDatetime(t *testing.T) {
+	quantised := QuantiseObserverQuery(url.Values{}, options)
+
+	datetime, err := time.Parse(time.RFC3339, quantised.Get("datetime"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, datetime.Second())
+}
+
+func TestCacheMiddlewareHit(t *testing.T) {
+	metrics := &cache.Metrics{}
+
+	calls := 0
+
+	r := setupCacheRouter(metrics, &calls)
+
+	// Nearby observers at nearby times should share the same cached response:
+	miss := performCacheRequest(r, "/default?datetime=2021-05-14T00:00:10Z&latitude=19.798484&longitude=-155.468094", nil)
+
+	hit := performCacheRequest(r, "/default?longitude=-155.471&latitude=19.801&datetime=2021-05-13T23:59:50Z", nil)
+
+	assert.Equal(t, http.StatusOK, miss.Code)
+	assert.Equal(t, http.StatusOK, hit.Code)
+	assert.Equal(t, "MISS", miss.Header().Get("X-Cache"))
+	assert.Equal(t, "HIT", hit.Header().Get("X-Cache"))
+	assert.Equal(t, miss.Body.String(), hit.Body.String())
+	assert.Equal(t, miss.Header().Get("ETag"), hit.Header().Get("ETag"))
+	assert.Equal(t, "public, max-age=60", hit.Header().Get("Cache-Control"))
+	assert.Equal(t, "application/json; charset=utf-8", hit.Header().Get("Content-Type"))
+
+	// Assert that the handler always sees the quantised parameters:
+	assert.JSONEq(t, `{"datetime":"2021-05-14T00:00:00Z","latitude":"19.8","longitude":"-155.47"}`, hit.Body.String())
+
+	// Assert that the handler was only called once:
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, cache.Stats{Hits: 1, Misses: 1}, metrics.Stats())
+}
+
+func TestCacheMiddlewareHitContentType(t *testing.T) {
+	calls := 0
+
+	r := setupCacheRouter(nil, &calls)
+
+	miss := performCacheRequest(r, "/text?datetime=2021-05-14T00:00:00Z&latitude=19.798484", nil)
+
+	hit := performCacheRequest(r, "/text?datetime=2021-05-14T00:00:00Z&latitude=19.798484", nil)
+
+	// Assert that a hit replays the original Content-Type of the response:
+	assert.Equal(t, "HIT", hit.Header().Get("X-Cache"))
+	assert.Equal(t, "text/plain; charset=utf-8", miss.Header().Get("Content-Type"))
+	assert.Equal(t, "text/plain; charset=utf-8", hit.Header().Get("Content-Type"))
+	assert.Equal(t, "latitude=19.8", hit.Body.String())
+	assert.Equal(t, 1, calls)
+}
+
+func TestCacheMiddlewareNotModified(t *testing.T) {
+	calls := 0
+
+	r := setupCacheRouter(nil, &calls)
+
+	w := performCacheRequest(r, "/default?datetime=2021-05-14T00:00:00Z", nil)
+
+	etag := w.Header().Get("ETag")
+
+	assert.NotEmpty(t, etag)
+
+	w = performCacheRequest(r, "/default?datetime=2021-05-14T00:00:00Z", http.Header{"If-None-Match": []string{etag}})
+
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+}
+
+func TestCacheMiddlewareDoesNotCacheErrors(t *testing.T) {
+	calls := 0
+
+	r := setupCacheRouter(nil, &calls)
+
+	first := performCacheRequest(r, "/error?datetime=2021-05-14T00:00:00Z", nil)
+
+	second := performCacheRequest(r, "/error?datetime=2021-05-14T00:00:00Z", nil)
+
+	assert.Equal(t, http.StatusBadRequest, first.Code)
+	assert.Equal(t, http.StatusBadRequest, second.Code)
+	assert.JSONEq(t, `{"error":"bad request"}`, second.Body.String())
+	assert.Equal(t, 2, calls)
+}
@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/observerly/nocturnal/pkg/computation"
+)
+
+// ComputationMiddleware carries the tracer in the context of every request, so that each dusk computation of the typed
+// functions that a handler calls (e.g., "solar_rise_set") is started with it, and so traced and timed.
+func ComputationMiddleware(tracer computation.Tracer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(computation.WithTracer(c.Request.Context(), tracer))
+
+		c.Next()
+	}
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/observerly/nocturnal/pkg/computation"
+)
+
+// recordingTracer records the name of every computation that is started:
+type recordingTracer struct {
+	started []string
+}
+
+type recordingSpan struct{}
+
+func (recordingSpan) End() {}
+
+func (t *recordingTracer) Start(ctx context.Context, name string) computation.Span {
+	t.started = append(t.started, name)
+	return recordingSpan{}
+}
+
+func TestComputationMiddleware(t *testing.T) {
+	// Set gin mode to test mode:
+	gin.SetMode(gin.TestMode)
+
+	tracer := &recordingTracer{}
+
+	r := gin.New()
+
+	r.Use(ComputationMiddleware(tracer))
+
+	r.GET("/default", func(c *gin.Context) {
+		computation.Start(c.Request.Context(), "solar_rise_set").End()
+
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+
+	req, _ := http.NewRequest(http.MethodGet, "/default", nil)
+
+	r.ServeHTTP(w, req)
+
+	// Assert that the computation of the handler is started with the tracer of the middleware:
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []string{"solar_rise_set"}, tracer.started)
+}
@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CORSOptions struct {
+	// The origins that are allowed to make cross-origin requests, either exactly (e.g., "https://observerly.com"), as a
+	// wildcard subdomain pattern (e.g., "https://*.observerly.com"), or "*" for any origin:
+	AllowOrigins []string
+	// The methods that are allowed in cross-origin requests, as advertised in preflight responses:
+	AllowMethods []string
+	// The request headers that are allowed in cross-origin requests, as advertised in preflight responses:
+	AllowHeaders []string
+	// The response headers that cross-origin requests are allowed to read:
+	ExposeHeaders []string
+	// Whether cross-origin requests may include credentials (e.g., cookies):
+	AllowCredentials bool
+	// How long the results of a preflight request may be cached for by the client:
+	MaxAge time.Duration
+}
+
+// isOriginAllowed determines whether the origin matches any of the allowed origins or wildcard subdomain patterns:
+func isOriginAllowed(origin string, allowed []string) bool {
+	origin = strings.ToLower(origin)
+
+	for _, pattern := range allowed {
+		pattern = strings.ToLower(pattern)
+
+		if pattern == "*" || pattern == origin {
+			return true
+		}
+
+		prefix, suffix, found := strings.Cut(pattern, "*")
+
+		if !found || !strings.HasPrefix(suffix, ".") {
+			continue
+		}
+
+		if !strings.HasPrefix(origin, prefix) || !strings.HasSuffix(origin, suffix) {
+			continue
+		}
+
+		// The wildcard must match one or more subdomain labels, e.g., "app" or "eu.app", but not the apex domain:
+		subdomain := origin[len(prefix) : len(origin)-len(suffix)]
+
+		if subdomain != "" && !strings.HasPrefix(subdomain, ".") && !strings.ContainsAny(subdomain, "/:@?#*") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isAllOriginsAllowed determines whether any origin is allowed:
+func isAllOriginsAllowed(allowed []string) bool {
+	for _, pattern := range allowed {
+		if pattern == "*" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CORSMiddleware implements Cross-Origin Resource Sharing (per the Fetch specification), answering preflight requests
+// with a 204 (or a 403 for a disallowed origin), and adding the CORS headers to the responses of allowed origins.
+func CORSMiddleware(options CORSOptions) gin.HandlerFunc {
+	allowMethods := strings.Join(options.AllowMethods, ", ")
+
+	allowHeaders := strings.Join(options.AllowHeaders, ", ")
+
+	exposeHeaders := strings.Join(options.ExposeHeaders, ", ")
+
+	maxAge := strconv.Itoa(int(options.MaxAge.Seconds()))
+
+	// A wildcard origin cannot be used with credentials, and reflecting any origin with credentials would let every site
+	// make credentialed requests, so credentials are never allowed for a wildcard origin:
+	wildcard := isAllOriginsAllowed(options.AllowOrigins)
+
+	return func(c *gin.Context) {
+		origin := c.Request.Header.Get("Origin")
+
+		preflight := c.Request.Method == http.MethodOptions && c.Request.Header.Get("Access-Control-Request-Method") != ""
+
+		// The response depends on the origin, so caches must key on it (unless every origin gets the same response):
+		if !wildcard {
+			c.Writer.Header().Add("Vary", "Origin")
+		}
+
+		if preflight {
+			c.Writer.Header().Add("Vary", "Access-Control-Request-Method")
+			c.Writer.Header().Add("Vary", "Access-Control-Request-Headers")
+		}
+
+		// Same-origin (and non-browser) requests do not send an Origin, and so are not subject to CORS:
+		if origin == "" {
+			c.Next()
+			return
+		}
+
+		if !isOriginAllowed(origin, options.AllowOrigins) {
+			if preflight {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+
+			c.Next()
+			return
+		}
+
+		if wildcard {
+			c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+		} else {
+			c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
+		}
+
+		if options.AllowCredentials && !wildcard {
+			c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if preflight {
+			if allowMethods != "" {
+				c.Writer.Header().Set("Access-Control-Allow-Methods", allowMethods)
+			}
+
+			if allowHeaders != "" {
+				c.Writer.Header().Set("Access-Control-Allow-Headers", allowHeaders)
+			}
+
+			if options.MaxAge > 0 {
+				c.Writer.Header().Set("Access-Control-Max-Age", maxAge)
+			}
+
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		if exposeHeaders != "" {
+			c.Writer.Header().Set("Access-Control-Expose-Headers", exposeHeaders)
+		}
+
+		c.Next()
+	}
+}
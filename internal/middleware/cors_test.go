@@ -0,0 +1,145 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+var corsOptions = CORSOptions{
+	AllowOrigins:     []string{"https://observerly.com", "https://*.observerly.com", "http://localhost:3001"},
+	AllowMethods:     []string{"GET", "OPTIONS"},
+	AllowHeaders:     []string{"Origin", "Content-Type"},
+	ExposeHeaders:    []string{"ETag", "X-Cache"},
+	AllowCredentials: true,
+	MaxAge:           24 * time.Hour,
+}
+
+func setupCORSRouter(options CORSOptions) *gin.Engine {
+	// Set gin mode to test mode:
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+
+	r.Use(CORSMiddleware(options))
+
+	r.GET("/default", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{})
+	})
+
+	return r
+}
+
+func performCORSRequest(r http.Handler, method string, origin string, requestMethod string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, "/default", nil)
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+	if requestMethod != "" {
+		req.Header.Set("Access-Control-Request-Method", requestMethod)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestIsOriginAllowed(t *testing.T) {
+	allowed := []string{"https://observerly.com", "https://*.observerly.com"}
+
+	assert.True(t, isOriginAllowed("https://observerly.com", allowed))
+	assert.True(t, isOriginAllowed("https://app.observerly.com", allowed))
+	assert.True(t, isOriginAllowed("https://eu.app.observerly.com", allowed))
+	assert.True(t, isOriginAllowed("https://APP.observerly.com", allowed))
+
+	// Assert that the scheme must match:
+	assert.False(t, isOriginAllowed("http://app.observerly.com", allowed))
+	// Assert that the wildcard does not match other domains that share the suffix:
+	assert.False(t, isOriginAllowed("https://evilobserverly.com", allowed))
+	assert.False(t, isOriginAllowed("https://observerly.com.evil.com", allowed))
+	assert.False(t, isOriginAllowed("https://.observerly.com", allowed))
+	assert.False(t, isOriginAllowed("https://evil.com:443/.observerly.com", allowed))
+
+	assert.True(t, isOriginAllowed("https://anywhere.com", []string{"*"}))
+}
+
+func TestCORSMiddlewareAllowedOrigin(t *testing.T) {
+	r := setupCORSRouter(corsOptions)
+
+	w := performCORSRequest(r, http.MethodGet, "https://app.observerly.com", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "https://app.observerly.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Equal(t, "ETag, X-Cache", w.Header().Get("Access-Control-Expose-Headers"))
+	assert.Equal(t, "Origin", w.Header().Get("Vary"))
+
+	// Assert that the preflight only headers are not set on actual responses:
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Methods"))
+	assert.Empty(t, w.Header().Get("Access-Control-Max-Age"))
+}
+
+func TestCORSMiddlewareDisallowedOrigin(t *testing.T) {
+	r := setupCORSRouter(corsOptions)
+
+	w := performCORSRequest(r, http.MethodGet, "https://evil.com", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "Origin", w.Header().Get("Vary"))
+}
+
+func TestCORSMiddlewareNoOrigin(t *testing.T) {
+	r := setupCORSRouter(corsOptions)
+
+	w := performCORSRequest(r, http.MethodGet, "", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORSMiddlewarePreflight(t *testing.T) {
+	r := setupCORSRouter(corsOptions)
+
+	w := performCORSRequest(r, http.MethodOptions, "https://observerly.com", http.MethodGet)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "https://observerly.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "GET, OPTIONS", w.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "Origin, Content-Type", w.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "86400", w.Header().Get("Access-Control-Max-Age"))
+	assert.Equal(t, []string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"}, w.Header().Values("Vary"))
+	assert.Empty(t, w.Body.String())
+}
+
+func TestCORSMiddlewarePreflightDisallowedOrigin(t *testing.T) {
+	r := setupCORSRouter(corsOptions)
+
+	w := performCORSRequest(r, http.MethodOptions, "https://evil.com", http.MethodGet)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORSMiddlewareAllowAllOrigins(t *testing.T) {
+	r := setupCORSRouter(CORSOptions{AllowOrigins: []string{"*"}})
+
+	w := performCORSRequest(r, http.MethodGet, "https://anywhere.com", "")
+
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, w.Header().Get("Vary"))
+}
+
+func TestCORSMiddlewareAllowAllOriginsWithCredentials(t *testing.T) {
+	r := setupCORSRouter(CORSOptions{AllowOrigins: []string{"*"}, AllowCredentials: true})
+
+	w := performCORSRequest(r, http.MethodGet, "https://anywhere.com", "")
+
+	// Assert that the origin is not reflected, and that credentials are not allowed for a wildcard origin:
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Empty(t, w.Header().Get("Vary"))
+}
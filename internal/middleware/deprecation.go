@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/observerly/nocturnal/internal/envelope"
+	"github.com/observerly/nocturnal/internal/metrics"
+)
+
+type DeprecationOptions struct {
+	// The version of the API that is deprecated, e.g., "v1":
+	Version string
+	// When the version was deprecated, where zero marks it as deprecated without a date:
+	DeprecatedAt time.Time
+	// When the version will stop being served (if scheduled), as announced in the Sunset header:
+	Sunset time.Time
+	// When the version stops being served (if ever), after which every request is responded to with a 410 Gone:
+	Cutoff time.Time
+	// The path of the version of the API that succeeds the deprecated version, e.g., "/api/v2":
+	Successor string
+	// The successor of each route of the deprecated version (if any), by its path, e.g., "/api/v1/sun": "/api/v2/sun":
+	Successors map[string]string
+	// The URL of the migration guide (if any), linked to with rel="sunset":
+	Documentation string
+}
+
+// getDeprecationHeader returns the value of the Deprecation header, i.e., the date of deprecation as a structured field
+// date (e.g., "@1676332800") per RFC 9745, or "true" when the date is unknown:
+func getDeprecationHeader(deprecatedAt time.Time) string {
+	if deprecatedAt.IsZero() {
+		return "true"
+	}
+
+	return fmt.Sprintf("@%d", deprecatedAt.Unix())
+}
+
+// getLinkHeader returns the value of the Link header, to the successor and the migration guide (if any):
+func getLinkHeader(successor string, documentation string) string {
+	links := []string{}
+
+	if successor != "" {
+		links = append(links, fmt.Sprintf("<%s>; rel=\"successor-version\"", successor))
+	}
+
+	if documentation != "" {
+		links = append(links, fmt.Sprintf("<%s>; rel=\"sunset\"", documentation))
+	}
+
+	return strings.Join(links, ", ")
+}
+
+// DeprecationMiddleware marks every response of a deprecated version of the API as deprecated, with its Sunset date
+// (per RFC 8594) and a link to the route that succeeds it, and counts the requests to it. After the cutoff, every
+// request is responded to with a 410 Gone, with the successor to migrate to:
+func DeprecationMiddleware(options DeprecationOptions) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+
+		metrics.DeprecatedRequestsTotal.WithLabelValues(options.Version, route).Inc()
+
+		successor := options.Successor
+
+		if s, exists := options.Successors[route]; exists {
+			successor = s
+		}
+
+		c.Header("Deprecation", getDeprecationHeader(options.DeprecatedAt))
+
+		if !options.Sunset.IsZero() {
+			c.Header("Sunset", options.Sunset.UTC().Format(http.TimeFormat))
+		}
+
+		if link := getLinkHeader(successor, options.Documentation); link != "" {
+			c.Header("Link", link)
+		}
+
+		if options.Cutoff.IsZero() || time.Now().Before(options.Cutoff) {
+			c.Next()
+			return
+		}
+
+		body := envelope.NewError(c, fmt.Sprintf("this version of the API was retired on %s, use %s instead", options.Cutoff.UTC().Format(time.RFC3339), successor))
+
+		body["successor"] = successor
+
+		if options.Documentation != "" {
+			body["documentation"] = options.Documentation
+		}
+
+		c.AbortWithStatusJSON(http.StatusGone, body)
+	}
+}
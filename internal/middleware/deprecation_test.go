@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecationMiddleware(t *testing.T) {
+	// Set gin mode to test mode:
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+
+	v1 := r.Group("/api/v1", DeprecationMiddleware(DeprecationOptions{Successor: "/api/v2"}))
+
+	v1.GET("/sun", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{})
+	})
+
+	r.GET("/api/v2/sun", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{})
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/sun", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	// Assert that the routes of the group are marked as deprecated, with a link to the successor:
+	assert.Equal(t, "true", w.Header().Get("Deprecation"))
+	assert.Equal(t, `</api/v2>; rel="successor-version"`, w.Header().Get("Link"))
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v2/sun", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	// Assert that the routes outside the group are not:
+	assert.Empty(t, w.Header().Get("Deprecation"))
+	assert.Empty(t, w.Header().Get("Link"))
+}
+
+func TestDeprecationMiddlewareCutoff(t *testing.T) {
+	// Set gin mode to test mode:
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+
+	v1 := r.Group("/api/v1", DeprecationMiddleware(DeprecationOptions{
+		Version:      "v1",
+		DeprecatedAt: time.Date(2023, time.February, 14, 0, 0, 0, 0, time.UTC),
+		Sunset:       time.Now().Add(-time.Hour),
+		Cutoff:       time.Now().Add(-time.Hour),
+		Successor:    "/api/v2",
+		Successors:   map[string]string{"/api/v1/sun": "/api/v2/sun"},
+	}))
+
+	v1.GET("/sun", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{})
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/sun", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	// Assert that the route is gone after the cutoff, and is linked to its own successor:
+	assert.Equal(t, http.StatusGone, w.Code)
+	assert.Equal(t, "@1676332800", w.Header().Get("Deprecation"))
+	assert.NotEmpty(t, w.Header().Get("Sunset"))
+	assert.Equal(t, `</api/v2/sun>; rel="successor-version"`, w.Header().Get("Link"))
+	assert.Contains(t, w.Body.String(), `"successor":"/api/v2/sun"`)
+}
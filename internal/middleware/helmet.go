@@ -1,20 +1,234 @@
 package middleware
 
 import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/gin-gonic/gin"
+
+	"github.com/observerly/nocturnal/internal/config"
 )
 
-func HelmetMiddleware() gin.HandlerFunc {
+// CSPDirectives builds a Content-Security-Policy from its directives and their sources, e.g.,
+// {"default-src": {"'self'"}, "img-src": {"'self'", "data:"}}, where a directive without sources (e.g.,
+// "upgrade-insecure-requests") is included on its own:
+type CSPDirectives map[string][]string
+
+// String returns the policy, with the directives in alphabetical order, e.g., "default-src 'self'; img-src 'self' data:":
+func (d CSPDirectives) String() string {
+	names := make([]string, 0, len(d))
+
+	for name := range d {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	directives := make([]string, 0, len(names))
+
+	for _, name := range names {
+		directives = append(directives, strings.TrimSpace(name+" "+strings.Join(d[name], " ")))
+	}
+
+	return strings.Join(directives, "; ")
+}
+
+// PermissionsPolicy builds a Permissions-Policy from each feature and its allowed origins, e.g.,
+// {"geolocation": {}, "fullscreen": {"self"}}, where a feature without origins is disabled entirely:
+type PermissionsPolicy map[string][]string
+
+// String returns the policy, with the features in alphabetical order, e.g., "fullscreen=(self), geolocation=()":
+func (p PermissionsPolicy) String() string {
+	features := make([]string, 0, len(p))
+
+	for feature := range p {
+		features = append(features, feature)
+	}
+
+	sort.Strings(features)
+
+	policies := make([]string, 0, len(features))
+
+	for _, feature := range features {
+		policies = append(policies, fmt.Sprintf("%s=(%s)", feature, strings.Join(p[feature], " ")))
+	}
+
+	return strings.Join(policies, ", ")
+}
+
+type HSTSOptions struct {
+	// How long browsers should only connect over HTTPS, where 0 omits the Strict-Transport-Security header:
+	MaxAge time.Duration
+	// Whether the policy also applies to every subdomain:
+	IncludeSubDomains bool
+	// Whether to consent to inclusion in the browsers' HSTS preload lists:
+	Preload bool
+}
+
+// String returns the Strict-Transport-Security header value, e.g., "max-age=5184000; includeSubDomains":
+func (h HSTSOptions) String() string {
+	value := fmt.Sprintf("max-age=%d", int64(h.MaxAge.Seconds()))
+
+	if h.IncludeSubDomains {
+		value += "; includeSubDomains"
+	}
+
+	if h.Preload {
+		value += "; preload"
+	}
+
+	return value
+}
+
+type HelmetRouteOptions struct {
+	// The Content-Security-Policy for the route, replacing the default policy when set (e.g., for an HTML docs page):
+	ContentSecurityPolicy CSPDirectives
+	// The headers to override for the route, where an empty value removes the header:
+	Headers map[string]string
+}
+
+type HelmetOptions struct {
+	// The directives of the Content-Security-Policy, where none omits the header:
+	ContentSecurityPolicy CSPDirectives
+	// The Strict-Transport-Security policy:
+	HSTS HSTSOptions
+	// The features of the Permissions-Policy, where none omits the header:
+	PermissionsPolicy PermissionsPolicy
+	// The headers to override (or add), where an empty value removes the header:
+	Headers map[string]string
+	// The overrides for the routes beginning with each path prefix, e.g., "/docs", where the longest prefix applies:
+	Routes map[string]HelmetRouteOptions
+}
+
+// NewHelmetOptions converts the configured security headers policy to the options of the HelmetMiddleware:
+func NewHelmetOptions(h config.HelmetConfig) HelmetOptions {
+	routes := map[string]HelmetRouteOptions{}
+
+	for prefix, route := range h.Routes {
+		routes[prefix] = HelmetRouteOptions{
+			ContentSecurityPolicy: route.ContentSecurityPolicy,
+			Headers:               route.Headers,
+		}
+	}
+
+	return HelmetOptions{
+		ContentSecurityPolicy: h.ContentSecurityPolicy,
+		HSTS: HSTSOptions{
+			MaxAge:            h.HSTS.MaxAge,
+			IncludeSubDomains: h.HSTS.IncludeSubDomains,
+			Preload:           h.HSTS.Preload,
+		},
+		PermissionsPolicy: h.PermissionsPolicy,
+		Headers:           h.Headers,
+		Routes:            routes,
+	}
+}
+
+// getHelmetHeaders returns the security headers for the options, with the overrides applied and any removed headers
+// omitted:
+func getHelmetHeaders(options HelmetOptions, route *HelmetRouteOptions) map[string]string {
+	headers := map[string]string{
+		"Cross-Origin-Opener-Policy": "same-origin",
+		"Referrer-Policy":            "strict-origin-when-cross-origin",
+		"X-Content-Type-Options":     "nosniff",
+		"X-Download-Options":         "noopen",
+		"X-DNS-Prefetch-Control":     "off",
+		"X-Frame-Options":            "Deny",
+		// N.B. the XSS auditor that X-XSS-Protection enabled is deprecated, and could itself introduce vulnerabilities:
+		"X-XSS-Protection": "0",
+	}
+
+	csp := options.ContentSecurityPolicy
+
+	if route != nil && route.ContentSecurityPolicy != nil {
+		csp = route.ContentSecurityPolicy
+	}
+
+	if len(csp) > 0 {
+		headers["Content-Security-Policy"] = csp.String()
+	}
+
+	if options.HSTS.MaxAge > 0 {
+		headers["Strict-Transport-Security"] = options.HSTS.String()
+	}
+
+	if len(options.PermissionsPolicy) > 0 {
+		headers["Permissions-Policy"] = options.PermissionsPolicy.String()
+	}
+
+	overrides := []map[string]string{headers, options.Headers}
+
+	if route != nil {
+		overrides = append(overrides, route.Headers)
+	}
+
+	// N.B. header names are case-insensitive, so are canonicalised as each set of overrides is applied in turn:
+	resolved := map[string]string{}
+
+	for _, override := range overrides {
+		for name, value := range override {
+			resolved[http.CanonicalHeaderKey(name)] = value
+		}
+	}
+
+	for name, value := range resolved {
+		if value == "" {
+			delete(resolved, name)
+		}
+	}
+
+	return resolved
+}
+
+// hasPathPrefix determines whether the path is within the prefix, e.g., "/docs/v2" is within "/docs" but "/docsify" is not:
+func hasPathPrefix(path string, prefix string) bool {
+	if !strings.HasPrefix(path, prefix) {
+		return false
+	}
+
+	return len(path) == len(prefix) || strings.HasSuffix(prefix, "/") || path[len(prefix)] == '/'
+}
+
+// HelmetMiddleware sets the security headers of the policy on every response, with any per-route overrides applied to
+// the routes beginning with their path prefix:
+func HelmetMiddleware(options HelmetOptions) gin.HandlerFunc {
+	// Resolve the headers of the default policy, and of each route, up front:
+	headers := getHelmetHeaders(options, nil)
+
+	prefixes := make([]string, 0, len(options.Routes))
+
+	routes := make(map[string]map[string]string, len(options.Routes))
+
+	for prefix, route := range options.Routes {
+		route := route
+
+		prefixes = append(prefixes, prefix)
+
+		routes[prefix] = getHelmetHeaders(options, &route)
+	}
+
+	// Match the longest (i.e., most specific) prefix first:
+	sort.Slice(prefixes, func(i, j int) bool {
+		return len(prefixes[i]) > len(prefixes[j])
+	})
+
 	return func(c *gin.Context) {
-		c.Writer.Header().Set("Content-Security-Policy", "default-src 'self'")
-		c.Writer.Header().Set("Cross-Origin-Opener-Policy", "same-origin")
-		c.Writer.Header().Set("Referrer-Policy", "strict-origin-when-cross-origin")
-		c.Writer.Header().Set("Strict-Transport-Security", "max-age=5184000; includeSubDomains")
-		c.Writer.Header().Set("X-Content-Type-Options", "nosniff")
-		c.Writer.Header().Set("X-Download-Options", "noopen")
-		c.Writer.Header().Set("X-DNS-Prefetch-Control", "off")
-		c.Writer.Header().Set("X-Frame-Options", "Deny")
-		c.Writer.Header().Set("X-XSS-Protection", "1; mode=block")
+		h := headers
+
+		for _, prefix := range prefixes {
+			if hasPathPrefix(c.Request.URL.Path, prefix) {
+				h = routes[prefix]
+				break
+			}
+		}
+
+		for name, value := range h {
+			c.Writer.Header().Set(name, value)
+		}
+
 		c.Next()
 	}
 }
@@ -4,10 +4,32 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/observerly/nocturnal/internal/config"
 )
 
+func performHelmetRequest(options HelmetOptions, path string) *httptest.ResponseRecorder {
+	// Set gin mode to test mode:
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+
+	r.Use(HelmetMiddleware(options))
+
+	r.GET("/*path", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, path, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
 func TestGetDefaultObserverQueryUndefined(t *testing.T) {
 	// Set gin mode to test mode:
 	gin.SetMode(gin.TestMode)
@@ -16,7 +38,7 @@ func TestGetDefaultObserverQueryUndefined(t *testing.T) {
 	r := gin.Default()
 
 	// Setup Helmet Security Headers:
-	r.Use(HelmetMiddleware())
+	r.Use(HelmetMiddleware(NewHelmetOptions(config.Default().Helmet)))
 
 	// Setup the route:
 	r.GET("/default", func(c *gin.Context) {
@@ -76,7 +98,118 @@ func TestGetDefaultObserverQueryUndefined(t *testing.T) {
 		t.Fatalf("Expected to get X-Frame-Options header %s but instead got %s\n", "Deny", w.Header().Get("X-Frame-Options"))
 	}
 
-	if w.Header().Get("X-XSS-Protection") != "1; mode=block" {
-		t.Fatalf("Expected to get X-XSS-Protection header %s but instead got %s\n", "1; mode=block", w.Header().Get("X-XSS-Protection"))
+	if w.Header().Get("X-XSS-Protection") != "0" {
+		t.Fatalf("Expected to get X-XSS-Protection header %s but instead got %s\n", "0", w.Header().Get("X-XSS-Protection"))
+	}
+
+	if w.Header().Get("Permissions-Policy") != "camera=(), geolocation=(), microphone=()" {
+		t.Fatalf("Expected to get Permissions-Policy header %s but instead got %s\n", "camera=(), geolocation=(), microphone=()", w.Header().Get("Permissions-Policy"))
+	}
+}
+
+func TestCSPDirectives(t *testing.T) {
+	csp := CSPDirectives{
+		"img-src":                   {"'self'", "data:"},
+		"default-src":               {"'self'"},
+		"upgrade-insecure-requests": {},
+	}
+
+	// Assert that the directives are built in alphabetical order, with valueless directives on their own:
+	assert.Equal(t, "default-src 'self'; img-src 'self' data:; upgrade-insecure-requests", csp.String())
+	assert.Equal(t, "", CSPDirectives{}.String())
+}
+
+func TestPermissionsPolicy(t *testing.T) {
+	policy := PermissionsPolicy{
+		"geolocation": {},
+		"fullscreen":  {"self", `"https://observerly.com"`},
+	}
+
+	assert.Equal(t, `fullscreen=(self "https://observerly.com"), geolocation=()`, policy.String())
+}
+
+func TestHSTSOptions(t *testing.T) {
+	assert.Equal(t, "max-age=5184000", HSTSOptions{MaxAge: 60 * 24 * time.Hour}.String())
+	assert.Equal(t, "max-age=5184000; includeSubDomains", HSTSOptions{MaxAge: 60 * 24 * time.Hour, IncludeSubDomains: true}.String())
+	assert.Equal(t, "max-age=31536000; includeSubDomains; preload", HSTSOptions{MaxAge: 365 * 24 * time.Hour, IncludeSubDomains: true, Preload: true}.String())
+}
+
+func TestHelmetMiddlewareHSTSPreload(t *testing.T) {
+	options := NewHelmetOptions(config.Default().Helmet)
+
+	options.HSTS = HSTSOptions{MaxAge: 365 * 24 * time.Hour, IncludeSubDomains: true, Preload: true}
+
+	w := performHelmetRequest(options, "/api/v2/sun")
+
+	assert.Equal(t, "max-age=31536000; includeSubDomains; preload", w.Header().Get("Strict-Transport-Security"))
+}
+
+func TestHelmetMiddlewareOmittedPolicies(t *testing.T) {
+	w := performHelmetRequest(HelmetOptions{}, "/api/v2/sun")
+
+	// Assert that the policies without any directives, features or max-age are omitted:
+	assert.NotContains(t, w.Header(), "Content-Security-Policy")
+	assert.NotContains(t, w.Header(), "Permissions-Policy")
+	assert.NotContains(t, w.Header(), "Strict-Transport-Security")
+
+	// Assert that the remaining headers are still set:
+	assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+}
+
+func TestHelmetMiddlewareHeaderOverrides(t *testing.T) {
+	options := NewHelmetOptions(config.Default().Helmet)
+
+	options.Headers = map[string]string{
+		"x-frame-options":              "SAMEORIGIN",
+		"X-Download-Options":           "",
+		"Cross-Origin-Resource-Policy": "cross-origin",
+	}
+
+	w := performHelmetRequest(options, "/api/v2/sun")
+
+	// Assert that headers are overridden (case-insensitively), removed and added:
+	assert.Equal(t, []string{"SAMEORIGIN"}, w.Header().Values("X-Frame-Options"))
+	assert.NotContains(t, w.Header(), "X-Download-Options")
+	assert.Equal(t, "cross-origin", w.Header().Get("Cross-Origin-Resource-Policy"))
+}
+
+func TestHelmetMiddlewareRouteOverrides(t *testing.T) {
+	options := NewHelmetOptions(config.Default().Helmet)
+
+	options.Headers = map[string]string{"X-Frame-Options": "SAMEORIGIN"}
+
+	options.Routes = map[string]HelmetRouteOptions{
+		"/docs": {
+			ContentSecurityPolicy: CSPDirectives{
+				"default-src": {"'self'"},
+				"script-src":  {"'self'", "https://cdn.jsdelivr.net"},
+				"style-src":   {"'self'", "'unsafe-inline'", "https://cdn.jsdelivr.net"},
+			},
+		},
+		"/docs/embed": {
+			Headers: map[string]string{"X-Frame-Options": ""},
+		},
 	}
+
+	w := performHelmetRequest(options, "/docs/index.html")
+
+	// Assert that the route's policy replaces the default policy, but keeps the global overrides:
+	assert.Equal(t, "default-src 'self'; script-src 'self' https://cdn.jsdelivr.net; style-src 'self' 'unsafe-inline' https://cdn.jsdelivr.net", w.Header().Get("Content-Security-Policy"))
+	assert.Equal(t, "SAMEORIGIN", w.Header().Get("X-Frame-Options"))
+
+	w = performHelmetRequest(options, "/docs/embed/sun")
+
+	// Assert that the longest matching prefix applies, keeping the default policy:
+	assert.Equal(t, "default-src 'self'", w.Header().Get("Content-Security-Policy"))
+	assert.NotContains(t, w.Header(), "X-Frame-Options")
+
+	w = performHelmetRequest(options, "/docsify")
+
+	// Assert that a prefix only matches whole path segments:
+	assert.Equal(t, "default-src 'self'", w.Header().Get("Content-Security-Policy"))
+
+	w = performHelmetRequest(options, "/api/v2/sun")
+
+	assert.Equal(t, "default-src 'self'", w.Header().Get("Content-Security-Policy"))
+	assert.Equal(t, "SAMEORIGIN", w.Header().Get("X-Frame-Options"))
 }
@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+
+	"github.com/observerly/nocturnal/internal/envelope"
+)
+
+// getQueryParams flattens the query parameters of the request, e.g., {"latitude": "19.798484"}:
+func getQueryParams(r *http.Request) map[string]string {
+	params := map[string]string{}
+
+	for key, values := range r.URL.Query() {
+		params[key] = strings.Join(values, ",")
+	}
+
+	return params
+}
+
+// getLevel returns the level to log a response with the status at, i.e., server errors as errors:
+func getLevel(status int) slog.Level {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return slog.LevelError
+	case status >= http.StatusBadRequest:
+		return slog.LevelWarn
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// LoggerMiddleware writes a single structured log record for every request, with the request ID, route, query
+// parameters, latency and status, along with the Cloud Logging httpRequest fields:
+// @see https://cloud.google.com/logging/docs/reference/v2/rest/v2/LogEntry#HttpRequest
+func LoggerMiddleware(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		latency := time.Since(start)
+
+		status := c.Writer.Status()
+
+		// The size is -1 if no body was written, e.g., for a 304:
+		size := c.Writer.Size()
+
+		if size < 0 {
+			size = 0
+		}
+
+		attrs := []slog.Attr{
+			slog.String("requestId", envelope.GetRequestID(c)),
+			slog.String("route", c.FullPath()),
+			slog.Any("query", getQueryParams(c.Request)),
+			slog.Int("status", status),
+			slog.Float64("latency", latency.Seconds()),
+			slog.Group("httpRequest",
+				slog.String("requestMethod", c.Request.Method),
+				slog.String("requestUrl", c.Request.URL.String()),
+				slog.Int("status", status),
+				slog.Int("responseSize", size),
+				slog.String("userAgent", c.Request.UserAgent()),
+				slog.String("remoteIp", c.ClientIP()),
+				slog.String("latency", fmt.Sprintf("%.9fs", latency.Seconds())),
+			),
+		}
+
+		// Correlate the log record with the request's trace, if it is being traced:
+		if span := trace.SpanContextFromContext(c.Request.Context()); span.IsValid() {
+			attrs = append(attrs, slog.String("traceId", span.TraceID().String()), slog.String("spanId", span.SpanID().String()))
+		}
+
+		if errs := c.Errors.ByType(gin.ErrorTypeAny).String(); errs != "" {
+			attrs = append(attrs, slog.String("errors", errs))
+		}
+
+		logger.LogAttrs(c.Request.Context(), getLevel(status), fmt.Sprintf("%s %s", c.Request.Method, c.Request.URL.Path), attrs...)
+	}
+}
@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+
+	"github.com/observerly/nocturnal/internal/logger"
+)
+
+func TestLoggerMiddleware(t *testing.T) {
+	// Set gin mode to test mode:
+	gin.SetMode(gin.TestMode)
+
+	var b bytes.Buffer
+
+	r := gin.New()
+
+	r.Use(RequestIDMiddleware())
+
+	r.Use(LoggerMiddleware(logger.New(&b, slog.LevelInfo)))
+
+	r.GET("/moon/:id", func(c *gin.Context) {
+		c.JSON(http.StatusBadRequest, gin.H{})
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/moon/1?latitude=19.798484&longitude=-155.468094", nil)
+	req.Header.Set(REQUEST_ID_HEADER, "abc-123")
+
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	var record map[string]interface{}
+
+	err := json.Unmarshal(b.Bytes(), &record)
+
+	// Assert that a single structured record is written for the request:
+	assert.Nil(t, err)
+	assert.Equal(t, "WARNING", record["severity"])
+	assert.Equal(t, "GET /moon/1", record["message"])
+	assert.Equal(t, "abc-123", record["requestId"])
+	assert.Equal(t, "/moon/:id", record["route"])
+	assert.Equal(t, map[string]interface{}{"latitude": "19.798484", "longitude": "-155.468094"}, record["query"])
+	assert.Equal(t, float64(400), record["status"])
+	assert.GreaterOrEqual(t, record["latency"], float64(0))
+
+	httpRequest := record["httpRequest"].(map[string]interface{})
+
+	assert.Equal(t, "GET", httpRequest["requestMethod"])
+	assert.Equal(t, "/moon/1?latitude=19.798484&longitude=-155.468094", httpRequest["requestUrl"])
+	assert.Equal(t, float64(400), httpRequest["status"])
+	assert.Regexp(t, `^\d+\.\d{9}s$`, httpRequest["latency"])
+}
+
+func TestLoggerMiddlewareTraced(t *testing.T) {
+	// Set gin mode to test mode:
+	gin.SetMode(gin.TestMode)
+
+	var b bytes.Buffer
+
+	r := gin.New()
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+
+	// Stand in for the tracing middleware, by setting the span of the request:
+	r.Use(func(c *gin.Context) {
+		span := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID, TraceFlags: trace.FlagsSampled})
+
+		c.Request = c.Request.WithContext(trace.ContextWithSpanContext(c.Request.Context(), span))
+	})
+
+	r.Use(LoggerMiddleware(logger.New(&b, slog.LevelInfo)))
+
+	r.GET("/sun", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{})
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/sun", nil)
+
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	var record map[string]interface{}
+
+	err := json.Unmarshal(b.Bytes(), &record)
+
+	// Assert that the record is correlated with the trace:
+	assert.Nil(t, err)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", record["traceId"])
+	assert.Equal(t, "00f067aa0ba902b7", record["spanId"])
+}
+
+func TestGetLevel(t *testing.T) {
+	assert.Equal(t, slog.LevelInfo, getLevel(http.StatusOK))
+	assert.Equal(t, slog.LevelWarn, getLevel(http.StatusNotFound))
+	assert.Equal(t, slog.LevelError, getLevel(http.StatusInternalServerError))
+}
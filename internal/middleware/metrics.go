@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/observerly/nocturnal/internal/metrics"
+)
+
+// MetricsMiddleware records the count and latency of every request, by method, route (the registered route pattern,
+// rather than the raw path, to keep the cardinality bounded) and status code.
+func MetricsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		route := c.FullPath()
+
+		// Requests that do not match any registered route are grouped together:
+		if route == "" {
+			route = "unmatched"
+		}
+
+		metrics.ObserveRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
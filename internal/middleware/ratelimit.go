@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/observerly/nocturnal/internal/envelope"
+	"github.com/observerly/nocturnal/internal/ratelimit"
+)
+
+type RateLimitOptions struct {
+	// The limit for anonymous clients, which are identified by their IP address:
+	Limit ratelimit.Limit
+	// The request header that clients present their API key in, e.g., "X-API-Key":
+	APIKeyHeader string
+	// The limit for each API key (i.e., the limit of the tier that the key belongs to):
+	APIKeys map[string]ratelimit.Limit
+}
+
+// getClientKey returns the bucket key and limit for the client, or false if the client presented an unknown API key:
+func getClientKey(c *gin.Context, options RateLimitOptions) (string, ratelimit.Limit, bool) {
+	apiKey := c.GetHeader(options.APIKeyHeader)
+
+	if apiKey == "" {
+		// N.B. the client IP only honours the X-Forwarded-For (and X-Real-IP) headers of trusted proxies:
+		return "ip:" + c.ClientIP(), options.Limit, true
+	}
+
+	limit, exists := options.APIKeys[apiKey]
+
+	if !exists {
+		return "", ratelimit.Limit{}, false
+	}
+
+	// The API key is hashed, so that it is never held (e.g., in a shared store) in plain text:
+	sum := sha256.Sum256([]byte(apiKey))
+
+	return "key:" + hex.EncodeToString(sum[:]), limit, true
+}
+
+func setRateLimitHeaders(c *gin.Context, result ratelimit.Result) {
+	c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+	c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	c.Header("RateLimit-Reset", strconv.Itoa(int(result.Reset.Seconds())))
+}
+
+// RateLimitMiddleware limits the rate of requests from each client with a token bucket, where clients are identified
+// by their API key (and limited by its tier), or otherwise by their IP address. Requests over the limit are rejected
+// with a 429, and requests with an unknown API key with a 401.
+func RateLimitMiddleware(store ratelimit.Store, options RateLimitOptions) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key, limit, ok := getClientKey(c, options)
+
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, envelope.NewError(c, "invalid API key"))
+			return
+		}
+
+		if limit.IsUnlimited() {
+			c.Next()
+			return
+		}
+
+		result, err := store.Take(c.Request.Context(), key, limit)
+
+		// Fail open if the store is unavailable, rather than rejecting every request:
+		if err != nil {
+			_ = c.Error(err)
+			c.Next()
+			return
+		}
+
+		setRateLimitHeaders(c, result)
+
+		if !result.Allowed {
+			c.Header("Retry-After", strconv.Itoa(int(result.RetryAfter.Seconds())))
+
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, envelope.NewError(c, fmt.Sprintf("rate limit exceeded, retry after %d seconds", int(result.RetryAfter.Seconds()))))
+			return
+		}
+
+		c.Next()
+	}
+}
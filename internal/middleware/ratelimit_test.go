@@ -0,0 +1,154 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/observerly/nocturnal/internal/ratelimit"
+)
+
+var rateLimitOptions = RateLimitOptions{
+	Limit:        ratelimit.Limit{RequestsPerMinute: 60, Burst: 2},
+	APIKeyHeader: "X-API-Key",
+	APIKeys: map[string]ratelimit.Limit{
+		"partner":   {RequestsPerMinute: 600, Burst: 5},
+		"unlimited": {},
+	},
+}
+
+// failingStore is a Store that is always unavailable:
+type failingStore struct{}
+
+func (failingStore) Take(_ context.Context, _ string, _ ratelimit.Limit) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("store unavailable")
+}
+
+func setupRateLimitRouter(store ratelimit.Store) *gin.Engine {
+	// Set gin mode to test mode:
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+
+	_ = r.SetTrustedProxies([]string{"10.0.0.1"})
+
+	r.Use(RequestIDMiddleware())
+
+	r.Use(RateLimitMiddleware(store, rateLimitOptions))
+
+	r.GET("/default", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{})
+	})
+
+	return r
+}
+
+func performRateLimitRequest(r http.Handler, remoteAddr string, header http.Header) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodGet, "/default", nil)
+	req.RemoteAddr = remoteAddr
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimitMiddlewareByIP(t *testing.T) {
+	r := setupRateLimitRouter(ratelimit.NewMemory())
+
+	w := performRateLimitRequest(r, "192.0.2.1:1234", nil)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "1", w.Header().Get("RateLimit-Reset"))
+
+	w = performRateLimitRequest(r, "192.0.2.1:1234", nil)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+
+	w = performRateLimitRequest(r, "192.0.2.1:1234", nil)
+
+	// Assert that the burst is exhausted:
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+	assert.JSONEq(t, `{"error":"rate limit exceeded, retry after 1 seconds","requestId":"`+w.Header().Get("X-Request-ID")+`"}`, w.Body.String())
+
+	// Assert that another client is not limited:
+	w = performRateLimitRequest(r, "192.0.2.2:1234", nil)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestRateLimitMiddlewareTrustedProxy(t *testing.T) {
+	r := setupRateLimitRouter(ratelimit.NewMemory())
+
+	// Requests forwarded by a trusted proxy are limited by the forwarded client IP:
+	for i := 0; i < 2; i++ {
+		w := performRateLimitRequest(r, "10.0.0.1:1234", http.Header{"X-Forwarded-For": []string{"192.0.2.1"}})
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+
+	w := performRateLimitRequest(r, "10.0.0.1:1234", http.Header{"X-Forwarded-For": []string{"192.0.2.2"}})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// The X-Forwarded-For header of an untrusted client is ignored, so it cannot evade the limit:
+	for i, forwarded := range []string{"192.0.2.100", "192.0.2.101", "192.0.2.102"} {
+		w = performRateLimitRequest(r, "192.0.2.3:1234", http.Header{"X-Forwarded-For": []string{forwarded}})
+
+		if i < 2 {
+			assert.Equal(t, http.StatusOK, w.Code)
+		} else {
+			assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		}
+	}
+}
+
+func TestRateLimitMiddlewareByAPIKey(t *testing.T) {
+	r := setupRateLimitRouter(ratelimit.NewMemory())
+
+	w := performRateLimitRequest(r, "192.0.2.1:1234", http.Header{"X-Api-Key": []string{"partner"}})
+
+	// Assert that the client is limited by the tier of its API key:
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "5", w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "4", w.Header().Get("RateLimit-Remaining"))
+}
+
+func TestRateLimitMiddlewareUnlimitedAPIKey(t *testing.T) {
+	r := setupRateLimitRouter(ratelimit.NewMemory())
+
+	for i := 0; i < 5; i++ {
+		w := performRateLimitRequest(r, "192.0.2.1:1234", http.Header{"X-Api-Key": []string{"unlimited"}})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+	}
+}
+
+func TestRateLimitMiddlewareInvalidAPIKey(t *testing.T) {
+	r := setupRateLimitRouter(ratelimit.NewMemory())
+
+	w := performRateLimitRequest(r, "192.0.2.1:1234", http.Header{"X-Api-Key": []string{"unknown"}, "X-Request-Id": []string{"abc-123"}})
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.JSONEq(t, `{"error":"invalid API key","requestId":"abc-123"}`, w.Body.String())
+}
+
+func TestRateLimitMiddlewareStoreUnavailable(t *testing.T) {
+	r := setupRateLimitRouter(failingStore{})
+
+	w := performRateLimitRequest(r, "192.0.2.1:1234", nil)
+
+	// Assert that requests are allowed if the store is unavailable:
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,124 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"runtime/debug"
+	"strings"
+
+	"github.com/getsentry/sentry-go"
+	sentrygin "github.com/getsentry/sentry-go/gin"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/exp/slog"
+
+	"github.com/observerly/nocturnal/internal/envelope"
+)
+
+// The message of the error envelope for a panic, N.B. the panic value itself is never returned to the client, as it
+// may expose internal details, but can be found in the logs (and Sentry) by the request ID:
+const INTERNAL_SERVER_ERROR_MESSAGE string = envelope.INTERNAL_SERVER_ERROR_MESSAGE
+
+// getPanicError converts any panic value (e.g., a string, an error or any other value) to an error:
+func getPanicError(recovered interface{}) error {
+	switch v := recovered.(type) {
+	case error:
+		return v
+	case string:
+		return errors.New(v)
+	default:
+		return fmt.Errorf("%v", v)
+	}
+}
+
+// isBrokenPipe determines whether the panic is from writing to a connection the client has already closed:
+func isBrokenPipe(err error) bool {
+	var syscallErr *os.SyscallError
+
+	if !errors.As(err, &syscallErr) {
+		return false
+	}
+
+	var opErr *net.OpError
+
+	if !errors.As(err, &opErr) {
+		return false
+	}
+
+	message := strings.ToLower(syscallErr.Error())
+
+	return strings.Contains(message, "broken pipe") || strings.Contains(message, "connection reset by peer")
+}
+
+// reportPanic reports the panic to Sentry with the request context, unless the sentrygin middleware (which reports every
+// panic that passes through it) has already done so:
+func reportPanic(c *gin.Context, recovered interface{}) {
+	if sentrygin.GetHubFromContext(c) != nil {
+		return
+	}
+
+	hub := sentry.GetHubFromContext(c.Request.Context())
+
+	if hub == nil {
+		hub = sentry.CurrentHub().Clone()
+	}
+
+	hub.ConfigureScope(func(scope *sentry.Scope) {
+		scope.SetRequest(c.Request)
+		scope.SetTag("request_id", envelope.GetRequestID(c))
+	})
+
+	hub.RecoverWithContext(context.WithValue(c.Request.Context(), sentry.RequestContextKey, c.Request), recovered)
+}
+
+// RecoveryMiddleware recovers from a panic of any value in the rest of the chain, logs it (with the stack trace) and
+// reports it to Sentry, and responds with a 500 and the standard error envelope, so that a client never receives an
+// empty response.
+func RecoveryMiddleware(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			recovered := recover()
+
+			if recovered == nil {
+				return
+			}
+
+			// N.B. http.ErrAbortHandler is used to deliberately abort a response, and so is re-panicked for net/http:
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+
+			err := getPanicError(recovered)
+
+			// If the client has gone away, there is no one to respond to, or anything to report:
+			if isBrokenPipe(err) {
+				_ = c.Error(err)
+				c.Abort()
+				return
+			}
+
+			logger.LogAttrs(c.Request.Context(), slog.LevelError, "Recovered from panic",
+				slog.String("requestId", envelope.GetRequestID(c)),
+				slog.String("error", err.Error()),
+				slog.String("stack", string(debug.Stack())),
+			)
+
+			reportPanic(c, recovered)
+
+			_ = c.Error(err)
+
+			// If the handler had already started writing its response, the status can no longer be changed:
+			if c.Writer.Written() {
+				c.Abort()
+				return
+			}
+
+			c.AbortWithStatusJSON(http.StatusInternalServerError, envelope.NewError(c, INTERNAL_SERVER_ERROR_MESSAGE))
+		}()
+
+		c.Next()
+	}
+}
@@ -0,0 +1,218 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	sentrygin "github.com/getsentry/sentry-go/gin"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/exp/slog"
+
+	"github.com/observerly/nocturnal/internal/logger"
+)
+
+// transport captures the events sent to Sentry, in place of sending them:
+type transport struct {
+	mu     sync.Mutex
+	events []*sentry.Event
+}
+
+func (t *transport) Flush(_ time.Duration) bool {
+	return true
+}
+
+func (t *transport) Configure(_ sentry.ClientOptions) {}
+
+func (t *transport) SendEvent(event *sentry.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.events = append(t.events, event)
+}
+
+func (t *transport) Events() []*sentry.Event {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.events
+}
+
+type panicValue struct {
+	Code int
+}
+
+func setupRecoveryRouter(b *bytes.Buffer, t *transport, withSentryGin bool) *gin.Engine {
+	// Set gin mode to test mode:
+	gin.SetMode(gin.TestMode)
+
+	client, _ := sentry.NewClient(sentry.ClientOptions{
+		Dsn:       "https://public@sentry.example.com/1",
+		Transport: t,
+	})
+
+	r := gin.New()
+
+	// Bind the Sentry hub (with the capturing transport) to every request:
+	r.Use(func(c *gin.Context) {
+		hub := sentry.NewHub(client, sentry.NewScope())
+
+		c.Request = c.Request.WithContext(sentry.SetHubOnContext(c.Request.Context(), hub))
+	})
+
+	r.Use(RequestIDMiddleware())
+
+	r.Use(RecoveryMiddleware(logger.New(b, slog.LevelInfo)))
+
+	if withSentryGin {
+		r.Use(sentrygin.New(sentrygin.Options{Repanic: true}))
+	}
+
+	r.GET("/string", func(c *gin.Context) {
+		panic("something went wrong")
+	})
+
+	r.GET("/error", func(c *gin.Context) {
+		panic(errors.New("dusk: no solution"))
+	})
+
+	r.GET("/value", func(c *gin.Context) {
+		panic(panicValue{Code: 42})
+	})
+
+	r.GET("/written", func(c *gin.Context) {
+		c.String(http.StatusOK, "partial")
+		panic("after writing")
+	})
+
+	r.GET("/broken", func(c *gin.Context) {
+		panic(&net.OpError{Op: "write", Net: "tcp", Err: os.NewSyscallError("write", syscall.EPIPE)})
+	})
+
+	return r
+}
+
+func TestRecoveryMiddlewareAnyPanicValue(t *testing.T) {
+	for _, path := range []string{"/string", "/error", "/value"} {
+		var b bytes.Buffer
+
+		tr := &transport{}
+
+		r := setupRecoveryRouter(&b, tr, false)
+
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set(REQUEST_ID_HEADER, "abc-123")
+
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		var body map[string]string
+
+		err := json.Unmarshal(w.Body.Bytes(), &body)
+
+		// Assert that the standard error envelope is always returned, without the panic value:
+		assert.Nil(t, err, path)
+		assert.Equal(t, http.StatusInternalServerError, w.Code, path)
+		assert.Equal(t, map[string]string{"error": INTERNAL_SERVER_ERROR_MESSAGE, "requestId": "abc-123"}, body, path)
+
+		// Assert that the panic is logged with its stack trace:
+		assert.Contains(t, b.String(), "Recovered from panic", path)
+		assert.Contains(t, b.String(), "stack", path)
+
+		// Assert that the panic is reported to Sentry with the request context:
+		events := tr.Events()
+
+		if assert.Len(t, events, 1, path) {
+			assert.Equal(t, "abc-123", events[0].Tags["request_id"], path)
+			assert.Contains(t, events[0].Request.URL, path, path)
+		}
+	}
+}
+
+func TestRecoveryMiddlewareErrorPanic(t *testing.T) {
+	var b bytes.Buffer
+
+	r := setupRecoveryRouter(&b, &transport{}, false)
+
+	w := httptest.NewRecorder()
+
+	req, _ := http.NewRequest(http.MethodGet, "/error", nil)
+
+	r.ServeHTTP(w, req)
+
+	// Assert that the error is logged, but not returned to the client:
+	assert.Contains(t, b.String(), "dusk: no solution")
+	assert.NotContains(t, w.Body.String(), "dusk: no solution")
+}
+
+func TestRecoveryMiddlewareWithSentryGin(t *testing.T) {
+	var b bytes.Buffer
+
+	tr := &transport{}
+
+	r := setupRecoveryRouter(&b, tr, true)
+
+	w := httptest.NewRecorder()
+
+	req, _ := http.NewRequest(http.MethodGet, "/error", nil)
+
+	r.ServeHTTP(w, req)
+
+	// Assert that the re-panic from sentrygin is recovered, and the panic is reported to Sentry only once:
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), INTERNAL_SERVER_ERROR_MESSAGE)
+	assert.Len(t, tr.Events(), 1)
+}
+
+func TestRecoveryMiddlewareAlreadyWritten(t *testing.T) {
+	var b bytes.Buffer
+
+	r := setupRecoveryRouter(&b, &transport{}, false)
+
+	w := httptest.NewRecorder()
+
+	req, _ := http.NewRequest(http.MethodGet, "/written", nil)
+
+	r.ServeHTTP(w, req)
+
+	// Assert that the response already written is left as is:
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "partial", w.Body.String())
+}
+
+func TestRecoveryMiddlewareBrokenPipe(t *testing.T) {
+	var b bytes.Buffer
+
+	tr := &transport{}
+
+	r := setupRecoveryRouter(&b, tr, false)
+
+	w := httptest.NewRecorder()
+
+	req, _ := http.NewRequest(http.MethodGet, "/broken", nil)
+
+	r.ServeHTTP(w, req)
+
+	// Assert that nothing is written or reported, as the client has gone away:
+	assert.Empty(t, w.Body.String())
+	assert.Empty(t, tr.Events())
+}
+
+func TestGetPanicError(t *testing.T) {
+	err := errors.New("dusk: no solution")
+
+	assert.Equal(t, err, getPanicError(err))
+	assert.EqualError(t, getPanicError("something went wrong"), "something went wrong")
+	assert.EqualError(t, getPanicError(panicValue{Code: 42}), "{42}")
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/getsentry/sentry-go"
+	sentrygin "github.com/getsentry/sentry-go/gin"
+	"github.com/gin-gonic/gin"
+
+	"github.com/observerly/nocturnal/internal/envelope"
+)
+
+// The header that the request ID is propagated from (by upstream clients and proxies) and to (in the response):
+const REQUEST_ID_HEADER string = "X-Request-ID"
+
+// The maximum length of a propagated request ID, longer IDs are replaced to protect the logs:
+const MAX_REQUEST_ID_LENGTH int = 128
+
+// isValidRequestID determines whether a propagated request ID is non-empty, bounded and printable ASCII:
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > MAX_REQUEST_ID_LENGTH {
+		return false
+	}
+
+	for _, r := range id {
+		if r < 0x21 || r > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
+
+// newRequestID generates a random 128-bit request ID, as 32 hexadecimal characters:
+func newRequestID() string {
+	b := make([]byte, 16)
+
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
+
+// RequestIDMiddleware propagates the X-Request-ID of the request (or generates a new one), storing it in the context
+// and setting it on the response, so that a request can be correlated across clients, logs and error reports.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(REQUEST_ID_HEADER)
+
+		if !isValidRequestID(id) {
+			id = newRequestID()
+		}
+
+		c.Set(envelope.REQUEST_ID_KEY, id)
+
+		c.Header(REQUEST_ID_HEADER, id)
+
+		c.Next()
+	}
+}
+
+// SentryRequestIDMiddleware tags the Sentry events of the request with its request ID, and so must be used after
+// both the RequestIDMiddleware and the sentrygin middleware:
+func SentryRequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if hub := sentrygin.GetHubFromContext(c); hub != nil {
+			hub.ConfigureScope(func(scope *sentry.Scope) {
+				scope.SetTag("request_id", envelope.GetRequestID(c))
+			})
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/observerly/nocturnal/internal/envelope"
+)
+
+func setupRequestIDRouter() *gin.Engine {
+	// Set gin mode to test mode:
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+
+	r.Use(RequestIDMiddleware())
+
+	r.GET("/default", func(c *gin.Context) {
+		c.JSON(http.StatusBadRequest, envelope.NewError(c, "bad request"))
+	})
+
+	return r
+}
+
+func performRequestIDRequest(r http.Handler, id string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodGet, "/default", nil)
+	if id != "" {
+		req.Header.Set(REQUEST_ID_HEADER, id)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRequestIDMiddlewarePropagates(t *testing.T) {
+	w := performRequestIDRequest(setupRequestIDRouter(), "abc-123")
+
+	assert.Equal(t, "abc-123", w.Header().Get(REQUEST_ID_HEADER))
+	assert.JSONEq(t, `{"error":"bad request","requestId":"abc-123"}`, w.Body.String())
+}
+
+func TestRequestIDMiddlewareGenerates(t *testing.T) {
+	r := setupRequestIDRouter()
+
+	first := performRequestIDRequest(r, "")
+
+	second := performRequestIDRequest(r, "")
+
+	assert.Regexp(t, `^[0-9a-f]{32}$`, first.Header().Get(REQUEST_ID_HEADER))
+	assert.NotEqual(t, first.Header().Get(REQUEST_ID_HEADER), second.Header().Get(REQUEST_ID_HEADER))
+}
+
+func TestRequestIDMiddlewareReplacesInvalid(t *testing.T) {
+	r := setupRequestIDRouter()
+
+	for _, id := range []string{"abc 123", strings.Repeat("a", MAX_REQUEST_ID_LENGTH+1), "abcé"} {
+		w := performRequestIDRequest(r, id)
+
+		assert.Regexp(t, `^[0-9a-f]{32}$`, w.Header().Get(REQUEST_ID_HEADER))
+	}
+}
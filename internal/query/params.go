@@ -1,10 +1,14 @@
 package query
 
 import (
+	"fmt"
 	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/observerly/nocturnal/internal/utils"
+	"github.com/observerly/nocturnal/pkg/observer"
 )
 
 func GetDefaultObserverParams(c *gin.Context) (string, string, string) {
@@ -16,3 +20,59 @@ func GetDefaultObserverParams(c *gin.Context) (string, string, string) {
 
 	return datetime, longitude, latitude
 }
+
+func GetDefaultObserverElevationParam(c *gin.Context) string {
+	elevation := c.DefaultQuery("elevation", strconv.Itoa(0))
+
+	return elevation
+}
+
+// GetObserver returns the observer from the request query (each parameter with its default), where any malformed
+// parameter is taken as zero, as for the v2 API:
+func GetObserver(c *gin.Context) observer.Observer {
+	d, lon, lat := GetDefaultObserverParams(c)
+
+	datetime, _ := utils.ParseDatetimeRFC3339(d)
+
+	longitude, _ := strconv.ParseFloat(lon, 64)
+
+	latitude, _ := strconv.ParseFloat(lat, 64)
+
+	elevation, _ := strconv.ParseFloat(GetDefaultObserverElevationParam(c), 64)
+
+	return observer.New(datetime, longitude, latitude, elevation)
+}
+
+// ParseObserverParams parses the observer's datetime, longitude, latitude and elevation from the request query (each
+// with its default), returning an error if any is malformed or out of range:
+func ParseObserverParams(c *gin.Context) (time.Time, float64, float64, float64, error) {
+	d, lon, lat := GetDefaultObserverParams(c)
+
+	datetime, err := time.Parse(time.RFC3339, d)
+
+	if err != nil {
+		return time.Time{}, 0, 0, 0, fmt.Errorf("datetime: %q must be an RFC3339 timestamp", d)
+	}
+
+	longitude, err := strconv.ParseFloat(lon, 64)
+
+	if err != nil || longitude < -180 || longitude > 180 {
+		return time.Time{}, 0, 0, 0, fmt.Errorf("longitude: %q must be a number between -180 and 180", lon)
+	}
+
+	latitude, err := strconv.ParseFloat(lat, 64)
+
+	if err != nil || latitude < -90 || latitude > 90 {
+		return time.Time{}, 0, 0, 0, fmt.Errorf("latitude: %q must be a number between -90 and 90", lat)
+	}
+
+	elv := GetDefaultObserverElevationParam(c)
+
+	elevation, err := strconv.ParseFloat(elv, 64)
+
+	if err != nil {
+		return time.Time{}, 0, 0, 0, fmt.Errorf("elevation: %q must be a number", elv)
+	}
+
+	return datetime, longitude, latitude, elevation, nil
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -75,3 +76,39 @@ func TestGetDefaultObserverQueryWhenPopulated(t *testing.T) {
 		t.Fatalf("Expected to get status %d but instead got %d\n", http.StatusOK, w.Code)
 	}
 }
+
+func TestParseObserverParams(t *testing.T) {
+	// Set gin mode to test mode:
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+
+	r.GET("/observer", func(c *gin.Context) {
+		datetime, longitude, latitude, elevation, err := ParseObserverParams(c)
+
+		if err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		c.String(http.StatusOK, "%s %v %v %v", datetime.Format(time.RFC3339), longitude, latitude, elevation)
+	})
+
+	for query, expected := range map[string]string{
+		"?datetime=2021-05-14T00:00:00-10:00&longitude=-155.5&latitude=19.8&elevation=4205": "2021-05-14T00:00:00-10:00 -155.5 19.8 4205",
+		"?datetime=2021-05-14":                          `datetime: "2021-05-14" must be an RFC3339 timestamp`,
+		"?datetime=2021-05-14T00:00:00Z&longitude=181":  `longitude: "181" must be a number between -180 and 180`,
+		"?datetime=2021-05-14T00:00:00Z&latitude=north": `latitude: "north" must be a number between -90 and 90`,
+		"?datetime=2021-05-14T00:00:00Z&elevation=high": `elevation: "high" must be a number`,
+	} {
+		req, _ := http.NewRequest(http.MethodGet, "/observer"+query, nil)
+
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		if w.Body.String() != expected {
+			t.Errorf("Expected %q for %s but instead got %q\n", expected, query, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// The interval at which buckets that have refilled (and so are equivalent to new buckets) are removed:
+const SWEEP_INTERVAL time.Duration = time.Minute
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	// The time at which the bucket will have refilled:
+	full time.Time
+}
+
+// Memory is an in-memory Store of token buckets, for a single instance:
+type Memory struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	swept   time.Time
+	now     func() time.Time
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		buckets: make(map[string]*bucket),
+		swept:   time.Now(),
+		now:     time.Now,
+	}
+}
+
+// ceilDuration returns the duration for the given (fractional) number of seconds, rounded up to the whole second:
+func ceilDuration(seconds float64) time.Duration {
+	return time.Duration(math.Ceil(seconds)) * time.Second
+}
+
+func (m *Memory) Take(_ context.Context, key string, limit Limit) (Result, error) {
+	if limit.IsUnlimited() {
+		return Result{Allowed: true}, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+
+	m.sweep(now)
+
+	capacity := float64(limit.Capacity())
+
+	b, exists := m.buckets[key]
+
+	if !exists {
+		b = &bucket{tokens: capacity, updated: now}
+		m.buckets[key] = b
+	}
+
+	// Refill the bucket for the time elapsed since it was last updated:
+	b.tokens = math.Min(capacity, b.tokens+now.Sub(b.updated).Seconds()*limit.Rate())
+
+	b.updated = now
+
+	result := Result{Limit: limit.Capacity()}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = ceilDuration((1 - b.tokens) / limit.Rate())
+	}
+
+	result.Remaining = int(b.tokens)
+
+	result.Reset = ceilDuration((capacity - b.tokens) / limit.Rate())
+
+	b.full = now.Add(result.Reset)
+
+	return result, nil
+}
+
+// sweep removes the buckets which would have refilled by now, so that idle clients do not accumulate in memory:
+func (m *Memory) sweep(now time.Time) {
+	if now.Sub(m.swept) < SWEEP_INTERVAL {
+		return
+	}
+
+	m.swept = now
+
+	for key, b := range m.buckets {
+		if !now.Before(b.full) {
+			delete(m.buckets, key)
+		}
+	}
+}
+
+// Len returns the number of buckets currently held:
+func (m *Memory) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.buckets)
+}
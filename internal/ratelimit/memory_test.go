@@ -0,0 +1,104 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var ctx = context.Background()
+
+func newTestMemory(now *time.Time) *Memory {
+	m := NewMemory()
+	m.now = func() time.Time { return *now }
+	m.swept = *now
+	return m
+}
+
+func TestLimit(t *testing.T) {
+	assert.True(t, Limit{}.IsUnlimited())
+	assert.Equal(t, 60, Limit{RequestsPerMinute: 60}.Capacity())
+	assert.Equal(t, 10, Limit{RequestsPerMinute: 60, Burst: 10}.Capacity())
+	assert.Equal(t, 1.0, Limit{RequestsPerMinute: 60}.Rate())
+}
+
+func TestMemoryTakeUnlimited(t *testing.T) {
+	m := NewMemory()
+
+	result, err := m.Take(ctx, "ip:127.0.0.1", Limit{})
+
+	assert.Nil(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, m.Len())
+}
+
+func TestMemoryTakeBurst(t *testing.T) {
+	now := time.Date(2021, 5, 14, 0, 0, 0, 0, time.UTC)
+
+	m := newTestMemory(&now)
+
+	limit := Limit{RequestsPerMinute: 60, Burst: 3}
+
+	for i := 2; i >= 0; i-- {
+		result, _ := m.Take(ctx, "ip:127.0.0.1", limit)
+
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 3, result.Limit)
+		assert.Equal(t, i, result.Remaining)
+	}
+
+	result, _ := m.Take(ctx, "ip:127.0.0.1", limit)
+
+	// Assert that the burst is exhausted, and that a token is available after one second:
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+	assert.Equal(t, time.Second, result.RetryAfter)
+	assert.Equal(t, 3*time.Second, result.Reset)
+
+	// Assert that other clients have their own bucket:
+	result, _ = m.Take(ctx, "ip:127.0.0.2", limit)
+
+	assert.True(t, result.Allowed)
+}
+
+func TestMemoryTakeRefill(t *testing.T) {
+	now := time.Date(2021, 5, 14, 0, 0, 0, 0, time.UTC)
+
+	m := newTestMemory(&now)
+
+	limit := Limit{RequestsPerMinute: 60, Burst: 1}
+
+	result, _ := m.Take(ctx, "ip:127.0.0.1", limit)
+	assert.True(t, result.Allowed)
+
+	result, _ = m.Take(ctx, "ip:127.0.0.1", limit)
+	assert.False(t, result.Allowed)
+
+	// Move the clock on by the time it takes to refill a single token:
+	now = now.Add(time.Second)
+
+	result, _ = m.Take(ctx, "ip:127.0.0.1", limit)
+	assert.True(t, result.Allowed)
+}
+
+func TestMemorySweep(t *testing.T) {
+	now := time.Date(2021, 5, 14, 0, 0, 0, 0, time.UTC)
+
+	m := newTestMemory(&now)
+
+	// A bucket that takes 10 minutes to refill:
+	for i := 0; i < 10; i++ {
+		_, _ = m.Take(ctx, "key:slow", Limit{RequestsPerMinute: 1, Burst: 100})
+	}
+
+	_, _ = m.Take(ctx, "ip:127.0.0.1", Limit{RequestsPerMinute: 60})
+
+	now = now.Add(2 * SWEEP_INTERVAL)
+
+	_, _ = m.Take(ctx, "ip:127.0.0.2", Limit{RequestsPerMinute: 60})
+
+	// Assert that only the refilled bucket was removed:
+	assert.Equal(t, 2, m.Len())
+}
@@ -0,0 +1,52 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+type Limit struct {
+	// The sustained number of requests allowed per minute, where 0 is unlimited:
+	RequestsPerMinute int
+	// The number of requests allowed in a single burst, i.e., the capacity of the bucket (defaulting to the
+	// requests per minute when 0):
+	Burst int
+}
+
+// IsUnlimited determines whether the limit allows an unlimited number of requests:
+func (l Limit) IsUnlimited() bool {
+	return l.RequestsPerMinute <= 0
+}
+
+// Capacity returns the maximum number of tokens that the bucket holds:
+func (l Limit) Capacity() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return l.RequestsPerMinute
+}
+
+// Rate returns the number of tokens added to the bucket per second:
+func (l Limit) Rate() float64 {
+	return float64(l.RequestsPerMinute) / 60
+}
+
+type Result struct {
+	// Whether the request is allowed, i.e., whether a token was taken from the bucket:
+	Allowed bool
+	// The capacity of the bucket:
+	Limit int
+	// The number of whole tokens remaining in the bucket:
+	Remaining int
+	// The duration until the bucket is full again:
+	Reset time.Duration
+	// The duration until the next token is available, if the request was not allowed:
+	RetryAfter time.Duration
+}
+
+// Store is implemented by rate limit backends, e.g., the in-memory token buckets, or a shared store (e.g., a Redis
+// script that atomically refills and takes from the bucket), so that limits can be enforced across instances.
+type Store interface {
+	// Take attempts to take a token from the bucket for the key, which is refilled according to the limit:
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
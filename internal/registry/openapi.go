@@ -0,0 +1,103 @@
+package registry
+
+import (
+	"strings"
+)
+
+// The version of the OpenAPI specification that the schema adheres to:
+const OPENAPI_VERSION string = "3.0.3"
+
+type OpenAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+}
+
+type OpenAPISchema struct {
+	Type    string      `json:"type"`
+	Format  string      `json:"format,omitempty"`
+	Default interface{} `json:"default,omitempty"`
+}
+
+type OpenAPIParameter struct {
+	Name        string        `json:"name"`
+	In          string        `json:"in"`
+	Description string        `json:"description"`
+	Required    bool          `json:"required"`
+	Schema      OpenAPISchema `json:"schema"`
+}
+
+type OpenAPIResponse struct {
+	Description string `json:"description"`
+}
+
+type OpenAPIOperation struct {
+	Summary     string                     `json:"summary"`
+	Deprecated  bool                       `json:"deprecated,omitempty"`
+	Parameters  []OpenAPIParameter         `json:"parameters"`
+	Responses   map[string]OpenAPIResponse `json:"responses"`
+	OperationID string                     `json:"operationId"`
+}
+
+type OpenAPI struct {
+	OpenAPI string                                 `json:"openapi"`
+	Info    OpenAPIInfo                            `json:"info"`
+	Paths   map[string]map[string]OpenAPIOperation `json:"paths"`
+}
+
+// getOperationID returns a unique identifier for the operation of the route, e.g., "getApiV2Sun":
+func getOperationID(route Route) string {
+	id := strings.ToLower(route.Method)
+
+	for _, segment := range strings.Split(route.Path, "/") {
+		if segment != "" {
+			id += strings.ToUpper(segment[:1]) + segment[1:]
+		}
+	}
+
+	return id
+}
+
+// GetOpenAPI generates the OpenAPI schema of the routes of the API version:
+func (r *Registry) GetOpenAPI(version string, info OpenAPIInfo) OpenAPI {
+	schema := OpenAPI{
+		OpenAPI: OPENAPI_VERSION,
+		Info:    info,
+		Paths:   map[string]map[string]OpenAPIOperation{},
+	}
+
+	for _, route := range r.Version(version) {
+		parameters := []OpenAPIParameter{}
+
+		for _, p := range route.Parameters {
+			parameters = append(parameters, OpenAPIParameter{
+				Name:        p.Name,
+				In:          "query",
+				Description: p.Description,
+				Required:    p.Required,
+				Schema: OpenAPISchema{
+					Type:    p.Type,
+					Format:  p.Format,
+					Default: p.Default,
+				},
+			})
+		}
+
+		if _, exists := schema.Paths[route.Path]; !exists {
+			schema.Paths[route.Path] = map[string]OpenAPIOperation{}
+		}
+
+		schema.Paths[route.Path][strings.ToLower(route.Method)] = OpenAPIOperation{
+			Summary:    route.Summary,
+			Deprecated: route.Deprecated,
+			Parameters: parameters,
+			Responses: map[string]OpenAPIResponse{
+				"200": {Description: "OK"},
+				"400": {Description: "Invalid query parameters, with the error envelope."},
+			},
+			OperationID: getOperationID(route),
+		}
+	}
+
+	return schema
+}
@@ -0,0 +1,146 @@
+package registry
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Parameter struct {
+	// The name of the query parameter, e.g., "latitude":
+	Name string `json:"name"`
+	// The JSON schema type of the parameter, i.e., one of "string", "number", "integer" or "boolean":
+	Type string `json:"type"`
+	// The JSON schema format of the parameter (if any), e.g., "date-time":
+	Format string `json:"format,omitempty"`
+	// The description of the parameter, including its units:
+	Description string `json:"description"`
+	// The value of the parameter when omitted (if it is a fixed value):
+	Default interface{} `json:"default,omitempty"`
+	// Whether the parameter must be included in the request:
+	Required bool `json:"required"`
+}
+
+type Route struct {
+	// The HTTP method of the route, e.g., "GET":
+	Method string `json:"method"`
+	// The path of the route, e.g., "/api/v2/sun":
+	Path string `json:"path"`
+	// The version of the API that the route belongs to, e.g., "v2":
+	Version string `json:"version"`
+	// A short summary of what the route returns:
+	Summary string `json:"summary"`
+	// The query parameters accepted by the route:
+	Parameters []Parameter `json:"parameters"`
+	// Whether the route is deprecated, and should no longer be used:
+	Deprecated bool `json:"deprecated"`
+	// The path of the route that replaces a deprecated route (if any), e.g., "/api/v2/sun":
+	Successor string `json:"successor,omitempty"`
+	// The handler of the route:
+	Handler gin.HandlerFunc `json:"-"`
+}
+
+// Registry is the catalogue of every API route, from which the routes are registered on the engine and the API index
+// and OpenAPI schema are generated, so that they can never drift apart:
+type Registry struct {
+	routes []Route
+}
+
+func New() *Registry {
+	return &Registry{
+		routes: []Route{},
+	}
+}
+
+// Add adds the routes to the registry:
+func (r *Registry) Add(routes ...Route) {
+	r.routes = append(r.routes, routes...)
+}
+
+// Routes returns every route in the registry, in the order that they were added:
+func (r *Registry) Routes() []Route {
+	return append([]Route{}, r.routes...)
+}
+
+// Versions returns the API versions of the routes in the registry, in ascending order, e.g., ["v1", "v2"]:
+func (r *Registry) Versions() []string {
+	versions := []string{}
+
+	seen := map[string]bool{}
+
+	for _, route := range r.routes {
+		if !seen[route.Version] {
+			seen[route.Version] = true
+			versions = append(versions, route.Version)
+		}
+	}
+
+	sort.Strings(versions)
+
+	return versions
+}
+
+// Version returns the routes of the API version, in path order:
+func (r *Registry) Version(version string) []Route {
+	routes := []Route{}
+
+	for _, route := range r.routes {
+		if route.Version == version {
+			routes = append(routes, route)
+		}
+	}
+
+	sort.SliceStable(routes, func(i, j int) bool {
+		return routes[i].Path < routes[j].Path
+	})
+
+	return routes
+}
+
+// Register registers the handler of every route of the API version on the group, relative to its base path, e.g.,
+// "/api/v2/sun" as "/sun" on the "/api/v2" group, so that the routes have the middleware of the group:
+func (r *Registry) Register(group *gin.RouterGroup, version string) {
+	for _, route := range r.Version(version) {
+		group.Handle(route.Method, strings.TrimPrefix(route.Path, group.BasePath()), route.Handler)
+	}
+}
+
+// GetOpenAPIPath returns the path of the OpenAPI schema of the API version, e.g., "/api/v2/openapi.json":
+func GetOpenAPIPath(version string) string {
+	return "/api/" + version + "/openapi.json"
+}
+
+// GetSchemaLink returns the link to the operation of the route within the OpenAPI schema, as a JSON pointer, e.g.,
+// "/api/v2/openapi.json#/paths/~1api~1v2~1sun/get":
+func GetSchemaLink(route Route) string {
+	// Escape the path per RFC 6901, i.e., "~" as "~0" and "/" as "~1":
+	path := strings.ReplaceAll(strings.ReplaceAll(route.Path, "~", "~0"), "/", "~1")
+
+	return GetOpenAPIPath(route.Version) + "#/paths/" + path + "/" + strings.ToLower(route.Method)
+}
+
+// GetObserverParameters returns the parameters of the observer's datetime and geographic coordinates, that every
+// route accepts:
+func GetObserverParameters() []Parameter {
+	return []Parameter{
+		{
+			Name:        "datetime",
+			Type:        "string",
+			Format:      "date-time",
+			Description: "The datetime of the observation, as an RFC3339 timestamp, defaulting to the current time.",
+		},
+		{
+			Name:        "longitude",
+			Type:        "number",
+			Description: "The longitude of the observer, in degrees east of the Greenwich meridian.",
+			Default:     0,
+		},
+		{
+			Name:        "latitude",
+			Type:        "number",
+			Description: "The latitude of the observer, in degrees north of the equator.",
+			Default:     0,
+		},
+	}
+}
@@ -0,0 +1,104 @@
+package registry
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestRegistry() *Registry {
+	routes := New()
+
+	handler := func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	}
+
+	routes.Add(Route{
+		Method:     "GET",
+		Path:       "/api/v2/sun",
+		Version:    "v2",
+		Summary:    "The Sun.",
+		Parameters: GetObserverParameters(),
+		Handler:    handler,
+	}, Route{
+		Method:     "GET",
+		Path:       "/api/v1/sun",
+		Version:    "v1",
+		Summary:    "The Sun.",
+		Deprecated: true,
+		Successor:  "/api/v2/sun",
+		Handler:    handler,
+	}, Route{
+		Method:  "GET",
+		Path:    "/api/v2/moon",
+		Version: "v2",
+		Summary: "The Moon.",
+		Handler: handler,
+	})
+
+	return routes
+}
+
+func TestRegistryVersions(t *testing.T) {
+	routes := getTestRegistry()
+
+	assert.Equal(t, []string{"v1", "v2"}, routes.Versions())
+	assert.Len(t, routes.Routes(), 3)
+
+	// Assert that the routes of a version are in path order:
+	v2 := routes.Version("v2")
+
+	assert.Equal(t, "/api/v2/moon", v2[0].Path)
+	assert.Equal(t, "/api/v2/sun", v2[1].Path)
+}
+
+func TestRegistryRegister(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+
+	routes := getTestRegistry()
+
+	routes.Register(r.Group("/api/v1"), "v1")
+
+	routes.Register(r.Group("/api/v2"), "v2")
+
+	// Assert that every route in the registry is registered on its version's group:
+	assert.Len(t, r.Routes(), 3)
+
+	w := httptest.NewRecorder()
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/sun", nil)
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestGetSchemaLink(t *testing.T) {
+	assert.Equal(t, "/api/v2/openapi.json#/paths/~1api~1v2~1sun/get", GetSchemaLink(Route{Method: "GET", Path: "/api/v2/sun", Version: "v2"}))
+}
+
+func TestGetOpenAPI(t *testing.T) {
+	schema := getTestRegistry().GetOpenAPI("v1", OpenAPIInfo{Title: "Nocturnal", Version: "v1"})
+
+	assert.Equal(t, OPENAPI_VERSION, schema.OpenAPI)
+	assert.Len(t, schema.Paths, 1)
+
+	operation := schema.Paths["/api/v1/sun"]["get"]
+
+	assert.True(t, operation.Deprecated)
+	assert.Equal(t, "getApiV1Sun", operation.OperationID)
+
+	schema = getTestRegistry().GetOpenAPI("v2", OpenAPIInfo{Title: "Nocturnal", Version: "v2"})
+
+	parameters := schema.Paths["/api/v2/sun"]["get"].Parameters
+
+	// Assert that the parameters are described as query parameters, with their types and defaults:
+	assert.Len(t, parameters, 3)
+	assert.Equal(t, OpenAPIParameter{Name: "latitude", In: "query", Description: "The latitude of the observer, in degrees north of the equator.", Schema: OpenAPISchema{Type: "number", Default: 0}}, parameters[2])
+	assert.Equal(t, "date-time", parameters[0].Schema.Format)
+}
@@ -0,0 +1,175 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/observerly/nocturnal/internal/config"
+)
+
+// The query parameters of an observer at Mauna Kea, Hawaii, on the night of the 14th May 2021:
+const OBSERVER_QUERY string = "?datetime=2021-05-14T00:00:00-10:00&longitude=-155.468094&latitude=19.798484&elevation=4205"
+
+func TestAPIEndpoints(t *testing.T) {
+	endpoints := map[string][]string{
+		"/api/v2/sun":             {"observer", "rise", "maximum", "set"},
+		"/api/v2/solar":           {"observer", "rise", "maximum", "set"},
+		"/api/v2/moon":            {"observer", "rise", "maximum", "set"},
+		"/api/v2/lunar":           {"observer", "rise", "maximum", "set"},
+		"/api/v2/moon/libration":  {"observer", "libration"},
+		"/api/v2/lunar/libration": {"observer", "libration"},
+		"/api/v2/transit":         {"observer", "rise", "maximum", "set", "path"},
+		"/api/v2/occultation":     {"observer", "target", "occultations"},
+		"/api/v2/twilight":        {"observer", "civil", "nautical", "astronomical"},
+		"/api/v3/sun":             {"observer", "bodies"},
+		"/api/v3/moon":            {"observer", "bodies"},
+		"/api/v3/planets":         {"observer", "bodies"},
+		"/api/v3/transit":         {"observer", "bodies"},
+		"/api/v3/twilight":        {"observer", "bodies"},
+		"/api/v1/sun":             {"observer", "position", "transit", "tomorrow"},
+		"/api/v1/solar":           {"observer", "position", "transit", "tomorrow"},
+		"/api/v1/moon":            {"observer", "phase", "position", "transit"},
+		"/api/v1/lunar":           {"observer", "phase", "position", "transit"},
+		"/api/v1/transit":         {"observer", "phase", "position", "properties", "path"},
+		"/api/v1/twilight":        {"observer", "civil", "nautical", "astronomical"},
+	}
+
+	for path, keys := range endpoints {
+		w := performRequest(r, "GET", path+OBSERVER_QUERY)
+
+		var body map[string]interface{}
+
+		err := json.Unmarshal(w.Body.Bytes(), &body)
+
+		// Assert that the real endpoint is served in-process, with the documented response schema:
+		assert.Nil(t, err, path)
+		assert.Equal(t, http.StatusOK, w.Code, path)
+
+		for _, key := range keys {
+			assert.Contains(t, body, key, path)
+		}
+	}
+}
+
+func TestAPIDeprecatedVersion(t *testing.T) {
+	w := performRequest(r, "GET", "/api/v1/sun"+OBSERVER_QUERY)
+
+	// Assert that the routes of the deprecated version have the deprecation headers of its group, linking to their own
+	// successor:
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "@1676332800", w.Header().Get("Deprecation"))
+	assert.Empty(t, w.Header().Get("Sunset"))
+	assert.Equal(t, `</api/v2/sun>; rel="successor-version"`, w.Header().Get("Link"))
+
+	w = performRequest(r, "GET", "/api/v1")
+
+	// Assert that the index of the deprecated version links to the latest version:
+	assert.Equal(t, "@1676332800", w.Header().Get("Deprecation"))
+	assert.Equal(t, `</api/v2>; rel="successor-version"`, w.Header().Get("Link"))
+
+	w = performRequest(r, "GET", "/api/v2/sun"+OBSERVER_QUERY)
+
+	// Assert that the routes of the latest version are not deprecated:
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Deprecation"))
+}
+
+func TestAPIRetiredVersion(t *testing.T) {
+	cfg := config.Default()
+
+	cfg.Deprecation["v1"] = config.DeprecationConfig{
+		DeprecatedAt:  time.Date(2023, time.February, 14, 0, 0, 0, 0, time.UTC),
+		Sunset:        time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		Cutoff:        time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		Documentation: "https://nocturnal.observerly.com/docs/migration",
+	}
+
+	r := New(cfg, Dependencies{})
+
+	w := performRequest(r, "GET", "/api/v1/sun"+OBSERVER_QUERY)
+
+	var body map[string]interface{}
+
+	err := json.Unmarshal(w.Body.Bytes(), &body)
+
+	// Assert that the deprecated version is gone after its cutoff, with the successor to migrate to:
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusGone, w.Code)
+	assert.Equal(t, "Mon, 01 Jan 2024 00:00:00 GMT", w.Header().Get("Sunset"))
+	assert.Equal(t, `</api/v2/sun>; rel="successor-version", <https://nocturnal.observerly.com/docs/migration>; rel="sunset"`, w.Header().Get("Link"))
+	assert.Equal(t, "/api/v2/sun", body["successor"])
+	assert.Equal(t, "https://nocturnal.observerly.com/docs/migration", body["documentation"])
+	assert.Contains(t, body["error"], "retired")
+
+	w = performRequest(r, "GET", "/api/v2/sun"+OBSERVER_QUERY)
+
+	// Assert that the latest version is still served:
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAPIPreviewVersion(t *testing.T) {
+	w := performRequest(r, "GET", "/api/v3/sun"+OBSERVER_QUERY)
+
+	// Assert that a version after the latest is served, but is not deprecated:
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Deprecation"))
+
+	cfg := config.Default()
+
+	cfg.APIVersion = "v3"
+
+	r := New(cfg, Dependencies{Routes: GetRoutes()})
+
+	w = performRequest(r, "GET", "/api/v2/sun"+OBSERVER_QUERY)
+
+	// Assert that promoting the version to the latest deprecates the version before it:
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "true", w.Header().Get("Deprecation"))
+	assert.Equal(t, `</api/v3>; rel="successor-version"`, w.Header().Get("Link"))
+}
+
+func TestIsDeprecatedVersion(t *testing.T) {
+	assert.True(t, isDeprecatedVersion("v1", "v2"))
+	assert.False(t, isDeprecatedVersion("v2", "v2"))
+	assert.False(t, isDeprecatedVersion("v3", "v2"))
+	assert.True(t, isDeprecatedVersion("v2", "v10"))
+}
+
+func TestAPIInvalidParameters(t *testing.T) {
+	w := performRequest(r, "GET", "/api/v2/moon"+OBSERVER_QUERY+"&next=maybe")
+
+	var body map[string]interface{}
+
+	err := json.Unmarshal(w.Body.Bytes(), &body)
+
+	// Assert that an invalid parameter is a 400, with the error envelope:
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, body, "error")
+	assert.Equal(t, w.Header().Get("X-Request-ID"), body["requestId"])
+}
+
+func TestAPIIndexEnumeratesRoutes(t *testing.T) {
+	w := performRequest(r, "GET", "/api/v2")
+
+	var index struct {
+		Endpoints []struct {
+			Path string `json:"path"`
+		} `json:"endpoints"`
+	}
+
+	err := json.Unmarshal(w.Body.Bytes(), &index)
+
+	assert.Nil(t, err)
+
+	// Assert that every route of the version in the index is served by the router:
+	for _, endpoint := range index.Endpoints {
+		w := performRequest(r, "GET", endpoint.Path+OBSERVER_QUERY)
+
+		assert.Equal(t, http.StatusOK, w.Code, endpoint.Path)
+	}
+}
@@ -0,0 +1,113 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/observerly/dusk/pkg/dusk"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/observerly/nocturnal/internal/config"
+	"github.com/observerly/nocturnal/pkg/events"
+	"github.com/observerly/nocturnal/pkg/moon"
+	"github.com/observerly/nocturnal/pkg/observer"
+	"github.com/observerly/nocturnal/pkg/occultation"
+	"github.com/observerly/nocturnal/pkg/planets"
+	"github.com/observerly/nocturnal/pkg/sun"
+	"github.com/observerly/nocturnal/pkg/transit"
+	"github.com/observerly/nocturnal/pkg/twilight"
+)
+
+// The query parameters of a target (Betelgeuse), for the routes of a fixed equatorial coordinate:
+const TARGET_QUERY string = "&ra=88.792958&dec=7.407064"
+
+// getEventsResponse returns the v3 response of the named body with its events, as for the observer of OBSERVER_QUERY:
+func getEventsResponse(o observer.Observer, location *time.Location, bodies ...events.Body) events.Response {
+	return events.Response{
+		Observer: events.NewObserver(o.Datetime, o.Longitude, o.Latitude, o.Elevation, location),
+		Bodies:   bodies,
+	}
+}
+
+func TestAdapterEquivalence(t *testing.T) {
+	cfg := config.Default()
+
+	// Disable the response cache, so that every response is computed by the adapter itself:
+	cfg.Cache.Size = 0
+
+	r := New(cfg, Dependencies{})
+
+	ctx := context.Background()
+
+	datetime, _ := time.Parse(time.RFC3339, "2021-05-14T00:00:00-10:00")
+
+	o := observer.New(datetime, -155.468094, 19.798484, 4205)
+
+	location, err := observer.GetLocation(o.Longitude, o.Latitude)
+
+	assert.Nil(t, err)
+
+	eq := dusk.EquatorialCoordinate{RightAscension: 88.792958, Declination: 7.407064}
+
+	// Each route, with the result of the typed function that its adapter must respond with exactly:
+	routes := []struct {
+		path string
+		get  func() (any, error)
+	}{
+		{"/api/v2/sun", func() (any, error) {
+			return sun.GetSolarTransit(ctx, o)
+		}},
+		{"/api/v2/moon", func() (any, error) {
+			return moon.GetLunarTransit(ctx, o)
+		}},
+		{"/api/v2/moon/libration", func() (any, error) {
+			return moon.GetLibration(ctx, o), nil
+		}},
+		{"/api/v2/transit", func() (any, error) {
+			return transit.GetObjectTransit(ctx, o, eq)
+		}},
+		{"/api/v2/occultation", func() (any, error) {
+			return occultation.GetOccultations(ctx, o, eq, 1)
+		}},
+		{"/api/v2/twilight", func() (any, error) {
+			return twilight.GetTwilightPeriods(ctx, o)
+		}},
+		{"/api/v3/sun", func() (any, error) {
+			evs, err := sun.GetSolarEvents(ctx, o.Datetime, o.Longitude, o.Latitude, location)
+			return getEventsResponse(o, location, events.NewBody("sun", evs...)), err
+		}},
+		{"/api/v3/moon", func() (any, error) {
+			evs, err := moon.GetLunarEvents(ctx, o.Datetime, o.Longitude, o.Latitude, o.Elevation, location)
+			return getEventsResponse(o, location, events.NewBody("moon", evs...)), err
+		}},
+		{"/api/v3/transit", func() (any, error) {
+			evs, err := transit.GetTransitEvents(ctx, o.Datetime, eq, o.Longitude, o.Latitude, location)
+			return getEventsResponse(o, location, events.NewBody("target", evs...)), err
+		}},
+		{"/api/v3/twilight", func() (any, error) {
+			evs, err := twilight.GetTwilightEvents(ctx, o.Datetime, o.Longitude, o.Latitude, location)
+			return getEventsResponse(o, location, events.NewBody("sun", evs...)), err
+		}},
+		{"/api/v3/planets", func() (any, error) {
+			bodies, err := planets.GetPlanetaryEvents(ctx, o.Datetime, nil, o.Longitude, o.Latitude, location)
+			return getEventsResponse(o, location, bodies...), err
+		}},
+	}
+
+	for _, route := range routes {
+		res, err := route.get()
+
+		assert.Nil(t, err, route.path)
+
+		expected, _ := json.Marshal(res)
+
+		w := performRequest(r, "GET", route.path+OBSERVER_QUERY+TARGET_QUERY)
+
+		// Assert that the adapter responds with exactly the result of the typed function:
+		assert.Equal(t, http.StatusOK, w.Code, route.path)
+		assert.JSONEq(t, string(expected), w.Body.String(), route.path)
+	}
+}
@@ -0,0 +1,91 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/observerly/nocturnal/internal/registry"
+)
+
+// The name of the API, as it appears in each index and OpenAPI schema:
+const API_NAME string = "Nocturnal API by observerly"
+
+// The description of each version of the API:
+var descriptions = map[string]string{
+	"v1": "Nocturnal 🌑 is observerly's Gin Gonic API for Lunar and Solar advanced scheduling, that utilises Dusk.",
+	"v2": "Nocturnal 🌑 is observerly's Gin Gonic API for Lunar, Solar and astronomical advanced scheduling, that utilises Dusk.",
+	"v3": "Nocturnal 🌑 is observerly's Gin Gonic API for Lunar, Solar, planetary and astronomical advanced scheduling, that utilises Dusk, where every endpoint responds with the events of each body.",
+}
+
+// getDescription returns the description of the API version:
+func getDescription(version string) string {
+	if description, exists := descriptions[version]; exists {
+		return description
+	}
+
+	return descriptions["v2"]
+}
+
+// getEndpointCatalogue enumerates the routes of the API version, with their parameters, deprecation status and links:
+func getEndpointCatalogue(routes *registry.Registry, version string) []gin.H {
+	catalogue := []gin.H{}
+
+	for _, route := range routes.Version(version) {
+		endpoint := gin.H{
+			"method":     route.Method,
+			"path":       route.Path,
+			"summary":    route.Summary,
+			"parameters": route.Parameters,
+			"deprecated": route.Deprecated,
+			"links": gin.H{
+				"self":   route.Path,
+				"schema": registry.GetSchemaLink(route),
+			},
+		}
+
+		if route.Successor != "" {
+			endpoint["successor"] = route.Successor
+		}
+
+		catalogue = append(catalogue, endpoint)
+	}
+
+	return catalogue
+}
+
+// GetIndexHandler returns the self-describing index of the API version, generated from the route registry:
+func GetIndexHandler(routes *registry.Registry, version string, latest string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(
+			http.StatusOK,
+			gin.H{
+				"description": getDescription(version),
+				"endpoint":    fmt.Sprintf("/api/%v", version),
+				"name":        API_NAME,
+				"version":     version,
+				"deprecated":  isDeprecatedVersion(version, latest),
+				"endpoints":   getEndpointCatalogue(routes, version),
+				"links": gin.H{
+					"self":    fmt.Sprintf("/api/%v", version),
+					"latest":  fmt.Sprintf("/api/%v", latest),
+					"openapi": registry.GetOpenAPIPath(version),
+				},
+			},
+		)
+	}
+}
+
+// GetOpenAPIHandler returns the OpenAPI schema of the API version, generated from the route registry:
+func GetOpenAPIHandler(routes *registry.Registry, version string) gin.HandlerFunc {
+	schema := routes.GetOpenAPI(version, registry.OpenAPIInfo{
+		Title:       API_NAME,
+		Description: getDescription(version),
+		Version:     version,
+	})
+
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, schema)
+	}
+}
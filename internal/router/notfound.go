@@ -0,0 +1,126 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/observerly/nocturnal/internal/envelope"
+	"github.com/observerly/nocturnal/internal/utils"
+)
+
+// The maximum edit distance between an unknown path and a route for the route to be suggested, e.g., a typo such as
+// "/api/v2/mon" for "/api/v2/moon":
+const MAX_SUGGESTION_DISTANCE int = 3
+
+// matchesRoute determines whether the path matches the route's pattern, including any :param or *wildcard segments:
+func matchesRoute(pattern string, path string) bool {
+	patterns := strings.Split(strings.Trim(pattern, "/"), "/")
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i, p := range patterns {
+		if strings.HasPrefix(p, "*") {
+			return true
+		}
+
+		if i >= len(segments) {
+			return false
+		}
+
+		if !strings.HasPrefix(p, ":") && p != segments[i] {
+			return false
+		}
+	}
+
+	return len(patterns) == len(segments)
+}
+
+// getEndpoints returns the unique paths of every route registered on the engine, in alphabetical order:
+func getEndpoints(r *gin.Engine) []string {
+	endpoints := []string{}
+
+	seen := map[string]bool{}
+
+	for _, route := range r.Routes() {
+		if !seen[route.Path] {
+			seen[route.Path] = true
+			endpoints = append(endpoints, route.Path)
+		}
+	}
+
+	sort.Strings(endpoints)
+
+	return endpoints
+}
+
+// getAllowedMethods returns the methods of every route registered on the engine for the path, in alphabetical order:
+func getAllowedMethods(r *gin.Engine, path string) []string {
+	methods := []string{}
+
+	seen := map[string]bool{}
+
+	for _, route := range r.Routes() {
+		if matchesRoute(route.Path, path) && !seen[route.Method] {
+			seen[route.Method] = true
+			methods = append(methods, route.Method)
+		}
+	}
+
+	sort.Strings(methods)
+
+	return methods
+}
+
+// getClosestEndpoint returns the endpoint with the smallest edit distance to the path, or "" if none are close enough:
+func getClosestEndpoint(endpoints []string, path string) string {
+	closest := ""
+
+	distance := MAX_SUGGESTION_DISTANCE + 1
+
+	for _, endpoint := range endpoints {
+		if d := utils.GetLevenshteinDistance(strings.ToLower(path), strings.ToLower(endpoint)); d < distance {
+			closest = endpoint
+			distance = d
+		}
+	}
+
+	return closest
+}
+
+// NotFoundHandler responds to a request for an unknown path with a 404, listing the valid endpoints and suggesting the
+// closest one (if any), N.B. the endpoints are resolved per request, so include every route registered after setup:
+func NotFoundHandler(r *gin.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		endpoints := getEndpoints(r)
+
+		body := envelope.NewError(c, fmt.Sprintf("no endpoint matches %s %s", c.Request.Method, c.Request.URL.Path))
+
+		body["endpoints"] = endpoints
+
+		if suggestion := getClosestEndpoint(endpoints, c.Request.URL.Path); suggestion != "" {
+			body["suggestion"] = suggestion
+		}
+
+		c.JSON(http.StatusNotFound, body)
+	}
+}
+
+// MethodNotAllowedHandler responds to a request with a method that the path does not support with a 405, and an Allow
+// header listing the methods that it does support:
+func MethodNotAllowedHandler(r *gin.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		methods := getAllowedMethods(r, c.Request.URL.Path)
+
+		c.Header("Allow", strings.Join(methods, ", "))
+
+		body := envelope.NewError(c, fmt.Sprintf("method %s is not allowed for %s", c.Request.Method, c.Request.URL.Path))
+
+		body["allow"] = methods
+
+		c.JSON(http.StatusMethodNotAllowed, body)
+	}
+}
@@ -0,0 +1,231 @@
+package router
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/observerly/nocturnal/internal/handlers/moon"
+	"github.com/observerly/nocturnal/internal/handlers/occultation"
+	"github.com/observerly/nocturnal/internal/handlers/planets"
+	"github.com/observerly/nocturnal/internal/handlers/sun"
+	"github.com/observerly/nocturnal/internal/handlers/transit"
+	"github.com/observerly/nocturnal/internal/handlers/twilight"
+	"github.com/observerly/nocturnal/internal/registry"
+	pkgmoon "github.com/observerly/nocturnal/pkg/moon"
+	pkgoccultation "github.com/observerly/nocturnal/pkg/occultation"
+	pkgplanets "github.com/observerly/nocturnal/pkg/planets"
+)
+
+// withParameters returns the observer parameters, along with any parameters specific to the route:
+func withParameters(parameters ...registry.Parameter) []registry.Parameter {
+	return append(registry.GetObserverParameters(), parameters...)
+}
+
+var elevationParameter = registry.Parameter{
+	Name:        "elevation",
+	Type:        "number",
+	Description: "The elevation of the observer, in metres above sea level.",
+	Default:     0,
+}
+
+var raParameter = registry.Parameter{
+	Name:        "ra",
+	Type:        "number",
+	Description: "The right ascension of the target, in degrees.",
+	Default:     0,
+}
+
+var decParameter = registry.Parameter{
+	Name:        "dec",
+	Type:        "number",
+	Description: "The declination of the target, in degrees.",
+	Default:     0,
+}
+
+// GetRoutes returns the registry of every route of the API:
+func GetRoutes() *registry.Registry {
+	routes := registry.New()
+
+	// Moon (Lunar) Properties API version 1 (deprecated):
+	for _, path := range []string{"/api/v1/moon", "/api/v1/lunar"} {
+		routes.Add(registry.Route{
+			Method:     "GET",
+			Path:       path,
+			Version:    "v1",
+			Summary:    "The position, phase and rise and set times of the Moon.",
+			Parameters: withParameters(),
+			Deprecated: true,
+			Successor:  "/api/v2/moon",
+			Handler:    moon.GetMoonDeprecatedV1,
+		})
+	}
+
+	// Moon (Lunar) Properties API version 2 (^14.02.2023):
+	for _, path := range []string{"/api/v2/moon", "/api/v2/lunar"} {
+		routes.Add(registry.Route{
+			Method:  "GET",
+			Path:    path,
+			Version: "v2",
+			Summary: "The topocentric position and phase of the Moon at its rise, upper culmination and set.",
+			Parameters: withParameters(elevationParameter, registry.Parameter{
+				Name:        "next",
+				Type:        "boolean",
+				Description: "Whether to search forward for the next rise, upper culmination and set, rather than those of the local day.",
+				Default:     false,
+			}, registry.Parameter{
+				Name:        "days",
+				Type:        "integer",
+				Description: fmt.Sprintf("The number of days to search forward when next is true, between 1 and %d.", pkgmoon.MAX_SEARCH_DAYS),
+				Default:     pkgmoon.MAX_SEARCH_DAYS,
+			}),
+			Handler: moon.GetMoon,
+		})
+	}
+
+	// Moon (Lunar) Libration API version 2:
+	for _, path := range []string{"/api/v2/moon/libration", "/api/v2/lunar/libration"} {
+		routes.Add(registry.Route{
+			Method:     "GET",
+			Path:       path,
+			Version:    "v2",
+			Summary:    "The optical libration, position angles and selenographic colongitude of the Moon.",
+			Parameters: withParameters(),
+			Handler:    moon.GetMoonLibration,
+		})
+	}
+
+	// Sun (Solar) Properties API version 1 (deprecated):
+	for _, path := range []string{"/api/v1/sun", "/api/v1/solar"} {
+		routes.Add(registry.Route{
+			Method:     "GET",
+			Path:       path,
+			Version:    "v1",
+			Summary:    "The position and rise and set times of the Sun.",
+			Parameters: withParameters(),
+			Deprecated: true,
+			Successor:  "/api/v2/sun",
+			Handler:    sun.GetSunDeprecatedV1,
+		})
+	}
+
+	// Sun (Solar) Properties API version 2 (^14.02.2023):
+	for _, path := range []string{"/api/v2/sun", "/api/v2/solar"} {
+		routes.Add(registry.Route{
+			Method:     "GET",
+			Path:       path,
+			Version:    "v2",
+			Summary:    "The position of the Sun at its rise, upper culmination and set.",
+			Parameters: withParameters(),
+			Handler:    sun.GetSun,
+		})
+	}
+
+	// Transit Properties API version 1 (deprecated):
+	routes.Add(registry.Route{
+		Method:     "GET",
+		Path:       "/api/v1/transit",
+		Version:    "v1",
+		Summary:    "The position, transit times and horizontal path of a target.",
+		Parameters: withParameters(raParameter, decParameter),
+		Deprecated: true,
+		Successor:  "/api/v2/transit",
+		Handler:    transit.GetTransitDeprecatedV1,
+	})
+
+	// Transit Properties API version 2 (^02.03.2023):
+	routes.Add(registry.Route{
+		Method:     "GET",
+		Path:       "/api/v2/transit",
+		Version:    "v2",
+		Summary:    "The position of a target at its rise, upper culmination and set, and its horizontal path.",
+		Parameters: withParameters(raParameter, decParameter),
+		Handler:    transit.GetTransit,
+	})
+
+	// Lunar Occultation Predictions API version 2:
+	routes.Add(registry.Route{
+		Method:  "GET",
+		Path:    "/api/v2/occultation",
+		Version: "v2",
+		Summary: "The lunar occultations of a target, with their disappearance and reappearance times and position angles.",
+		Parameters: withParameters(elevationParameter, raParameter, decParameter, registry.Parameter{
+			Name:        "target",
+			Type:        "string",
+			Description: "The name of a planet (e.g., mars) or bright star (e.g., aldebaran) to occult, instead of the ra and dec.",
+		}, registry.Parameter{
+			Name:        "days",
+			Type:        "integer",
+			Description: fmt.Sprintf("The number of days to search forward, between 1 and %d.", pkgoccultation.MAX_SEARCH_DAYS),
+			Default:     1,
+		}),
+		Handler: occultation.GetOccultation,
+	})
+
+	// Twilight (Crepusculum) Properties API version 1 (deprecated):
+	routes.Add(registry.Route{
+		Method:     "GET",
+		Path:       "/api/v1/twilight",
+		Version:    "v1",
+		Summary:    "The civil, nautical and astronomical twilight times.",
+		Parameters: withParameters(),
+		Deprecated: true,
+		Successor:  "/api/v2/twilight",
+		Handler:    twilight.GetTwilight,
+	})
+
+	// Twilight (Crepusculum) Properties API version 2 (^02.03.2023):
+	routes.Add(registry.Route{
+		Method:     "GET",
+		Path:       "/api/v2/twilight",
+		Version:    "v2",
+		Summary:    "The civil, nautical and astronomical twilight times.",
+		Parameters: withParameters(),
+		Handler:    twilight.GetTwilight,
+	})
+
+	// API version 3, where every endpoint responds with the same model of bodies and their events, N.B. only the Moon
+	// (at its topocentric position) depends on the elevation of the observer, as the events of the Sun, the planets
+	// and a target are computed for the geocentric position of the body and a sea-level horizon:
+	routes.Add(registry.Route{
+		Method:     "GET",
+		Path:       "/api/v3/sun",
+		Version:    "v3",
+		Summary:    "The rise, upper culmination and set events of the Sun.",
+		Parameters: withParameters(),
+		Handler:    sun.GetSunV3,
+	}, registry.Route{
+		Method:     "GET",
+		Path:       "/api/v3/moon",
+		Version:    "v3",
+		Summary:    "The rise, upper culmination and set events of the Moon, at its topocentric position.",
+		Parameters: withParameters(elevationParameter),
+		Handler:    moon.GetMoonV3,
+	}, registry.Route{
+		Method:  "GET",
+		Path:    "/api/v3/planets",
+		Version: "v3",
+		Summary: "The rise, upper culmination and set events of each planet.",
+		Parameters: withParameters(registry.Parameter{
+			Name:        "planet",
+			Type:        "string",
+			Description: fmt.Sprintf("The name of a single planet to return, one of %s, defaulting to every planet.", strings.Join(pkgplanets.NAMES, ", ")),
+		}),
+		Handler: planets.GetPlanets,
+	}, registry.Route{
+		Method:     "GET",
+		Path:       "/api/v3/transit",
+		Version:    "v3",
+		Summary:    "The rise, upper culmination and set events of a target.",
+		Parameters: withParameters(raParameter, decParameter),
+		Handler:    transit.GetTransitV3,
+	}, registry.Route{
+		Method:     "GET",
+		Path:       "/api/v3/twilight",
+		Version:    "v3",
+		Summary:    "The civil, nautical and astronomical dusk and dawn events of the Sun.",
+		Parameters: withParameters(),
+		Handler:    twilight.GetTwilightV3,
+	})
+
+	return routes
+}
@@ -4,174 +4,350 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
-	"time"
 
 	"github.com/getsentry/sentry-go"
 	sentrygin "github.com/getsentry/sentry-go/gin"
-	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"golang.org/x/exp/slog"
 
+	"github.com/observerly/nocturnal/internal/cache"
+	"github.com/observerly/nocturnal/internal/config"
+	"github.com/observerly/nocturnal/internal/health"
+	"github.com/observerly/nocturnal/internal/logger"
+	"github.com/observerly/nocturnal/internal/metrics"
 	middleware "github.com/observerly/nocturnal/internal/middleware"
+	"github.com/observerly/nocturnal/internal/ratelimit"
+	"github.com/observerly/nocturnal/internal/registry"
+	"github.com/observerly/nocturnal/internal/tracing"
+	buildinfo "github.com/observerly/nocturnal/internal/version"
 )
 
-func getAPIVersionFromEnv() string {
-	value, exists := os.LookupEnv("API_VERSION_LATEST")
-	if !exists {
-		value = "v2"
+// The hit and miss counters for the response cache:
+var CacheMetrics = &cache.Metrics{}
+
+// getRateLimitOptions resolves the limit of each API key from the tier that it belongs to:
+func getRateLimitOptions(rl config.RateLimitConfig) middleware.RateLimitOptions {
+	apiKeys := map[string]ratelimit.Limit{}
+
+	for key, name := range rl.APIKeys {
+		tier := rl.Tiers[name]
+
+		apiKeys[key] = ratelimit.Limit{
+			RequestsPerMinute: tier.RequestsPerMinute,
+			Burst:             tier.Burst,
+		}
+	}
+
+	return middleware.RateLimitOptions{
+		Limit: ratelimit.Limit{
+			RequestsPerMinute: rl.RequestsPerMinute,
+			Burst:             rl.Burst,
+		},
+		APIKeyHeader: rl.APIKeyHeader,
+		APIKeys:      apiKeys,
 	}
-	return value
 }
 
-func CORSMiddleware(config cors.Config) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		origin := c.Request.Header.Get("Origin")
+// isTraced determines whether a request is traced, i.e., every request except for the probes and metrics:
+func isTraced(r *http.Request) bool {
+	switch r.URL.Path {
+	case "/metrics", "/healthz", "/readyz":
+		return false
+	default:
+		return true
+	}
+}
 
-		if config.AllowAllOrigins || config.AllowOrigins != nil {
-			for _, value := range config.AllowOrigins {
-				if value == origin {
-					c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
-				}
-			}
+// getSentryTracesSampler samples the transactions sent to Sentry. When Sentry is an OpenTelemetry exporter, the spans
+// have already been sampled by OpenTelemetry, so sentrygin's own (duplicate) transactions are dropped instead:
+func getSentryTracesSampler(cfg *config.Config) sentry.TracesSampler {
+	return func(ctx sentry.SamplingContext) float64 {
+		if !cfg.Tracing.HasExporter("sentry") {
+			return cfg.Sentry.TracesSampleRate
 		}
 
-		if config.AllowMethods != nil {
-			c.Writer.Header().Set("Access-Control-Allow-Methods", strings.Join(config.AllowMethods, ","))
+		if ctx.Span.Op == "http.server" {
+			return 0
 		}
 
-		if config.AllowHeaders != nil {
-			c.Writer.Header().Set("Access-Control-Allow-Headers", strings.Join(config.AllowHeaders, ","))
-		}
+		return 1
+	}
+}
 
-		if config.ExposeHeaders != nil {
-			c.Writer.Header().Set("Access-Control-Expose-Headers", strings.Join(config.ExposeHeaders, ","))
-		}
+// The versions of the API, each of which is a route group with its own index and OpenAPI schema:
+var API_VERSIONS = []string{"v1", "v2", "v3"}
+
+// isDeprecatedVersion determines whether the API version precedes the latest version, e.g., "v1" when the latest is
+// "v2", where any version after the latest (e.g., "v3") is a preview, and not deprecated:
+func isDeprecatedVersion(version string, latest string) bool {
+	v, err := strconv.Atoi(strings.TrimPrefix(version, "v"))
 
-		if config.AllowCredentials {
-			c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+	if err != nil {
+		return false
+	}
+
+	l, err := strconv.Atoi(strings.TrimPrefix(latest, "v"))
+
+	if err != nil {
+		return false
+	}
+
+	return v < l
+}
+
+// Dependencies are the services that the router is built from, where any left unset default to those for production,
+// so that tests can substitute their own (e.g., a registry of stub routes, or a shared cache store):
+type Dependencies struct {
+	// The structured logger, defaulting to JSON logs to stdout at the configured level:
+	Logger *slog.Logger
+	// The registry of the API's routes, defaulting to every route of the API:
+	Routes *registry.Registry
+	// The response cache store, defaulting to an in-memory LRU of the configured size:
+	Cache cache.Store
+	// The hit and miss counters of the response cache, defaulting to CacheMetrics:
+	CacheMetrics *cache.Metrics
+	// The rate limit store, defaulting to in-memory token buckets:
+	RateLimit ratelimit.Store
+}
+
+// withDefaults returns the dependencies, with the production defaults in place of any that are unset:
+func (deps Dependencies) withDefaults(cfg *config.Config) Dependencies {
+	if deps.Logger == nil {
+		// The level has already been validated, but fallback to info regardless:
+		log, err := logger.NewFromLevel(os.Stdout, cfg.Log.Level)
+
+		if err != nil {
+			log = logger.New(os.Stdout, slog.LevelInfo)
 		}
 
-		if config.MaxAge > 0 {
-			c.Writer.Header().Set("Access-Control-Max-Age", fmt.Sprintf("%d", config.MaxAge))
+		deps.Logger = log
+	}
+
+	if deps.Routes == nil {
+		deps.Routes = GetRoutes()
+	}
+
+	if deps.Cache == nil && cfg.Cache.Size > 0 {
+		deps.Cache = cache.NewLRU(cfg.Cache.Size)
+	}
+
+	if deps.CacheMetrics == nil {
+		deps.CacheMetrics = CacheMetrics
+	}
+
+	if deps.RateLimit == nil {
+		deps.RateLimit = ratelimit.NewMemory()
+	}
+
+	return deps
+}
+
+// getSuccessors returns the successor of each route of the API version that has one, by its path:
+func getSuccessors(routes *registry.Registry, version string) map[string]string {
+	successors := map[string]string{}
+
+	for _, route := range routes.Version(version) {
+		if route.Successor != "" {
+			successors[route.Path] = route.Successor
 		}
+	}
+
+	return successors
+}
 
-		c.Next()
+// getGroupMiddleware returns the middleware of the route group of the API version, i.e., every version before the
+// latest is marked as deprecated, per its configured deprecation policy:
+func getGroupMiddleware(cfg *config.Config, routes *registry.Registry, version string) []gin.HandlerFunc {
+	if !isDeprecatedVersion(version, cfg.APIVersion) {
+		return []gin.HandlerFunc{}
+	}
+
+	policy := cfg.Deprecation[version]
+
+	return []gin.HandlerFunc{
+		middleware.DeprecationMiddleware(middleware.DeprecationOptions{
+			Version:       version,
+			DeprecatedAt:  policy.DeprecatedAt,
+			Sunset:        policy.Sunset,
+			Cutoff:        policy.Cutoff,
+			Successor:     fmt.Sprintf("/api/%v", cfg.APIVersion),
+			Successors:    getSuccessors(routes, version),
+			Documentation: policy.Documentation,
+		}),
 	}
 }
 
-func SetupRouter() *gin.Engine {
-	var version = getAPIVersionFromEnv()
+// SetupRouter returns the router of the full API, with the production dependencies:
+func SetupRouter(cfg *config.Config) *gin.Engine {
+	return New(cfg, Dependencies{})
+}
 
-	mode := os.Getenv("GIN_MODE")
+// The number of trailing characters of the Sentry DSN that are logged unmasked:
+const DSN_VISIBLE_CHARACTERS int = 10
+
+// maskDSN returns the DSN with every character replaced with '*' but the last DSN_VISIBLE_CHARACTERS, or with every
+// character replaced if the DSN is not longer than that:
+func maskDSN(dsn string) string {
+	if len(dsn) <= DSN_VISIBLE_CHARACTERS {
+		return strings.Repeat("*", len(dsn))
+	}
+
+	return strings.Repeat("*", len(dsn)-DSN_VISIBLE_CHARACTERS) + dsn[len(dsn)-DSN_VISIBLE_CHARACTERS:]
+}
+
+// New returns the router of the API, built from the configuration and dependencies, with every route of the registry
+// registered on the route group of its version:
+func New(cfg *config.Config, deps Dependencies) *gin.Engine {
+	var version = cfg.APIVersion
+
+	mode := cfg.Mode
 
 	if mode == "release" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
-	// Create gin router
-	r := gin.Default()
+	deps = deps.withDefaults(cfg)
 
-	// Logging middleware
-	r.Use(gin.Logger())
+	log := deps.Logger
 
-	// Recovery middleware recovers from any panics and writes a 500 if there was one.
-	r.Use(gin.CustomRecovery(func(c *gin.Context, recovered interface{}) {
-		if err, ok := recovered.(string); ok {
-			c.JSON(
-				http.StatusInternalServerError,
-				gin.H{
-					"error": err,
-				},
-			)
-		}
-		c.AbortWithStatus(http.StatusInternalServerError)
-	}))
+	routes := deps.Routes
 
-	// Setup Cross Origin Resource Sharing:
-	config := cors.Config{
-		AllowOrigins: []string{
-			"https://observerly.com",
-			"https://app.observerly.com",
-			"https://vega.observerly.com",
-			"http://localhost:3001",
-		},
-		AllowMethods:     []string{"GET", "OPTIONS"},
-		AllowHeaders:     []string{"Origin"},
-		ExposeHeaders:    []string{"Content-Type", "Content-Length", "Accept-Encoding", "X-CSRF-Token", "Authorization", "accept", "origin", "Cache-Control", "X-Requested-With"},
-		AllowCredentials: true,
-		MaxAge:           24 * time.Hour,
+	// Create gin router (without gin's default plain text logger):
+	r := gin.New()
+
+	// Only trust the X-Forwarded-For headers set by the configured proxies, when determining the client's IP:
+	if err := r.SetTrustedProxies(cfg.RateLimit.TrustedProxies); err != nil {
+		log.Error("Setting trusted proxies failed", "error", err)
 	}
 
-	r.Use(CORSMiddleware(config))
+	// Tracing middleware starts a server span for every request, continuing any trace propagated by the caller (this is
+	// a no-op unless tracing has been setup):
+	r.Use(otelgin.Middleware(cfg.Tracing.ServiceName, otelgin.WithFilter(isTraced)))
+
+	// Computation middleware traces and times every dusk computation of the request, as a child span of its server span:
+	r.Use(middleware.ComputationMiddleware(tracing.Tracer{}))
+
+	// Request ID middleware propagates (or generates) the X-Request-ID of every request:
+	r.Use(middleware.RequestIDMiddleware())
+
+	// Logging middleware writes a structured JSON log record for every request:
+	r.Use(middleware.LoggerMiddleware(log))
+
+	// Metrics middleware records the count and latency of every request (including those that panic):
+	r.Use(middleware.MetricsMiddleware())
+
+	// Recovery middleware recovers from a panic of any value, reports it and writes a 500 with the error envelope:
+	r.Use(middleware.RecoveryMiddleware(log))
+
+	// Setup Cross Origin Resource Sharing:
+	r.Use(middleware.CORSMiddleware(middleware.CORSOptions{
+		AllowOrigins:     cfg.CORS.AllowOrigins,
+		AllowMethods:     cfg.CORS.AllowMethods,
+		AllowHeaders:     cfg.CORS.AllowHeaders,
+		ExposeHeaders:    cfg.CORS.ExposeHeaders,
+		AllowCredentials: cfg.CORS.AllowCredentials,
+		MaxAge:           cfg.CORS.MaxAge,
+	}))
+
+	// Setup Helmet Security Headers, from the configured policy:
+	r.Use(middleware.HelmetMiddleware(middleware.NewHelmetOptions(cfg.Helmet)))
+
+	// Prometheus metrics, registered before the response cache so that the metrics are never cached:
+	r.GET("/metrics", gin.WrapH(metrics.Handler(deps.CacheMetrics)))
+
+	// Liveness and readiness probes, registered before the response cache so that they are never cached:
+	r.GET("/healthz", health.GetLiveness)
+	r.GET("/readyz", health.GetReadiness)
 
-	// Setup Helmet Security Headers:
-	r.Use(middleware.HelmetMiddleware())
+	// Build information, registered before the response cache so that it is never cached:
+	r.GET("/version", func(c *gin.Context) {
+		c.JSON(
+			http.StatusOK,
+			gin.H{
+				"latest":    fmt.Sprintf("/api/%v", version),
+				"commit":    buildinfo.Commit,
+				"buildTime": buildinfo.BuildTime,
+				"go":        buildinfo.GetGoVersion(),
+				"dusk":      buildinfo.GetDuskVersion(),
+			},
+		)
+	})
+
+	// Setup per-client rate limiting, by API key or IP address, after the probes and metrics so they are never limited:
+	if cfg.RateLimit.IsEnabled() {
+		r.Use(middleware.RateLimitMiddleware(deps.RateLimit, getRateLimitOptions(cfg.RateLimit)))
+	}
 
 	// Initialise Sentry if GIN_MODE is release and DSN is set:
-	dsn := os.Getenv("SENTRY_DSN")
+	dsn := cfg.Sentry.DSN
 
-	// If we have a dsn, print out an obfuscated version of it with the last 10 characters replace with '*':
+	// If we have a dsn, print out an obfuscated version of it:
 	if dsn != "" {
-		fmt.Printf("SENTRY_DSN: %v\n", strings.Repeat("*", len(dsn)-10)+dsn[len(dsn)-10:])
+		log.Info("Sentry DSN configured", "dsn", maskDSN(dsn))
 	}
 
 	// If we are in release mode and have a DSN, initialise Sentry:
 	if mode == "release" && dsn != "" {
 		// Make a log that we are initialising Sentry:
-		fmt.Println("Initialising Sentry...")
+		log.Info("Initialising Sentry...")
 
 		// To initialize Sentry's handler, you need to initialize Sentry itself beforehand:
 		if err := sentry.Init(sentry.ClientOptions{
 			Dsn:           dsn,
 			EnableTracing: true,
-			// Set TracesSampleRate to 1.0 to capture 100%
-			// of transactions for performance monitoring.
-			// We recommend adjusting this value in production,
-			TracesSampleRate: 1.0,
+			// The fraction of transactions captured for performance monitoring:
+			TracesSampler: getSentryTracesSampler(cfg),
 		}); err != nil {
-			fmt.Printf("Sentry initialization failed: %v\n", err)
+			log.Error("Sentry initialization failed", "error", err)
 		}
 
-		// Use sentrygin middleware to send errors to Sentry:
-		r.Use(sentrygin.New(sentrygin.Options{}))
+		// Use sentrygin middleware to send errors to Sentry, re-panicking so that the recovery middleware responds:
+		r.Use(sentrygin.New(sentrygin.Options{Repanic: true}))
+
+		// Tag the Sentry events of every request with its request ID:
+		r.Use(middleware.SentryRequestIDMiddleware())
 	}
 
-	r.GET("/version", func(c *gin.Context) {
-		c.JSON(
-			http.StatusOK,
-			gin.H{
-				"latest": fmt.Sprintf("/api/%v", version),
-			},
-		)
-	})
+	// The route group of each version of the API, with its self-describing index, OpenAPI schema and every route of the
+	// version in the registry:
+	for _, v := range API_VERSIONS {
+		group := r.Group(fmt.Sprintf("/api/%v", v), getGroupMiddleware(cfg, routes, v)...)
 
-	// /api/v1 Group w/ Name(v1)
-	r.GET("/api/v1", func(c *gin.Context) {
-		c.JSON(
-			http.StatusOK,
-			gin.H{
-				"description": "Nocturnal 🌑 is observerly's Gin Gonic API for Lunar and Solar advanced scheduling, that utilises Dusk.",
-				"endpoint":    "/api/v1",
-				"name":        "Nocturnal API by observerly",
-			},
-		)
-	})
+		// Setup the response cache within the group, so that cached responses still have the group's headers (where a
+		// cache size of 0 disables the cache):
+		if cfg.Cache.Size > 0 {
+			group.Use(middleware.CacheMiddleware(deps.Cache, middleware.CacheOptions{
+				TTL:                 cfg.Cache.TTL,
+				CoordinatePrecision: cfg.Cache.CoordinatePrecision,
+				DatetimePrecision:   cfg.Cache.DatetimePrecision,
+				Metrics:             deps.CacheMetrics,
+			}))
+		}
 
-	r.GET("/api/v2", func(c *gin.Context) {
-		c.JSON(
-			http.StatusOK,
-			gin.H{
-				"description": "Nocturnal 🌑 is observerly's Gin Gonic API for Lunar, Solar and astronomical advanced scheduling, that utilises Dusk.",
-				"endpoint":    fmt.Sprintf("/api/%v", version),
-				"name":        "Nocturnal API by observerly",
-			},
-		)
-	})
+		group.GET("", GetIndexHandler(routes, v, version))
 
-	// 404 Handler, ensure we are always redirected from api to the latest version of the API:
-	r.NoRoute(func(c *gin.Context) {
-		c.Redirect(http.StatusFound, "/api/v2")
-	})
+		group.GET("/openapi.json", GetOpenAPIHandler(routes, v))
+
+		routes.Register(group, v)
+	}
+
+	// Redirect the bare root and /api paths to the latest version of the API:
+	latest := func(c *gin.Context) {
+		c.Redirect(http.StatusFound, fmt.Sprintf("/api/%v", version))
+	}
+
+	r.GET("/", latest)
+	r.GET("/api", latest)
+
+	// Respond to unknown paths with a 404 (suggesting the closest endpoint), and to unsupported methods with a 405:
+	r.HandleMethodNotAllowed = true
+
+	r.NoRoute(NotFoundHandler(r))
+
+	r.NoMethod(MethodNotAllowedHandler(r))
 
 	return r
 }
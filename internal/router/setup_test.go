@@ -2,17 +2,49 @@ package router
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"runtime"
 	"testing"
 
+	"github.com/getsentry/sentry-go"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/observerly/nocturnal/internal/config"
+	"github.com/observerly/nocturnal/internal/handlers/sun"
+	"github.com/observerly/nocturnal/internal/registry"
 )
 
-// Setup the Gin API router:
-var r = SetupRouter()
+// getTestRoutes returns a registry of a deprecated v1 route and its v2 successor:
+func getTestRoutes() *registry.Registry {
+	routes := registry.New()
+
+	routes.Add(registry.Route{
+		Method:     "GET",
+		Path:       "/api/v1/sun",
+		Version:    "v1",
+		Summary:    "The position and rise and set times of the Sun.",
+		Parameters: registry.GetObserverParameters(),
+		Deprecated: true,
+		Successor:  "/api/v2/sun",
+		Handler:    sun.GetSunDeprecatedV1,
+	}, registry.Route{
+		Method:     "GET",
+		Path:       "/api/v2/sun",
+		Version:    "v2",
+		Summary:    "The position of the Sun at its rise, upper culmination and set.",
+		Parameters: registry.GetObserverParameters(),
+		Handler:    sun.GetSun,
+	})
+
+	return routes
+}
+
+// Setup the Gin API router, with every route of the API:
+var r = SetupRouter(config.Default())
 
 // Setup the base response struct:
 var response map[string]string
@@ -30,10 +62,79 @@ func TestSetupRouter(t *testing.T) {
 }
 
 func TestNoRoute(t *testing.T) {
-	// Perform a GET request with that handler.
-	w := performRequest(r, "GET", "/")
-	// Assert we redirected correctly, the request gives a 302:
-	assert.Equal(t, http.StatusFound, w.Code)
+	for _, path := range []string{"/", "/api"} {
+		// Perform a GET request with that handler.
+		w := performRequest(r, "GET", path)
+		// Assert we redirected correctly, the request gives a 302:
+		assert.Equal(t, http.StatusFound, w.Code)
+		assert.Equal(t, "/api/v2", w.Header().Get("Location"))
+	}
+}
+
+func TestNotFoundRoute(t *testing.T) {
+	w := performRequest(r, "GET", "/api/v2/sn")
+
+	var body map[string]interface{}
+
+	err := json.Unmarshal(w.Body.Bytes(), &body)
+
+	// Assert that an unknown path is a 404, listing the endpoints and suggesting the closest one:
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "no endpoint matches GET /api/v2/sn", body["error"])
+	assert.Contains(t, body["endpoints"], "/api/v2")
+	assert.Contains(t, body["endpoints"], "/healthz")
+	assert.Equal(t, "/api/v2/sun", body["suggestion"])
+	assert.NotEmpty(t, body["requestId"])
+
+	w = performRequest(r, "GET", "/something/else/entirely")
+
+	body = map[string]interface{}{}
+
+	err = json.Unmarshal(w.Body.Bytes(), &body)
+
+	// Assert that no endpoint is suggested when none are close:
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.NotContains(t, body, "suggestion")
+}
+
+func TestMethodNotAllowedRoute(t *testing.T) {
+	w := performRequest(r, "POST", "/api/v2")
+
+	var body map[string]interface{}
+
+	err := json.Unmarshal(w.Body.Bytes(), &body)
+
+	// Assert that an unsupported method is a 405, with an Allow header of the supported methods:
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Equal(t, "GET", w.Header().Get("Allow"))
+	assert.Equal(t, "method POST is not allowed for /api/v2", body["error"])
+
+	// Assert that an unknown path is a 404 regardless of the method:
+	w = performRequest(r, "POST", "/api/v2/unknown")
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestMatchesRoute(t *testing.T) {
+	assert.True(t, matchesRoute("/api/v2/sun", "/api/v2/sun"))
+	assert.True(t, matchesRoute("/api/v2/sun", "/api/v2/sun/"))
+	assert.True(t, matchesRoute("/api/v2/moon/:id", "/api/v2/moon/1"))
+	assert.True(t, matchesRoute("/static/*filepath", "/static/css/main.css"))
+	assert.False(t, matchesRoute("/api/v2/sun", "/api/v2/sun/1"))
+	assert.False(t, matchesRoute("/api/v2/moon/:id", "/api/v2/moon"))
+	assert.False(t, matchesRoute("/api/v2/sun", "/api/v2/moon"))
+}
+
+func TestGetClosestEndpoint(t *testing.T) {
+	endpoints := []string{"/api/v2/moon", "/api/v2/sun", "/api/v2/transit"}
+
+	assert.Equal(t, "/api/v2/moon", getClosestEndpoint(endpoints, "/api/v2/mon"))
+	assert.Equal(t, "/api/v2/sun", getClosestEndpoint(endpoints, "/API/V2/SUN"))
+	assert.Equal(t, "/api/v2/transit", getClosestEndpoint(endpoints, "/api/v2/transits"))
+	assert.Equal(t, "", getClosestEndpoint(endpoints, "/api/v2/occultation"))
 }
 
 func TestVersionRoute(t *testing.T) {
@@ -75,18 +176,20 @@ func TestAPIBaseRoute(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 
 	// Convert the JSON response:
-	err := json.Unmarshal(w.Body.Bytes(), &response)
+	var index map[string]interface{}
+
+	err := json.Unmarshal(w.Body.Bytes(), &index)
 
 	// Grab the description & whether or not it exists
-	description, exists := response["description"]
+	description, exists := index["description"]
 	assert.True(t, exists)
 
 	// Grab the endpoint & whether or not it exists
-	endpoint, exists := response["endpoint"]
+	endpoint, exists := index["endpoint"]
 	assert.True(t, exists)
 
 	// Grab the name & whether or not it exists
-	name, exists := response["name"]
+	name, exists := index["name"]
 	assert.True(t, exists)
 
 	// Assert on the correctness of the response:
@@ -95,3 +198,207 @@ func TestAPIBaseRoute(t *testing.T) {
 	assert.Equal(t, body["endpoint"], endpoint)
 	assert.Equal(t, body["name"], name)
 }
+
+func TestAPIIndexCatalogue(t *testing.T) {
+	r := New(config.Default(), Dependencies{Routes: getTestRoutes()})
+
+	w := performRequest(r, "GET", "/api/v1")
+
+	var index struct {
+		Deprecated bool              `json:"deprecated"`
+		Links      map[string]string `json:"links"`
+		Endpoints  []struct {
+			Method     string               `json:"method"`
+			Path       string               `json:"path"`
+			Parameters []registry.Parameter `json:"parameters"`
+			Deprecated bool                 `json:"deprecated"`
+			Successor  string               `json:"successor"`
+			Links      map[string]string    `json:"links"`
+		} `json:"endpoints"`
+	}
+
+	err := json.Unmarshal(w.Body.Bytes(), &index)
+
+	// Assert that the index enumerates the registered routes of the version, with their parameters and links:
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, index.Deprecated)
+	assert.Equal(t, map[string]string{"self": "/api/v1", "latest": "/api/v2", "openapi": "/api/v1/openapi.json"}, index.Links)
+
+	if assert.Len(t, index.Endpoints, 1) {
+		endpoint := index.Endpoints[0]
+
+		assert.Equal(t, "GET", endpoint.Method)
+		assert.Equal(t, "/api/v1/sun", endpoint.Path)
+		assert.True(t, endpoint.Deprecated)
+		assert.Equal(t, "/api/v2/sun", endpoint.Successor)
+		assert.Equal(t, []string{"datetime", "longitude", "latitude"}, []string{endpoint.Parameters[0].Name, endpoint.Parameters[1].Name, endpoint.Parameters[2].Name})
+		assert.Equal(t, float64(0), endpoint.Parameters[1].Default)
+		assert.Equal(t, "/api/v1/openapi.json#/paths/~1api~1v1~1sun/get", endpoint.Links["schema"])
+	}
+}
+
+func TestOpenAPIRoute(t *testing.T) {
+	r := New(config.Default(), Dependencies{Routes: getTestRoutes()})
+
+	w := performRequest(r, "GET", "/api/v2/openapi.json")
+
+	var schema registry.OpenAPI
+
+	err := json.Unmarshal(w.Body.Bytes(), &schema)
+
+	// Assert that the OpenAPI schema is generated from the registered routes of the version:
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, registry.OPENAPI_VERSION, schema.OpenAPI)
+	assert.Equal(t, "v2", schema.Info.Version)
+	assert.Len(t, schema.Paths, 1)
+	assert.Equal(t, "getApiV2Sun", schema.Paths["/api/v2/sun"]["get"].OperationID)
+
+	// Assert that the registered routes are served:
+	w = performRequest(r, "GET", "/api/v2/sun?latitude=19.798484&longitude=-155.468094")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestMetricsRoute(t *testing.T) {
+	// Perform a GET request with that handler.
+	w := performRequest(r, "GET", "/metrics")
+
+	// Assert we encoded correctly, the request gives a 200:
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Assert that the metrics are never served from the response cache:
+	assert.Empty(t, w.Header().Get("X-Cache"))
+
+	// Assert on the correctness of the response:
+	assert.Contains(t, w.Body.String(), "nocturnal_cache_hits_total")
+}
+
+func TestVersionRouteBuildInfo(t *testing.T) {
+	// Perform a GET request with that handler.
+	w := performRequest(r, "GET", "/version")
+
+	// Assert we encoded correctly, the request gives a 200:
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Convert the JSON response:
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+
+	// Assert on the correctness of the response:
+	assert.Nil(t, err)
+	assert.Equal(t, "unknown", response["commit"])
+	assert.Equal(t, "unknown", response["buildTime"])
+	assert.Equal(t, runtime.Version(), response["go"])
+	assert.Regexp(t, `^v\d+\.\d+\.\d+`, response["dusk"])
+}
+
+func TestHealthRoutes(t *testing.T) {
+	for _, path := range []string{"/healthz", "/readyz"} {
+		// Perform a GET request with that handler.
+		w := performRequest(r, "GET", path)
+
+		// Assert we encoded correctly, the request gives a 200:
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		// Assert that the probes are never served from the response cache:
+		assert.Empty(t, w.Header().Get("X-Cache"))
+	}
+}
+
+func TestPreflightRoute(t *testing.T) {
+	req, _ := http.NewRequest("OPTIONS", "/api/v2/moon", nil)
+	req.Header.Set("Origin", "https://app.observerly.com")
+	req.Header.Set("Access-Control-Request-Method", "GET")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	// Assert that the preflight is answered, rather than redirected:
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "https://app.observerly.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "86400", w.Header().Get("Access-Control-Max-Age"))
+}
+
+func TestRateLimitedRoutes(t *testing.T) {
+	cfg := config.Default()
+
+	cfg.Cache.Size = 0
+	cfg.RateLimit.RequestsPerMinute = 60
+	cfg.RateLimit.Burst = 1
+
+	r := New(cfg, Dependencies{Routes: getTestRoutes()})
+
+	// Assert that the API is rate limited:
+	assert.Equal(t, http.StatusOK, performRequest(r, "GET", "/api/v2").Code)
+	assert.Equal(t, http.StatusTooManyRequests, performRequest(r, "GET", "/api/v2").Code)
+
+	// Assert that the probes are never rate limited:
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusOK, performRequest(r, "GET", "/healthz").Code)
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	req, _ := http.NewRequest("GET", "/api/v2", nil)
+	req.Header.Set("X-Request-ID", "abc-123")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	// Assert that the request ID is propagated to the response:
+	assert.Equal(t, "abc-123", w.Header().Get("X-Request-ID"))
+}
+
+func TestSentryTracesSampler(t *testing.T) {
+	cfg := config.Default()
+
+	cfg.Sentry.TracesSampleRate = 0.25
+
+	sampler := getSentryTracesSampler(cfg)
+
+	server := &sentry.Span{Op: "http.server"}
+
+	// Assert that Sentry samples by its own rate, when it is not an OpenTelemetry exporter:
+	assert.Equal(t, 0.25, sampler(sentry.SamplingContext{Span: server}))
+
+	cfg.Tracing.Exporters = []string{"sentry"}
+
+	// Assert that sentrygin's own transactions are dropped in favour of the (already sampled) OpenTelemetry spans:
+	assert.Equal(t, 0.0, sampler(sentry.SamplingContext{Span: server}))
+	assert.Equal(t, 1.0, sampler(sentry.SamplingContext{Span: &sentry.Span{}}))
+}
+
+func TestMaskDSN(t *testing.T) {
+	assert.Equal(t, "******************.ingest/42", maskDSN("https://key@sentry.ingest/42"))
+
+	// Assert that a DSN no longer than the visible characters is masked entirely (rather than panicking):
+	assert.Equal(t, "*****", maskDSN("short"))
+	assert.Equal(t, "**********", maskDSN("0123456789"))
+}
+
+func TestIsTraced(t *testing.T) {
+	for path, traced := range map[string]bool{"/api/v2/sun": true, "/version": true, "/metrics": false, "/healthz": false, "/readyz": false} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+
+		assert.Equal(t, traced, isTraced(req), path)
+	}
+}
+
+func TestRecoveredPanic(t *testing.T) {
+	r := New(config.Default(), Dependencies{Routes: getTestRoutes()})
+
+	r.GET("/api/v2/panic", func(c *gin.Context) {
+		panic(errors.New("dusk: no solution"))
+	})
+
+	w := performRequest(r, "GET", "/api/v2/panic")
+
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+
+	// Assert that an error panic returns the error envelope, rather than an empty 500:
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "internal server error", response["error"])
+	assert.Equal(t, w.Header().Get("X-Request-ID"), response["requestId"])
+}
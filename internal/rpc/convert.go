@@ -0,0 +1,100 @@
+package rpc
+
+import (
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/observerly/nocturnal/pkg/events"
+	nocturnalv1 "github.com/observerly/nocturnal/pkg/proto/nocturnal/v1"
+)
+
+// The protobuf event type of each type of event of the events model:
+var EVENT_TYPES = map[string]nocturnalv1.EventType{
+	events.RISE:              nocturnalv1.EventType_EVENT_TYPE_RISE,
+	events.MAXIMUM:           nocturnalv1.EventType_EVENT_TYPE_MAXIMUM,
+	events.SET:               nocturnalv1.EventType_EVENT_TYPE_SET,
+	events.CIVIL_DUSK:        nocturnalv1.EventType_EVENT_TYPE_CIVIL_DUSK,
+	events.NAUTICAL_DUSK:     nocturnalv1.EventType_EVENT_TYPE_NAUTICAL_DUSK,
+	events.ASTRONOMICAL_DUSK: nocturnalv1.EventType_EVENT_TYPE_ASTRONOMICAL_DUSK,
+	events.ASTRONOMICAL_DAWN: nocturnalv1.EventType_EVENT_TYPE_ASTRONOMICAL_DAWN,
+	events.NAUTICAL_DAWN:     nocturnalv1.EventType_EVENT_TYPE_NAUTICAL_DAWN,
+	events.CIVIL_DAWN:        nocturnalv1.EventType_EVENT_TYPE_CIVIL_DAWN,
+}
+
+// parseObserver returns the observer's datetime (defaulting to now), longitude, latitude and elevation, returning an
+// InvalidArgument error (as the HTTP API returns a 400) if any is out of range:
+func parseObserver(o *nocturnalv1.Observer) (time.Time, float64, float64, float64, error) {
+	if o == nil {
+		return time.Time{}, 0, 0, 0, status.Error(codes.InvalidArgument, "observer: must be given")
+	}
+
+	datetime := time.Now().UTC()
+
+	if o.Datetime != nil {
+		if err := o.Datetime.CheckValid(); err != nil {
+			return time.Time{}, 0, 0, 0, status.Errorf(codes.InvalidArgument, "observer.datetime: %v", err)
+		}
+
+		datetime = o.Datetime.AsTime()
+	}
+
+	if o.Longitude < -180 || o.Longitude > 180 {
+		return time.Time{}, 0, 0, 0, status.Errorf(codes.InvalidArgument, "observer.longitude: %v must be a number between -180 and 180", o.Longitude)
+	}
+
+	if o.Latitude < -90 || o.Latitude > 90 {
+		return time.Time{}, 0, 0, 0, status.Errorf(codes.InvalidArgument, "observer.latitude: %v must be a number between -90 and 90", o.Latitude)
+	}
+
+	return datetime, o.Longitude, o.Latitude, o.Elevation, nil
+}
+
+// newObserver returns the protobuf observer of the events model's observer:
+func newObserver(o events.Observer) *nocturnalv1.Observer {
+	return &nocturnalv1.Observer{
+		Datetime:  timestamppb.New(o.UTC),
+		Longitude: o.Longitude,
+		Latitude:  o.Latitude,
+		Elevation: o.Elevation,
+		Timezone:  o.Timezone,
+	}
+}
+
+// newHorizontal returns the protobuf horizontal coordinate of the events model's horizontal coordinate:
+func newHorizontal(hz events.Horizontal) *nocturnalv1.HorizontalCoordinate {
+	return &nocturnalv1.HorizontalCoordinate{
+		Altitude: hz.Altitude,
+		Azimuth:  hz.Azimuth,
+	}
+}
+
+// newEquatorial returns the protobuf equatorial coordinate of the events model's equatorial coordinate:
+func newEquatorial(eq events.Equatorial) *nocturnalv1.EquatorialCoordinate {
+	return &nocturnalv1.EquatorialCoordinate{
+		RightAscension: eq.RightAscension,
+		Declination:    eq.Declination,
+	}
+}
+
+// newBody returns the protobuf body of the events model's body, with its events in the same (chronological) order:
+func newBody(b events.Body) *nocturnalv1.Body {
+	evs := make([]*nocturnalv1.Event, 0, len(b.Events))
+
+	for _, e := range b.Events {
+		evs = append(evs, &nocturnalv1.Event{
+			Type:       EVENT_TYPES[e.Type],
+			Time:       timestamppb.New(e.UTC),
+			LocalTime:  e.Local.Format(time.RFC3339),
+			Horizontal: newHorizontal(e.Horizontal),
+			Equatorial: newEquatorial(e.Equatorial),
+		})
+	}
+
+	return &nocturnalv1.Body{
+		Name:   b.Name,
+		Events: evs,
+	}
+}
@@ -0,0 +1,141 @@
+package rpc
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/observerly/dusk/pkg/dusk"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/observerly/nocturnal/pkg/computation"
+	"github.com/observerly/nocturnal/pkg/moon"
+	"github.com/observerly/nocturnal/pkg/planets"
+	nocturnalv1 "github.com/observerly/nocturnal/pkg/proto/nocturnal/v1"
+)
+
+// The default interval between each position of an ephemeris:
+const DEFAULT_EPHEMERIS_INTERVAL = time.Hour
+
+// The default number of positions of an ephemeris:
+const DEFAULT_EPHEMERIS_COUNT uint32 = 24
+
+// The maximum number of positions of an ephemeris, e.g., every minute for a day:
+const MAX_EPHEMERIS_COUNT uint32 = 1440
+
+// position returns the equatorial coordinate of a body at the datetime:
+type position func(datetime time.Time) (dusk.EquatorialCoordinate, error)
+
+// getPosition returns the position of the named body, for the observer at the longitude, latitude and elevation:
+func getPosition(req *nocturnalv1.StreamEphemerisRequest, longitude float64, latitude float64, elevation float64) (position, error) {
+	name := strings.ToLower(req.Body)
+
+	switch name {
+	case "sun":
+		return func(datetime time.Time) (dusk.EquatorialCoordinate, error) {
+			return dusk.GetSolarEquatorialPosition(datetime.UTC()), nil
+		}, nil
+	case "moon":
+		return func(datetime time.Time) (dusk.EquatorialCoordinate, error) {
+			return moon.GetTopocentricLunarEquatorialPosition(datetime, longitude, latitude, elevation), nil
+		}, nil
+	case "target":
+		eq, err := parseTarget(req.Target)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return func(datetime time.Time) (dusk.EquatorialCoordinate, error) {
+			return eq, nil
+		}, nil
+	}
+
+	if _, exists := planets.PLANETS[name]; !exists {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("body: %q must be one of sun, moon, %s or target", req.Body, strings.Join(planets.NAMES, ", ")))
+	}
+
+	return func(datetime time.Time) (dusk.EquatorialCoordinate, error) {
+		return planets.GetPlanetaryEquatorialPosition(name, datetime)
+	}, nil
+}
+
+// StreamEphemeris streams the position of the body in the observer's sky, from the observer's datetime at each
+// interval, until every position is sent or the client cancels the stream:
+func (s *Service) StreamEphemeris(req *nocturnalv1.StreamEphemerisRequest, stream nocturnalv1.NocturnalService_StreamEphemerisServer) error {
+	datetime, longitude, latitude, elevation, err := parseObserver(req.Observer)
+
+	if err != nil {
+		return err
+	}
+
+	get, err := getPosition(req, longitude, latitude, elevation)
+
+	if err != nil {
+		return err
+	}
+
+	interval := DEFAULT_EPHEMERIS_INTERVAL
+
+	if req.Interval != nil {
+		if err := req.Interval.CheckValid(); err != nil {
+			return status.Errorf(codes.InvalidArgument, "interval: %v", err)
+		}
+
+		interval = req.Interval.AsDuration()
+	}
+
+	if interval <= 0 {
+		return status.Errorf(codes.InvalidArgument, "interval: %v must be positive", interval)
+	}
+
+	count := req.Count
+
+	if count == 0 {
+		count = DEFAULT_EPHEMERIS_COUNT
+	}
+
+	if count > MAX_EPHEMERIS_COUNT {
+		return status.Errorf(codes.InvalidArgument, "count: %d must be at most %d", count, MAX_EPHEMERIS_COUNT)
+	}
+
+	for i := uint32(0); i < count; i++ {
+		// Stop computing positions as soon as the client has gone away:
+		if err := stream.Context().Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+
+		at := datetime.Add(time.Duration(i) * interval)
+
+		span := computation.Start(stream.Context(), "ephemeris_position")
+
+		eq, err := get(at)
+
+		if err != nil {
+			span.End()
+			return getComputationError(err)
+		}
+
+		hz := dusk.ConvertEquatorialCoordinateToHorizontal(at.UTC(), longitude, latitude, eq)
+
+		span.End()
+
+		if err := stream.Send(&nocturnalv1.StreamEphemerisResponse{
+			Time: timestamppb.New(at),
+			Horizontal: &nocturnalv1.HorizontalCoordinate{
+				Altitude: hz.Altitude,
+				Azimuth:  hz.Azimuth,
+			},
+			Equatorial: &nocturnalv1.EquatorialCoordinate{
+				RightAscension: eq.RightAscension,
+				Declination:    eq.Declination,
+			},
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"runtime/debug"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	"github.com/observerly/nocturnal/internal/tracing"
+	"github.com/observerly/nocturnal/pkg/computation"
+	nocturnalv1 "github.com/observerly/nocturnal/pkg/proto/nocturnal/v1"
+)
+
+// getLevel returns the level to log a call with the status code at, i.e., server errors as errors:
+func getLevel(code codes.Code) slog.Level {
+	switch code {
+	case codes.OK:
+		return slog.LevelInfo
+	case codes.Internal, codes.Unknown, codes.Unavailable, codes.DataLoss, codes.Unimplemented:
+		return slog.LevelError
+	default:
+		return slog.LevelWarn
+	}
+}
+
+// logCall writes a single structured log record for the call, with its method, latency and status code:
+func logCall(ctx context.Context, logger *slog.Logger, method string, start time.Time, err error) {
+	code := status.Code(err)
+
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("code", code.String()),
+		slog.Float64("latency", time.Since(start).Seconds()),
+	}
+
+	// Correlate the log record with the call's trace, if it is being traced:
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		attrs = append(attrs, slog.String("traceId", span.TraceID().String()), slog.String("spanId", span.SpanID().String()))
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", status.Convert(err).Message()))
+	}
+
+	logger.LogAttrs(ctx, getLevel(code), method, attrs...)
+}
+
+// recoverCall converts a panic in a handler to an Internal error (as the recovery middleware returns a 500), logging
+// the panic with its stack trace:
+func recoverCall(ctx context.Context, logger *slog.Logger, method string, err *error) {
+	if r := recover(); r != nil {
+		logger.ErrorContext(ctx, "Panic recovered", "method", method, "panic", r, "stack", string(debug.Stack()))
+
+		*err = status.Error(codes.Internal, "internal server error")
+	}
+}
+
+// unaryInterceptor logs every unary call, recovers from any panic in its handler, and traces and times every dusk
+// computation of its handler:
+func unaryInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		start := time.Now()
+
+		defer func() {
+			logCall(ctx, logger, info.FullMethod, start, err)
+		}()
+
+		defer recoverCall(ctx, logger, info.FullMethod, &err)
+
+		return handler(computation.WithTracer(ctx, tracing.Tracer{}), req)
+	}
+}
+
+// tracedStream is a server stream whose context carries the computation tracer:
+type tracedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s tracedStream) Context() context.Context {
+	return s.ctx
+}
+
+// streamInterceptor logs every streaming call, recovers from any panic in its handler, and traces and times every
+// dusk computation of its handler:
+func streamInterceptor(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		start := time.Now()
+
+		defer func() {
+			logCall(ss.Context(), logger, info.FullMethod, start, err)
+		}()
+
+		defer recoverCall(ss.Context(), logger, info.FullMethod, &err)
+
+		return handler(srv, tracedStream{ServerStream: ss, ctx: computation.WithTracer(ss.Context(), tracing.Tracer{})})
+	}
+}
+
+// New creates the gRPC server for the NocturnalService, along with the standard health and reflection services (so
+// that e.g., grpcurl can describe the service), logging every call with the logger:
+func New(logger *slog.Logger) *grpc.Server {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryInterceptor(logger)),
+		grpc.ChainStreamInterceptor(streamInterceptor(logger)),
+	)
+
+	nocturnalv1.RegisterNocturnalServiceServer(srv, NewService())
+
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+
+	reflection.Register(srv)
+
+	return srv
+}
+
+// Serve accepts connections on the listener until the context is cancelled (e.g., on SIGTERM), at which point it stops
+// accepting new connections and waits (for up to the shutdown timeout) for in-flight calls to finish, before
+// cancelling any that remain (e.g., a long ephemeris stream).
+func Serve(ctx context.Context, srv *grpc.Server, listener net.Listener, shutdownTimeout time.Duration) error {
+	errs := make(chan error, 1)
+
+	go func() {
+		errs <- srv.Serve(listener)
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	stopped := make(chan struct{})
+
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		srv.Stop()
+	}
+
+	if err := <-errs; err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return err
+	}
+
+	return nil
+}
+
+// ListenAndServe listens on the address, and serves until the context is cancelled (see Serve):
+func ListenAndServe(ctx context.Context, srv *grpc.Server, addr string, shutdownTimeout time.Duration) error {
+	listener, err := net.Listen("tcp", addr)
+
+	if err != nil {
+		return err
+	}
+
+	return Serve(ctx, srv, listener, shutdownTimeout)
+}
@@ -0,0 +1,83 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/exp/slog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/observerly/nocturnal/internal/tracing"
+	"github.com/observerly/nocturnal/pkg/computation"
+)
+
+// fakeStream is a server stream with a background context, for calling a stream interceptor directly:
+type fakeStream struct {
+	grpc.ServerStream
+}
+
+func (fakeStream) Context() context.Context {
+	return context.Background()
+}
+
+func TestGetLevel(t *testing.T) {
+	assert.Equal(t, "INFO", getLevel(codes.OK).String())
+	assert.Equal(t, "WARN", getLevel(codes.InvalidArgument).String())
+	assert.Equal(t, "ERROR", getLevel(codes.Internal).String())
+}
+
+func TestStreamInterceptorTracesComputations(t *testing.T) {
+	var span computation.Span
+
+	err := streamInterceptor(slog.Default())(nil, fakeStream{}, &grpc.StreamServerInfo{FullMethod: "/test"}, func(srv any, ss grpc.ServerStream) error {
+		span = computation.Start(ss.Context(), "test")
+		span.End()
+		return nil
+	})
+
+	assert.Nil(t, err)
+
+	// Assert that the computations of the handler are traced with the tracer of the stream context:
+	assert.IsType(t, &tracing.Computation{}, span)
+}
+
+func TestServeStopsOnCancel(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- Serve(ctx, New(nil), listener, 5*time.Second)
+	}()
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+
+	assert.Nil(t, err)
+
+	defer conn.Close()
+
+	// Assert that the standard health service is served alongside the NocturnalService:
+	res, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, res.Status)
+
+	cancel()
+
+	// Assert that the server shut down cleanly, and no longer accepts connections:
+	assert.Nil(t, <-done)
+
+	_, err = net.Dial("tcp", listener.Addr().String())
+
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,141 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/observerly/dusk/pkg/dusk"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/observerly/nocturnal/pkg/computation"
+	"github.com/observerly/nocturnal/pkg/events"
+	"github.com/observerly/nocturnal/pkg/moon"
+	"github.com/observerly/nocturnal/pkg/observer"
+	nocturnalv1 "github.com/observerly/nocturnal/pkg/proto/nocturnal/v1"
+	"github.com/observerly/nocturnal/pkg/sun"
+	"github.com/observerly/nocturnal/pkg/transit"
+	"github.com/observerly/nocturnal/pkg/twilight"
+)
+
+// compute returns the events of a body for the observer at the datetime, in the local timezone of the location:
+type compute func(ctx context.Context, datetime time.Time, longitude float64, latitude float64, elevation float64, location *time.Location) ([]events.Event, error)
+
+// Service implements the NocturnalService with the same computations as the v3 HTTP API:
+type Service struct {
+	nocturnalv1.UnimplementedNocturnalServiceServer
+}
+
+// NewService creates the NocturnalService:
+func NewService() *Service {
+	return &Service{}
+}
+
+// getComputationError converts the error of a typed function to a status, i.e., InvalidArgument if it is due to an
+// invalid argument, or otherwise Internal:
+func getComputationError(err error) error {
+	if errors.Is(err, computation.ErrInvalidArgument) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
+
+// getEvents returns the observer and the named body with its events, as computed for the observer:
+func getEvents(ctx context.Context, o *nocturnalv1.Observer, name string, get compute) (*nocturnalv1.Observer, *nocturnalv1.Body, error) {
+	datetime, longitude, latitude, elevation, err := parseObserver(o)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	location, err := observer.GetLocation(longitude, latitude)
+
+	if err != nil {
+		return nil, nil, status.Error(codes.Internal, err.Error())
+	}
+
+	evs, err := get(ctx, datetime, longitude, latitude, elevation, location)
+
+	if err != nil {
+		return nil, nil, getComputationError(err)
+	}
+
+	observer := events.NewObserver(datetime, longitude, latitude, elevation, location)
+
+	return newObserver(observer), newBody(events.NewBody(name, evs...)), nil
+}
+
+func (s *Service) GetSun(ctx context.Context, req *nocturnalv1.GetSunRequest) (*nocturnalv1.GetSunResponse, error) {
+	observer, body, err := getEvents(ctx, req.Observer, "sun", func(ctx context.Context, datetime time.Time, longitude float64, latitude float64, _ float64, location *time.Location) ([]events.Event, error) {
+		return sun.GetSolarEvents(ctx, datetime, longitude, latitude, location)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &nocturnalv1.GetSunResponse{Observer: observer, Body: body}, nil
+}
+
+func (s *Service) GetMoon(ctx context.Context, req *nocturnalv1.GetMoonRequest) (*nocturnalv1.GetMoonResponse, error) {
+	observer, body, err := getEvents(ctx, req.Observer, "moon", moon.GetLunarEvents)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &nocturnalv1.GetMoonResponse{Observer: observer, Body: body}, nil
+}
+
+func (s *Service) GetTwilight(ctx context.Context, req *nocturnalv1.GetTwilightRequest) (*nocturnalv1.GetTwilightResponse, error) {
+	observer, body, err := getEvents(ctx, req.Observer, "sun", func(ctx context.Context, datetime time.Time, longitude float64, latitude float64, _ float64, location *time.Location) ([]events.Event, error) {
+		return twilight.GetTwilightEvents(ctx, datetime, longitude, latitude, location)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &nocturnalv1.GetTwilightResponse{Observer: observer, Body: body}, nil
+}
+
+func (s *Service) GetTransit(ctx context.Context, req *nocturnalv1.GetTransitRequest) (*nocturnalv1.GetTransitResponse, error) {
+	eq, err := parseTarget(req.Target)
+
+	if err != nil {
+		return nil, err
+	}
+
+	observer, body, err := getEvents(ctx, req.Observer, "target", func(ctx context.Context, datetime time.Time, longitude float64, latitude float64, _ float64, location *time.Location) ([]events.Event, error) {
+		return transit.GetTransitEvents(ctx, datetime, eq, longitude, latitude, location)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &nocturnalv1.GetTransitResponse{Observer: observer, Body: body}, nil
+}
+
+// parseTarget returns the equatorial coordinate of the target, returning an InvalidArgument error if it is out of
+// range (as for the ra and dec query parameters of the HTTP API):
+func parseTarget(target *nocturnalv1.EquatorialCoordinate) (dusk.EquatorialCoordinate, error) {
+	if target == nil {
+		return dusk.EquatorialCoordinate{}, status.Error(codes.InvalidArgument, "target: must be given")
+	}
+
+	if target.RightAscension < 0 || target.RightAscension >= 360 {
+		return dusk.EquatorialCoordinate{}, status.Error(codes.InvalidArgument, "target.right_ascension: must be a number of degrees between 0 and 360")
+	}
+
+	if target.Declination < -90 || target.Declination > 90 {
+		return dusk.EquatorialCoordinate{}, status.Error(codes.InvalidArgument, "target.declination: must be a number of degrees between -90 and 90")
+	}
+
+	return dusk.EquatorialCoordinate{
+		RightAscension: target.RightAscension,
+		Declination:    target.Declination,
+	}, nil
+}
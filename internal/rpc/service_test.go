@@ -0,0 +1,288 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/observerly/nocturnal/internal/handlers/sun"
+	"github.com/observerly/nocturnal/pkg/events"
+	nocturnalv1 "github.com/observerly/nocturnal/pkg/proto/nocturnal/v1"
+)
+
+var datetime = time.Date(2021, 5, 14, 10, 0, 0, 0, time.UTC)
+
+var maunaKea = &nocturnalv1.Observer{
+	Datetime:  timestamppb.New(datetime),
+	Longitude: -155.468094,
+	Latitude:  19.798484,
+	Elevation: 4205,
+}
+
+// newClient serves the gRPC server over an in-memory listener, returning a client connected to it:
+func newClient(t *testing.T) nocturnalv1.NocturnalServiceClient {
+	listener := bufconn.Listen(1024 * 1024)
+
+	srv := New(nil)
+
+	go func() {
+		_ = srv.Serve(listener)
+	}()
+
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+
+	assert.Nil(t, err)
+
+	t.Cleanup(func() {
+		conn.Close()
+		srv.Stop()
+	})
+
+	return nocturnalv1.NewNocturnalServiceClient(conn)
+}
+
+// getTypes returns the type of each event of the body, in order:
+func getTypes(body *nocturnalv1.Body) []nocturnalv1.EventType {
+	types := []nocturnalv1.EventType{}
+
+	for _, event := range body.Events {
+		types = append(types, event.Type)
+	}
+
+	return types
+}
+
+func TestGetSun(t *testing.T) {
+	client := newClient(t)
+
+	res, err := client.GetSun(context.Background(), &nocturnalv1.GetSunRequest{Observer: maunaKea})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Pacific/Honolulu", res.Observer.Timezone)
+	assert.Equal(t, "sun", res.Body.Name)
+	assert.Equal(t, []nocturnalv1.EventType{
+		nocturnalv1.EventType_EVENT_TYPE_RISE,
+		nocturnalv1.EventType_EVENT_TYPE_MAXIMUM,
+		nocturnalv1.EventType_EVENT_TYPE_SET,
+	}, getTypes(res.Body))
+
+	// Assert that the local time of each event is in the observer's timezone:
+	for _, event := range res.Body.Events {
+		local, err := time.Parse(time.RFC3339, event.LocalTime)
+
+		assert.Nil(t, err)
+		assert.True(t, local.Equal(event.Time.AsTime()))
+
+		_, offset := local.Zone()
+
+		assert.Equal(t, -10*60*60, offset)
+	}
+}
+
+func TestGetSunMirrorsHTTP(t *testing.T) {
+	client := newClient(t)
+
+	res, err := client.GetSun(context.Background(), &nocturnalv1.GetSunRequest{Observer: maunaKea})
+
+	assert.Nil(t, err)
+
+	r := gin.New()
+
+	r.GET("/api/v3/sun", sun.GetSunV3)
+
+	w := httptest.NewRecorder()
+
+	req, _ := http.NewRequest("GET", "/api/v3/sun?datetime=2021-05-14T10:00:00Z&longitude=-155.468094&latitude=19.798484&elevation=4205", nil)
+
+	r.ServeHTTP(w, req)
+
+	var body events.Response
+
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &body))
+
+	// Assert that the gRPC API returns exactly the same events as the HTTP API:
+	assert.Len(t, res.Body.Events, len(body.Bodies[0].Events))
+
+	for i, event := range body.Bodies[0].Events {
+		assert.Equal(t, EVENT_TYPES[event.Type], res.Body.Events[i].Type)
+		assert.True(t, event.UTC.Equal(res.Body.Events[i].Time.AsTime()))
+		assert.Equal(t, event.Horizontal.Altitude, res.Body.Events[i].Horizontal.Altitude)
+		assert.Equal(t, event.Equatorial.RightAscension, res.Body.Events[i].Equatorial.RightAscension)
+	}
+}
+
+func TestGetMoon(t *testing.T) {
+	client := newClient(t)
+
+	res, err := client.GetMoon(context.Background(), &nocturnalv1.GetMoonRequest{Observer: maunaKea})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "moon", res.Body.Name)
+	assert.NotEmpty(t, res.Body.Events)
+
+	// Assert that the events are in chronological order:
+	for i := 1; i < len(res.Body.Events); i++ {
+		assert.False(t, res.Body.Events[i].Time.AsTime().Before(res.Body.Events[i-1].Time.AsTime()))
+	}
+}
+
+func TestGetTwilight(t *testing.T) {
+	client := newClient(t)
+
+	res, err := client.GetTwilight(context.Background(), &nocturnalv1.GetTwilightRequest{Observer: maunaKea})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "sun", res.Body.Name)
+	assert.Equal(t, []nocturnalv1.EventType{
+		nocturnalv1.EventType_EVENT_TYPE_CIVIL_DUSK,
+		nocturnalv1.EventType_EVENT_TYPE_NAUTICAL_DUSK,
+		nocturnalv1.EventType_EVENT_TYPE_ASTRONOMICAL_DUSK,
+		nocturnalv1.EventType_EVENT_TYPE_ASTRONOMICAL_DAWN,
+		nocturnalv1.EventType_EVENT_TYPE_NAUTICAL_DAWN,
+		nocturnalv1.EventType_EVENT_TYPE_CIVIL_DAWN,
+	}, getTypes(res.Body))
+}
+
+func TestGetTransit(t *testing.T) {
+	client := newClient(t)
+
+	// Betelgeuse:
+	target := &nocturnalv1.EquatorialCoordinate{RightAscension: 88.7929583, Declination: 7.4070639}
+
+	res, err := client.GetTransit(context.Background(), &nocturnalv1.GetTransitRequest{Observer: maunaKea, Target: target})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "target", res.Body.Name)
+	assert.Contains(t, getTypes(res.Body), nocturnalv1.EventType_EVENT_TYPE_MAXIMUM)
+
+	for _, event := range res.Body.Events {
+		assert.Equal(t, target.RightAscension, event.Equatorial.RightAscension)
+		assert.Equal(t, target.Declination, event.Equatorial.Declination)
+	}
+}
+
+func TestInvalidArguments(t *testing.T) {
+	client := newClient(t)
+
+	_, err := client.GetSun(context.Background(), &nocturnalv1.GetSunRequest{})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.GetMoon(context.Background(), &nocturnalv1.GetMoonRequest{Observer: &nocturnalv1.Observer{Latitude: 91}})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "observer.latitude")
+
+	_, err = client.GetTransit(context.Background(), &nocturnalv1.GetTransitRequest{
+		Observer: maunaKea,
+		Target:   &nocturnalv1.EquatorialCoordinate{RightAscension: 360},
+	})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "target.right_ascension")
+}
+
+// receive returns every position of the ephemeris stream, along with the error that ended it (if any):
+func receive(stream nocturnalv1.NocturnalService_StreamEphemerisClient) ([]*nocturnalv1.StreamEphemerisResponse, error) {
+	positions := []*nocturnalv1.StreamEphemerisResponse{}
+
+	for {
+		position, err := stream.Recv()
+
+		if err == io.EOF {
+			return positions, nil
+		}
+
+		if err != nil {
+			return positions, err
+		}
+
+		positions = append(positions, position)
+	}
+}
+
+func TestStreamEphemeris(t *testing.T) {
+	client := newClient(t)
+
+	for _, body := range []string{"sun", "moon", "mars"} {
+		stream, err := client.StreamEphemeris(context.Background(), &nocturnalv1.StreamEphemerisRequest{
+			Observer: maunaKea,
+			Body:     body,
+			Interval: durationpb.New(30 * time.Minute),
+			Count:    4,
+		})
+
+		assert.Nil(t, err)
+
+		positions, err := receive(stream)
+
+		// Assert that every position is streamed, at each interval from the observer's datetime:
+		assert.Nil(t, err)
+		assert.Len(t, positions, 4)
+
+		for i, position := range positions {
+			assert.True(t, datetime.Add(time.Duration(i)*30*time.Minute).Equal(position.Time.AsTime()))
+			assert.True(t, position.Horizontal.Altitude >= -90 && position.Horizontal.Altitude <= 90)
+		}
+	}
+}
+
+func TestStreamEphemerisDefaults(t *testing.T) {
+	client := newClient(t)
+
+	stream, err := client.StreamEphemeris(context.Background(), &nocturnalv1.StreamEphemerisRequest{
+		Observer: maunaKea,
+		Body:     "target",
+		Target:   &nocturnalv1.EquatorialCoordinate{RightAscension: 88.7929583, Declination: 7.4070639},
+	})
+
+	assert.Nil(t, err)
+
+	positions, err := receive(stream)
+
+	// Assert that the ephemeris is hourly for a day by default:
+	assert.Nil(t, err)
+	assert.Len(t, positions, int(DEFAULT_EPHEMERIS_COUNT))
+	assert.Equal(t, DEFAULT_EPHEMERIS_INTERVAL, positions[1].Time.AsTime().Sub(positions[0].Time.AsTime()))
+}
+
+func TestStreamEphemerisInvalidArguments(t *testing.T) {
+	client := newClient(t)
+
+	for _, req := range []*nocturnalv1.StreamEphemerisRequest{
+		{Observer: maunaKea, Body: "pluto"},
+		{Observer: maunaKea, Body: "target"},
+		{Observer: maunaKea, Body: "sun", Interval: durationpb.New(-time.Minute)},
+		{Observer: maunaKea, Body: "sun", Count: MAX_EPHEMERIS_COUNT + 1},
+	} {
+		stream, err := client.StreamEphemeris(context.Background(), req)
+
+		assert.Nil(t, err)
+
+		positions, err := receive(stream)
+
+		assert.Empty(t, positions)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+)
+
+type Options struct {
+	// The TCP address to listen on, e.g., ":8103":
+	Addr string
+	// The maximum duration for reading the entire request, including the body:
+	ReadTimeout time.Duration
+	// The maximum duration for reading the request headers:
+	ReadHeaderTimeout time.Duration
+	// The maximum duration before timing out writes of the response:
+	WriteTimeout time.Duration
+	// The maximum duration to wait for the next request when keep-alives are enabled:
+	IdleTimeout time.Duration
+	// The maximum duration to wait for in-flight requests to drain on shutdown:
+	ShutdownTimeout time.Duration
+	// The maximum number of bytes the server will read parsing the request line and headers:
+	MaxHeaderBytes int
+}
+
+// New creates the HTTP server for the handler with the given options:
+func New(handler http.Handler, options Options) *http.Server {
+	return &http.Server{
+		Addr:              options.Addr,
+		Handler:           handler,
+		ReadTimeout:       options.ReadTimeout,
+		ReadHeaderTimeout: options.ReadHeaderTimeout,
+		WriteTimeout:      options.WriteTimeout,
+		IdleTimeout:       options.IdleTimeout,
+		MaxHeaderBytes:    options.MaxHeaderBytes,
+	}
+}
+
+// Serve accepts connections on the listener until the context is cancelled (e.g., on SIGTERM), at which point it stops
+// accepting new connections and waits (for up to the shutdown timeout) for in-flight requests to drain.
+func Serve(ctx context.Context, srv *http.Server, listener net.Listener, shutdownTimeout time.Duration) error {
+	errs := make(chan error, 1)
+
+	go func() {
+		errs <- srv.Serve(listener)
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+
+	if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// ListenAndServe listens on the server's address, and serves until the context is cancelled (see Serve):
+func ListenAndServe(ctx context.Context, srv *http.Server, shutdownTimeout time.Duration) error {
+	listener, err := net.Listen("tcp", srv.Addr)
+
+	if err != nil {
+		return err
+	}
+
+	return Serve(ctx, srv, listener, shutdownTimeout)
+}
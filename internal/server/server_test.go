@@ -0,0 +1,118 @@
+package server
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	srv := New(http.NotFoundHandler(), Options{
+		Addr:              ":8103",
+		ReadTimeout:       time.Second,
+		ReadHeaderTimeout: 2 * time.Second,
+		WriteTimeout:      3 * time.Second,
+		IdleTimeout:       4 * time.Second,
+		MaxHeaderBytes:    1024,
+	})
+
+	assert.Equal(t, ":8103", srv.Addr)
+	assert.Equal(t, time.Second, srv.ReadTimeout)
+	assert.Equal(t, 2*time.Second, srv.ReadHeaderTimeout)
+	assert.Equal(t, 3*time.Second, srv.WriteTimeout)
+	assert.Equal(t, 4*time.Second, srv.IdleTimeout)
+	assert.Equal(t, 1024, srv.MaxHeaderBytes)
+}
+
+func TestServeDrainsInFlightRequests(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+	assert.Nil(t, err)
+
+	started := make(chan struct{})
+
+	srv := New(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		// Simulate a slow (e.g., batch) request that is in-flight when the server is asked to shut down:
+		time.Sleep(200 * time.Millisecond)
+		_, _ = w.Write([]byte("ok"))
+	}), Options{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- Serve(ctx, srv, listener, 5*time.Second)
+	}()
+
+	responses := make(chan string, 1)
+
+	go func() {
+		res, err := http.Get("http://" + listener.Addr().String())
+		if err != nil {
+			responses <- err.Error()
+			return
+		}
+		defer res.Body.Close()
+		body, _ := io.ReadAll(res.Body)
+		responses <- string(body)
+	}()
+
+	<-started
+
+	// Shutdown whilst the request is in-flight:
+	cancel()
+
+	// Assert that the in-flight request completed, and that the server shut down cleanly:
+	assert.Equal(t, "ok", <-responses)
+	assert.Nil(t, <-done)
+
+	// Assert that the server no longer accepts connections:
+	_, err = http.Get("http://" + listener.Addr().String())
+	assert.NotNil(t, err)
+}
+
+func TestServeShutdownTimeout(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+	assert.Nil(t, err)
+
+	started := make(chan struct{})
+
+	release := make(chan struct{})
+
+	srv := New(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	}), Options{})
+
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- Serve(ctx, srv, listener, 50*time.Millisecond)
+	}()
+
+	go func() {
+		res, err := http.Get("http://" + listener.Addr().String())
+		if err == nil {
+			res.Body.Close()
+		}
+	}()
+
+	<-started
+
+	cancel()
+
+	// Assert that the request which fails to drain within the deadline is reported:
+	assert.ErrorIs(t, <-done, context.DeadlineExceeded)
+}
@@ -0,0 +1,125 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	sentryotel "github.com/getsentry/sentry-go/otel"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/observerly/nocturnal/internal/config"
+	"github.com/observerly/nocturnal/internal/metrics"
+	"github.com/observerly/nocturnal/internal/version"
+	"github.com/observerly/nocturnal/pkg/computation"
+)
+
+// The name of the instrumentation library that every computation span is created by:
+const TRACER_NAME string = "github.com/observerly/nocturnal"
+
+// ShutdownFunc flushes any buffered spans to the exporters, and stops the tracer provider:
+type ShutdownFunc func(ctx context.Context) error
+
+// Setup registers the global tracer provider and propagators, exporting sampled spans to each of the configured
+// exporters, and returns the function to flush and stop it. When tracing is disabled, the global no-op tracer provider
+// is kept, so that spans cost next to nothing.
+func Setup(ctx context.Context, cfg config.TracingConfig) (ShutdownFunc, error) {
+	if !cfg.IsEnabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(cfg.ServiceName),
+		semconv.ServiceVersion(version.Commit),
+	))
+
+	if err != nil {
+		return nil, fmt.Errorf("tracing resource: %w", err)
+	}
+
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		// Honour the sampling decision of the caller (e.g., from a traceparent header), otherwise sample by trace ID:
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRate))),
+	}
+
+	propagators := []propagation.TextMapPropagator{
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	}
+
+	if cfg.HasExporter("otlp") {
+		exporterOptions := []otlptracehttp.Option{
+			otlptracehttp.WithEndpoint(cfg.Endpoint),
+		}
+
+		if cfg.Insecure {
+			exporterOptions = append(exporterOptions, otlptracehttp.WithInsecure())
+		}
+
+		exporter, err := otlptracehttp.New(ctx, exporterOptions...)
+
+		if err != nil {
+			return nil, fmt.Errorf("tracing otlp exporter: %w", err)
+		}
+
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+
+	// Sentry receives the sampled spans as transactions, N.B. Sentry itself must also be initialised:
+	if cfg.HasExporter("sentry") {
+		options = append(options, sdktrace.WithSpanProcessor(sentryotel.NewSentrySpanProcessor()))
+
+		propagators = append(propagators, sentryotel.NewSentryPropagator())
+	}
+
+	provider := sdktrace.NewTracerProvider(options...)
+
+	otel.SetTracerProvider(provider)
+
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagators...))
+
+	return provider.Shutdown, nil
+}
+
+// Computation is an in-progress dusk computation, which is both traced as a span and timed in the metrics:
+type Computation struct {
+	span  trace.Span
+	timer *prometheus.Timer
+}
+
+// StartComputation starts a child span of the request's span (e.g., "dusk.solar_rise_set") for the named dusk
+// computation, and starts timing it:
+func StartComputation(ctx context.Context, name string) *Computation {
+	_, span := otel.Tracer(TRACER_NAME).Start(ctx, "dusk."+name, trace.WithAttributes(
+		attribute.String("nocturnal.computation", name),
+	))
+
+	return &Computation{
+		span:  span,
+		timer: metrics.NewComputationTimer(name),
+	}
+}
+
+// End ends the span, and records the duration of the computation:
+func (c *Computation) End() {
+	c.timer.ObserveDuration()
+
+	c.span.End()
+}
+
+// Tracer traces and times the computations of the typed functions (see computation.WithTracer), as for
+// StartComputation:
+type Tracer struct{}
+
+func (Tracer) Start(ctx context.Context, name string) computation.Span {
+	return StartComputation(ctx, name)
+}
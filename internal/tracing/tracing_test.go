@@ -0,0 +1,122 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+
+	"github.com/observerly/nocturnal/internal/config"
+	"github.com/observerly/nocturnal/pkg/computation"
+)
+
+// collector is a stand-in for an OTLP/HTTP collector, which records the body of every export request:
+type collector struct {
+	mu     sync.Mutex
+	bodies []string
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	if r.Method == http.MethodPost && r.URL.Path == "/v1/traces" {
+		c.mu.Lock()
+		c.bodies = append(c.bodies, string(body))
+		c.mu.Unlock()
+	}
+
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (c *collector) Received() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return strings.Join(c.bodies, "")
+}
+
+func TestSetupDisabled(t *testing.T) {
+	shutdown, err := Setup(context.Background(), config.Default().Tracing)
+
+	assert.Nil(t, err)
+	assert.Nil(t, shutdown(context.Background()))
+
+	// Assert that computations can still be started and ended without a tracer provider:
+	StartComputation(context.Background(), "solar_rise_set").End()
+}
+
+func TestSetupOTLPExporter(t *testing.T) {
+	c := &collector{}
+
+	server := httptest.NewServer(c)
+
+	defer server.Close()
+
+	cfg := config.Default().Tracing
+
+	cfg.Exporters = []string{"otlp"}
+	cfg.Endpoint = strings.TrimPrefix(server.URL, "http://")
+	cfg.Insecure = true
+	cfg.SampleRate = 1
+
+	shutdown, err := Setup(context.Background(), cfg)
+
+	assert.Nil(t, err)
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "GET /api/v2/sun")
+
+	StartComputation(ctx, "solar_rise_set").End()
+
+	// Start a computation as the typed functions do, with the tracer in the context:
+	computation.Start(computation.WithTracer(ctx, Tracer{}), "lunar_rise_set").End()
+
+	parent.End()
+
+	// Assert that shutting down flushes the spans to the collector:
+	assert.Nil(t, shutdown(context.Background()))
+
+	received := c.Received()
+
+	assert.Contains(t, received, "GET /api/v2/sun")
+	assert.Contains(t, received, "dusk.solar_rise_set")
+	assert.Contains(t, received, "dusk.lunar_rise_set")
+	assert.Contains(t, received, "nocturnal.computation")
+	assert.Contains(t, received, "nocturnal")
+}
+
+func TestSetupSampleRate(t *testing.T) {
+	c := &collector{}
+
+	server := httptest.NewServer(c)
+
+	defer server.Close()
+
+	cfg := config.Default().Tracing
+
+	cfg.Exporters = []string{"otlp"}
+	cfg.Endpoint = strings.TrimPrefix(server.URL, "http://")
+	cfg.Insecure = true
+	cfg.SampleRate = 0
+
+	shutdown, err := Setup(context.Background(), cfg)
+
+	assert.Nil(t, err)
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "GET /api/v2/moon")
+
+	StartComputation(ctx, "lunar_rise_set").End()
+
+	parent.End()
+
+	assert.Nil(t, shutdown(context.Background()))
+
+	// Assert that no spans are exported when none are sampled:
+	assert.Empty(t, c.Received())
+}
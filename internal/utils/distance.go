@@ -0,0 +1,42 @@
+package utils
+
+// GetLevenshteinDistance returns the minimum number of single character insertions, deletions or substitutions to
+// change a into b, e.g., 1 for "/api/v2/mon" and "/api/v2/moon":
+func GetLevenshteinDistance(a string, b string) int {
+	s, t := []rune(a), []rune(b)
+
+	// The distances from the prefix of s to each prefix of t, for the previous and current rows:
+	previous := make([]int, len(t)+1)
+
+	current := make([]int, len(t)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+
+			current[j] = previous[j] + 1
+
+			if current[j-1]+1 < current[j] {
+				current[j] = current[j-1] + 1
+			}
+
+			if previous[j-1]+cost < current[j] {
+				current[j] = previous[j-1] + cost
+			}
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(t)]
+}
@@ -0,0 +1,17 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLevenshteinDistance(t *testing.T) {
+	assert.Equal(t, 0, GetLevenshteinDistance("/api/v2/moon", "/api/v2/moon"))
+	assert.Equal(t, 1, GetLevenshteinDistance("/api/v2/mon", "/api/v2/moon"))
+	assert.Equal(t, 1, GetLevenshteinDistance("/api/v2/sum", "/api/v2/sun"))
+	assert.Equal(t, 2, GetLevenshteinDistance("/api/v2/snu", "/api/v2/sun"))
+	assert.Equal(t, 3, GetLevenshteinDistance("kitten", "sitting"))
+	assert.Equal(t, 7, GetLevenshteinDistance("", "/api/v2"))
+	assert.Equal(t, 7, GetLevenshteinDistance("/api/v2", ""))
+}
@@ -0,0 +1,52 @@
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// The module path of dusk, the astronomical library that performs every computation:
+const DUSK_MODULE_PATH string = "github.com/observerly/dusk"
+
+// The git commit that the binary was built from, embedded at build time, e.g.,
+// go build -ldflags "-X github.com/observerly/nocturnal/internal/version.Commit=$(git rev-parse HEAD)":
+var Commit = "unknown"
+
+// The time (in RFC3339 format) at which the binary was built, embedded at build time, e.g.,
+// go build -ldflags "-X github.com/observerly/nocturnal/internal/version.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)":
+var BuildTime = "unknown"
+
+// GetGoVersion returns the version of Go that the binary was built with:
+func GetGoVersion() string {
+	return runtime.Version()
+}
+
+// GetModuleVersion returns the version of the given module dependency that is embedded in the binary, or "unknown"
+// if the build information is not available (or the module is not a dependency):
+func GetModuleVersion(path string) string {
+	info, ok := debug.ReadBuildInfo()
+
+	if !ok {
+		return "unknown"
+	}
+
+	for _, dep := range info.Deps {
+		if dep.Path != path {
+			continue
+		}
+
+		// Prefer the version of any module replacement:
+		if dep.Replace != nil {
+			return dep.Replace.Version
+		}
+
+		return dep.Version
+	}
+
+	return "unknown"
+}
+
+// GetDuskVersion returns the version of the dusk module that is embedded in the binary:
+func GetDuskVersion() string {
+	return GetModuleVersion(DUSK_MODULE_PATH)
+}
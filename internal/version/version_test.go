@@ -0,0 +1,23 @@
+package version
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	// Link dusk into the test binary, so that its version is embedded in the build information:
+	_ "github.com/observerly/dusk/pkg/dusk"
+)
+
+func TestGetGoVersion(t *testing.T) {
+	assert.Equal(t, runtime.Version(), GetGoVersion())
+}
+
+func TestGetDuskVersion(t *testing.T) {
+	assert.Regexp(t, `^v\d+\.\d+\.\d+`, GetDuskVersion())
+}
+
+func TestGetModuleVersionUnknown(t *testing.T) {
+	assert.Equal(t, "unknown", GetModuleVersion("github.com/observerly/unknown"))
+}
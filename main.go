@@ -1,54 +1,49 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
-	"github.com/observerly/nocturnal/internal/router"
-	"github.com/observerly/nocturnal/pkg/moon"
-	"github.com/observerly/nocturnal/pkg/sun"
-	"github.com/observerly/nocturnal/pkg/transit"
-	"github.com/observerly/nocturnal/pkg/twilight"
+	"golang.org/x/exp/slog"
+
+	"github.com/observerly/nocturnal/internal/app"
+	"github.com/observerly/nocturnal/internal/config"
+	logging "github.com/observerly/nocturnal/internal/logger"
 )
 
 var (
-	port = flag.String("port", ":8103", "Port to listen on. Default is 8103.")
+	port = flag.String("port", "", "Port to listen on, e.g., :8103. Overrides the configured port.")
+
+	file = flag.String("config", os.Getenv("CONFIG_FILE"), "Path to a YAML config file. Default is the CONFIG_FILE environment variable.")
 )
 
 func main() {
 	// Parse command-line flags
 	flag.Parse()
 
-	r := router.SetupRouter()
-
-	// Moon (Lunar) Properties API version 1 (deprecated):
-	r.GET("/api/v1/moon", moon.GetMoonDeprecatedV1)
-	r.GET("/api/v1/lunar", moon.GetMoonDeprecatedV1)
-
-	// Moon (Lunar) Properties API version 2 (^14.02.2023):
-	r.GET("/api/v2/moon", moon.GetMoon)
-	r.GET("/api/v2/lunar", moon.GetMoon)
-
-	// Sun (Solar) Properties API
-	r.GET("/api/v1/sun", sun.GetSunDeprecatedV1)
-	r.GET("/api/v1/solar", sun.GetSunDeprecatedV1)
+	// Load the configuration from the config file (if any) and the environment:
+	cfg, err := config.Load(*file)
 
-	// Sun (Solar) Properties API version 2 (^14.02.2023):
-	r.GET("/api/v2/sun", sun.GetSun)
-	r.GET("/api/v2/solar", sun.GetSun)
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v\n", err)
+	}
 
-	// Transit Properties API
-	r.GET("/api/v1/transit", transit.GetTransitDeprecatedV1)
+	// Create the structured JSON logger, and use it for any remaining standard library logs:
+	logger, _ := logging.NewFromLevel(os.Stdout, cfg.Log.Level)
 
-	// Transit Properties API version 2 (^02.03.2023):
-	r.GET("/api/v2/transit", transit.GetTransit)
+	slog.SetDefault(logger)
 
-	// Twilight (Crepusculum) Properties API
-	r.GET("/api/v1/twilight", twilight.GetTwilight)
+	// Drain in-flight requests on SIGTERM (e.g., from Cloud Run) or SIGINT, before exiting:
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 
-	// Twilight (Crepusculum) Properties API version 2 (^02.03.2023):
-	r.GET("/api/v2/twilight", twilight.GetTwilight)
+	defer stop()
 
-	// Listen on port
-	log.Fatal(r.Run(*port))
+	if err := app.Run(ctx, cfg, logger, *port); err != nil {
+		logger.Error("Server failed", "error", err)
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,106 @@
+package catalogue
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/observerly/dusk/pkg/dusk"
+
+	"github.com/observerly/nocturnal/pkg/computation"
+)
+
+// The equatorial coordinates of the brightest stars, and of the bright stars near the ecliptic that the Moon can
+// occult, referred to the equinox and epoch of J2000 (from the Hipparcos catalogue, in degrees):
+var STARS = map[string]dusk.EquatorialCoordinate{
+	"achernar":      {RightAscension: 24.428523, Declination: -57.236753},
+	"acrux":         {RightAscension: 186.649563, Declination: -63.099093},
+	"alcyone":       {RightAscension: 56.871152, Declination: 24.105136},
+	"aldebaran":     {RightAscension: 68.980163, Declination: 16.509302},
+	"altair":        {RightAscension: 297.695827, Declination: 8.868321},
+	"antares":       {RightAscension: 247.351915, Declination: -26.432003},
+	"arcturus":      {RightAscension: 213.915300, Declination: 19.182409},
+	"bellatrix":     {RightAscension: 81.282764, Declination: 6.349703},
+	"betelgeuse":    {RightAscension: 88.792939, Declination: 7.407064},
+	"canopus":       {RightAscension: 95.987958, Declination: -52.695661},
+	"capella":       {RightAscension: 79.172328, Declination: 45.997991},
+	"castor":        {RightAscension: 113.649428, Declination: 31.888276},
+	"deneb":         {RightAscension: 310.357980, Declination: 45.280339},
+	"elnath":        {RightAscension: 81.572971, Declination: 28.607452},
+	"fomalhaut":     {RightAscension: 344.412693, Declination: -29.622237},
+	"hadar":         {RightAscension: 210.955856, Declination: -60.373035},
+	"mimosa":        {RightAscension: 191.930263, Declination: -59.688764},
+	"nunki":         {RightAscension: 283.816360, Declination: -26.296722},
+	"polaris":       {RightAscension: 37.954561, Declination: 89.264109},
+	"pollux":        {RightAscension: 116.328958, Declination: 28.026199},
+	"procyon":       {RightAscension: 114.825498, Declination: 5.224988},
+	"regulus":       {RightAscension: 152.092962, Declination: 11.967209},
+	"rigel":         {RightAscension: 78.634467, Declination: -8.201638},
+	"sirius":        {RightAscension: 101.287155, Declination: -16.716116},
+	"spica":         {RightAscension: 201.298247, Declination: -11.161319},
+	"vega":          {RightAscension: 279.234735, Declination: 38.783689},
+	"zubenelgenubi": {RightAscension: 222.719638, Declination: -16.041777},
+}
+
+// GetNames returns the names of every star in the catalogue, in alphabetical order:
+func GetNames() []string {
+	names := make([]string, 0, len(STARS))
+
+	for name := range STARS {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Precess returns the equatorial coordinate (referred to the equinox of J2000) precessed to the equinox of the
+// datetime, which accounts for the ~50 arcseconds per year that the equinox moves against the stars.
+// @see Chapter 21 of Meeus, J. 1998. Astronomical Algorithms. 2nd ed. Willmann-Bell.
+func Precess(eq dusk.EquatorialCoordinate, datetime time.Time) dusk.EquatorialCoordinate {
+	// The number of Julian centuries since the J2000 epoch:
+	t := (dusk.GetJulianDate(datetime.UTC()) - 2451545.0) / 36525
+
+	// The precessional angles (converted from arcseconds to radians):
+	ζ := (2306.2181*t + 0.30188*t*t + 0.017998*t*t*t) / 3600 * math.Pi / 180
+
+	z := (2306.2181*t + 1.09468*t*t + 0.018203*t*t*t) / 3600 * math.Pi / 180
+
+	θ := (2004.3109*t - 0.42665*t*t - 0.041833*t*t*t) / 3600 * math.Pi / 180
+
+	α0 := eq.RightAscension * math.Pi / 180
+
+	δ0 := eq.Declination * math.Pi / 180
+
+	A := math.Cos(δ0) * math.Sin(α0+ζ)
+
+	B := math.Cos(θ)*math.Cos(δ0)*math.Cos(α0+ζ) - math.Sin(θ)*math.Sin(δ0)
+
+	C := math.Sin(θ)*math.Cos(δ0)*math.Cos(α0+ζ) + math.Cos(θ)*math.Sin(δ0)
+
+	ra := math.Mod((math.Atan2(A, B)+z)*180/math.Pi, 360)
+
+	// Correct for negative angles:
+	if ra < 0 {
+		ra += 360
+	}
+
+	return dusk.EquatorialCoordinate{
+		RightAscension: ra,
+		Declination:    math.Asin(C) * 180 / math.Pi,
+	}
+}
+
+// GetEquatorialPosition returns the equatorial coordinate of the named star (case-insensitive), referred to the
+// equinox of the datetime:
+func GetEquatorialPosition(name string, datetime time.Time) (dusk.EquatorialCoordinate, error) {
+	eq, exists := STARS[strings.ToLower(strings.TrimSpace(name))]
+
+	if !exists {
+		return dusk.EquatorialCoordinate{}, computation.InvalidArgument("star: %q must be one of %s", name, strings.Join(GetNames(), ", "))
+	}
+
+	return Precess(eq, datetime), nil
+}
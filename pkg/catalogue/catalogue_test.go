@@ -0,0 +1,46 @@
+package catalogue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/observerly/dusk/pkg/dusk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrecess(t *testing.T) {
+	// θ Persei (corrected for proper motion) precessed to 2028 November 13.19 TD, per Example 21.b of Meeus (1998):
+	eq := Precess(dusk.EquatorialCoordinate{RightAscension: 41.054063, Declination: 49.227750}, time.Date(2028, 11, 13, 4, 33, 36, 0, time.UTC))
+
+	assert.InDelta(t, 41.547214, eq.RightAscension, 0.0001)
+	assert.InDelta(t, 49.348483, eq.Declination, 0.0001)
+}
+
+func TestPrecessJ2000(t *testing.T) {
+	eq := Precess(STARS["regulus"], time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	// Assert that a coordinate is unchanged at the J2000 epoch itself:
+	assert.InDelta(t, STARS["regulus"].RightAscension, eq.RightAscension, 1e-9)
+	assert.InDelta(t, STARS["regulus"].Declination, eq.Declination, 1e-9)
+}
+
+func TestGetEquatorialPosition(t *testing.T) {
+	eq, err := GetEquatorialPosition(" Aldebaran ", time.Date(2021, 5, 14, 0, 0, 0, 0, time.UTC))
+
+	// Assert that the name is case-insensitive, and that Aldebaran has precessed ~0.3° eastwards since J2000:
+	assert.Nil(t, err)
+	assert.InDelta(t, 69.28, eq.RightAscension, 0.01)
+	assert.InDelta(t, 16.55, eq.Declination, 0.01)
+
+	_, err = GetEquatorialPosition("vulcan", time.Now())
+
+	assert.ErrorContains(t, err, `star: "vulcan" must be one of achernar, acrux`)
+}
+
+func TestGetNames(t *testing.T) {
+	names := GetNames()
+
+	assert.Len(t, names, len(STARS))
+	assert.Equal(t, "achernar", names[0])
+	assert.Equal(t, "zubenelgenubi", names[len(names)-1])
+}
@@ -0,0 +1,284 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The base URL of the public Nocturnal API:
+const DEFAULT_BASE_URL string = "https://nocturnal.observerly.com"
+
+// The maximum number of times a failed request is retried (after the first attempt):
+const DEFAULT_MAX_RETRIES int = 3
+
+// The delay before the first retry, doubled for each subsequent retry:
+const DEFAULT_BACKOFF time.Duration = 250 * time.Millisecond
+
+// The maximum delay between retries, including any delay requested by the server's Retry-After header:
+const MAX_BACKOFF time.Duration = 30 * time.Second
+
+// The header that identifies the client's rate limit tier (see rateLimit.apiKeyHeader):
+const API_KEY_HEADER string = "X-API-Key"
+
+// Error is the error envelope of a response with a non-2xx status:
+type Error struct {
+	// The HTTP status code of the response, e.g., 400:
+	StatusCode int `json:"-"`
+	// The error message:
+	Message string `json:"error"`
+	// The ID of the request, for correlating with the server's logs:
+	RequestID string `json:"requestId"`
+	// The delay requested by the server before retrying, e.g., for a 429 (if any):
+	RetryAfter time.Duration `json:"-"`
+}
+
+func (e *Error) Error() string {
+	if e.RequestID == "" {
+		return fmt.Sprintf("nocturnal: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+	}
+
+	return fmt.Sprintf("nocturnal: %d %s: %s (request %s)", e.StatusCode, http.StatusText(e.StatusCode), e.Message, e.RequestID)
+}
+
+// Client is a typed client of the Nocturnal API:
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	apiKey     string
+	maxRetries int
+	backoff    time.Duration
+}
+
+// Option configures the client:
+type Option func(*Client) error
+
+// WithBaseURL sets the base URL of the API, e.g., "http://localhost:8103" (defaulting to DEFAULT_BASE_URL):
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) error {
+		u, err := url.Parse(baseURL)
+
+		if err != nil {
+			return fmt.Errorf("base URL: %w", err)
+		}
+
+		if u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("base URL: %q must be an absolute URL", baseURL)
+		}
+
+		c.baseURL = u
+
+		return nil
+	}
+}
+
+// WithHTTPClient sets the HTTP client that requests are sent with, e.g., with a timeout or custom transport (defaulting
+// to http.DefaultClient):
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) error {
+		if httpClient == nil {
+			return errors.New("http client: must not be nil")
+		}
+
+		c.httpClient = httpClient
+
+		return nil
+	}
+}
+
+// WithAPIKey sets the API key that identifies the client's rate limit tier:
+func WithAPIKey(apiKey string) Option {
+	return func(c *Client) error {
+		c.apiKey = apiKey
+
+		return nil
+	}
+}
+
+// WithRetries sets the maximum number of retries of a failed request (0 disables retries), and the delay before the
+// first retry, which is doubled for each subsequent retry:
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) error {
+		if maxRetries < 0 {
+			return fmt.Errorf("retries: %d must not be negative", maxRetries)
+		}
+
+		if backoff < 0 {
+			return fmt.Errorf("backoff: %v must not be negative", backoff)
+		}
+
+		c.maxRetries = maxRetries
+
+		c.backoff = backoff
+
+		return nil
+	}
+}
+
+// New creates a client of the API, configured with the options:
+func New(options ...Option) (*Client, error) {
+	c := &Client{
+		httpClient: http.DefaultClient,
+		maxRetries: DEFAULT_MAX_RETRIES,
+		backoff:    DEFAULT_BACKOFF,
+	}
+
+	if err := WithBaseURL(DEFAULT_BASE_URL)(c); err != nil {
+		return nil, err
+	}
+
+	for _, option := range options {
+		if err := option(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// isRetryable determines whether a request that failed with the status code may succeed if retried, i.e., when the
+// client is rate limited, or the server (or a proxy in front of it) is temporarily unavailable:
+func isRetryable(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// getBackoff returns the delay before the given retry (from 1), doubling from the initial backoff, with up to 50% of
+// jitter so that many clients do not retry in lockstep:
+func (c *Client) getBackoff(retry int, err error) time.Duration {
+	var e *Error
+
+	// Defer to the delay requested by the server, if any:
+	if errors.As(err, &e) && e.RetryAfter > 0 {
+		if e.RetryAfter > MAX_BACKOFF {
+			return MAX_BACKOFF
+		}
+
+		return e.RetryAfter
+	}
+
+	backoff := c.backoff << (retry - 1)
+
+	if backoff > MAX_BACKOFF || backoff <= 0 {
+		backoff = MAX_BACKOFF
+	}
+
+	if backoff < 2 {
+		return backoff
+	}
+
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)))
+}
+
+// getRetryAfter returns the delay requested by the Retry-After header (in seconds) of the response, if any:
+func getRetryAfter(res *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(res.Header.Get("Retry-After"))
+
+	if err != nil || seconds < 0 {
+		return 0
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// newError returns the error of a response with a non-2xx status, from its error envelope (if any):
+func newError(res *http.Response) error {
+	e := &Error{
+		StatusCode: res.StatusCode,
+		RetryAfter: getRetryAfter(res),
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 1<<16))
+
+	if err := json.Unmarshal(body, e); err != nil || e.Message == "" {
+		e.Message = strings.TrimSpace(string(body))
+	}
+
+	return e
+}
+
+// send sends a single GET request for the path and query, decoding a successful response into v:
+func (c *Client) send(ctx context.Context, path string, query url.Values, v any) error {
+	u := c.baseURL.JoinPath(path)
+
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if c.apiKey != "" {
+		req.Header.Set(API_KEY_HEADER, c.apiKey)
+	}
+
+	res, err := c.httpClient.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return newError(res)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(v); err != nil {
+		return fmt.Errorf("nocturnal: decoding the response of %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// get sends a GET request for the path and query, retrying (with backoff) any request that fails with a retryable
+// status or a network error, until the maximum number of retries or the context is done:
+func (c *Client) get(ctx context.Context, path string, query url.Values, v any) error {
+	for retry := 0; ; retry++ {
+		err := c.send(ctx, path, query, v)
+
+		if err == nil {
+			return nil
+		}
+
+		// Never retry once the caller has given up:
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		var e *Error
+
+		var ne net.Error
+
+		retryable := (errors.As(err, &e) && isRetryable(e.StatusCode)) || errors.As(err, &ne)
+
+		if !retryable || retry >= c.maxRetries {
+			return err
+		}
+
+		timer := time.NewTimer(c.getBackoff(retry+1, err))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
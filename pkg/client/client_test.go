@@ -0,0 +1,326 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/observerly/dusk/pkg/dusk"
+	"github.com/observerly/nocturnal/internal/config"
+	"github.com/observerly/nocturnal/internal/router"
+	"github.com/observerly/nocturnal/pkg/moon"
+	"github.com/observerly/nocturnal/pkg/observer"
+	"github.com/observerly/nocturnal/pkg/occultation"
+	"github.com/observerly/nocturnal/pkg/sun"
+	"github.com/observerly/nocturnal/pkg/transit"
+	"github.com/observerly/nocturnal/pkg/twilight"
+	"github.com/stretchr/testify/assert"
+)
+
+var o = observer.New(time.Date(2021, 5, 14, 0, 0, 0, 0, time.UTC), -155.468094, 19.798484, 4205)
+
+var betelgeuse = dusk.EquatorialCoordinate{RightAscension: 88.792958, Declination: 7.407064}
+
+// newTestClient returns a client of an httptest server running the real router of the API, with the options:
+func newTestClient(t *testing.T, options ...Option) *Client {
+	cfg := config.Default()
+
+	// Disable the response cache, which quantises the observer's coordinates and datetime:
+	cfg.Cache.Size = 0
+
+	srv := httptest.NewServer(router.New(cfg, router.Dependencies{}))
+
+	t.Cleanup(srv.Close)
+
+	c, err := New(append([]Option{WithBaseURL(srv.URL), WithHTTPClient(srv.Client())}, options...)...)
+
+	assert.Nil(t, err)
+
+	return c
+}
+
+// assertJSONEq asserts that the client's response marshals to exactly the JSON of the computed result:
+func assertJSONEq(t *testing.T, expected any, actual any) {
+	e, _ := json.Marshal(expected)
+
+	a, _ := json.Marshal(actual)
+
+	assert.JSONEq(t, string(e), string(a))
+}
+
+func TestNew(t *testing.T) {
+	c, err := New()
+
+	// Assert that the client defaults to the public API:
+	assert.Nil(t, err)
+	assert.Equal(t, DEFAULT_BASE_URL, c.baseURL.String())
+	assert.Equal(t, http.DefaultClient, c.httpClient)
+	assert.Equal(t, DEFAULT_MAX_RETRIES, c.maxRetries)
+
+	_, err = New(WithBaseURL("localhost:8103"))
+
+	// Assert that a relative base URL is rejected:
+	assert.NotNil(t, err)
+
+	_, err = New(WithHTTPClient(nil))
+
+	assert.NotNil(t, err)
+
+	_, err = New(WithRetries(-1, time.Second))
+
+	assert.NotNil(t, err)
+}
+
+func TestGetSun(t *testing.T) {
+	c := newTestClient(t)
+
+	res, err := c.GetSun(context.Background(), o)
+
+	assert.Nil(t, err)
+
+	expected, _ := sun.GetSolarTransit(context.Background(), o)
+
+	// Assert that the client returns exactly the server's computed result:
+	assertJSONEq(t, expected, res)
+	assert.Equal(t, o.Longitude, res.Observer.Longitude)
+}
+
+func TestGetMoon(t *testing.T) {
+	c := newTestClient(t)
+
+	res, err := c.GetMoon(context.Background(), o)
+
+	assert.Nil(t, err)
+
+	expected, _ := moon.GetLunarTransit(context.Background(), o)
+
+	assertJSONEq(t, expected, res)
+
+	res, err = c.SearchMoon(context.Background(), o, 7)
+
+	assert.Nil(t, err)
+
+	expected, _ = moon.SearchLunarTransit(context.Background(), o, 7)
+
+	assertJSONEq(t, expected, res)
+}
+
+func TestGetMoonLibration(t *testing.T) {
+	c := newTestClient(t)
+
+	res, err := c.GetMoonLibration(context.Background(), o)
+
+	assert.Nil(t, err)
+
+	assertJSONEq(t, moon.GetLibration(context.Background(), o), res)
+}
+
+func TestGetTransit(t *testing.T) {
+	c := newTestClient(t)
+
+	res, err := c.GetTransit(context.Background(), o, betelgeuse)
+
+	assert.Nil(t, err)
+
+	expected, _ := transit.GetObjectTransit(context.Background(), o, betelgeuse)
+
+	assertJSONEq(t, expected, res)
+	assert.NotEmpty(t, res.Path)
+}
+
+func TestGetOccultations(t *testing.T) {
+	c := newTestClient(t)
+
+	res, err := c.GetOccultations(context.Background(), o, betelgeuse, 7)
+
+	assert.Nil(t, err)
+
+	expected, _ := occultation.GetOccultations(context.Background(), o, betelgeuse, 7)
+
+	assertJSONEq(t, expected, res)
+
+	_, err = c.GetOccultations(context.Background(), o, betelgeuse, 32)
+
+	var e *Error
+
+	// Assert that an invalid parameter is returned as the server's error envelope:
+	assert.True(t, errors.As(err, &e))
+	assert.Equal(t, http.StatusBadRequest, e.StatusCode)
+	assert.NotEmpty(t, e.Message)
+	assert.NotEmpty(t, e.RequestID)
+}
+
+func TestGetNamedOccultations(t *testing.T) {
+	c := newTestClient(t)
+
+	res, err := c.GetNamedOccultations(context.Background(), o, "aldebaran", 7)
+
+	assert.Nil(t, err)
+
+	expected, _ := occultation.GetNamedOccultations(context.Background(), o, "aldebaran", 7)
+
+	assertJSONEq(t, expected, res)
+	assert.Equal(t, "aldebaran", res.Target.Name)
+
+	_, err = c.GetNamedOccultations(context.Background(), o, "vulcan", 7)
+
+	var e *Error
+
+	assert.True(t, errors.As(err, &e))
+	assert.Equal(t, http.StatusBadRequest, e.StatusCode)
+}
+
+func TestGetTwilight(t *testing.T) {
+	c := newTestClient(t)
+
+	res, err := c.GetTwilight(context.Background(), o)
+
+	assert.Nil(t, err)
+
+	expected, _ := twilight.GetTwilightPeriods(context.Background(), o)
+
+	assertJSONEq(t, expected, res)
+}
+
+func TestRetries(t *testing.T) {
+	var attempts int32
+
+	handler := router.New(config.Default(), router.Dependencies{})
+
+	// The server is unavailable for the first two attempts:
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		handler.ServeHTTP(w, r)
+	}))
+
+	defer srv.Close()
+
+	c, _ := New(WithBaseURL(srv.URL), WithRetries(3, time.Millisecond))
+
+	res, err := c.GetSun(context.Background(), o)
+
+	// Assert that the request succeeds on its third attempt:
+	assert.Nil(t, err)
+	assert.NotNil(t, res)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+
+	atomic.StoreInt32(&attempts, 0)
+
+	c, _ = New(WithBaseURL(srv.URL), WithRetries(1, time.Millisecond))
+
+	_, err = c.GetSun(context.Background(), o)
+
+	var e *Error
+
+	// Assert that the last error is returned once the retries are exhausted:
+	assert.True(t, errors.As(err, &e))
+	assert.Equal(t, http.StatusServiceUnavailable, e.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+}
+
+func TestNoRetryOnBadRequest(t *testing.T) {
+	var attempts int32
+
+	var apiKey atomic.Value
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		apiKey.Store(r.Header.Get(API_KEY_HEADER))
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"latitude: 91 must be a number between -90 and 90","requestId":"abc"}`))
+	}))
+
+	defer srv.Close()
+
+	c, _ := New(WithBaseURL(srv.URL), WithRetries(3, time.Millisecond), WithAPIKey("test"))
+
+	_, err := c.GetTwilight(context.Background(), o)
+
+	var e *Error
+
+	// Assert that a client error is not retried:
+	assert.True(t, errors.As(err, &e))
+	assert.Equal(t, "abc", e.RequestID)
+	assert.Equal(t, "latitude: 91 must be a number between -90 and 90", e.Message)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+
+	// Assert that the API key is presented in its header:
+	assert.Equal(t, "test", apiKey.Load())
+}
+
+func TestRateLimited(t *testing.T) {
+	cfg := config.Default()
+
+	cfg.Cache.Size = 0
+	cfg.RateLimit.RequestsPerMinute = 60
+	cfg.RateLimit.Burst = 1
+
+	srv := httptest.NewServer(router.New(cfg, router.Dependencies{}))
+
+	defer srv.Close()
+
+	c, _ := New(WithBaseURL(srv.URL), WithRetries(0, 0))
+
+	_, err := c.GetSun(context.Background(), o)
+
+	assert.Nil(t, err)
+
+	_, err = c.GetSun(context.Background(), o)
+
+	var e *Error
+
+	// Assert that a rate limited request carries the delay requested by the server:
+	assert.True(t, errors.As(err, &e))
+	assert.Equal(t, http.StatusTooManyRequests, e.StatusCode)
+	assert.Greater(t, e.RetryAfter, time.Duration(0))
+}
+
+func TestContextCancelled(t *testing.T) {
+	var attempts int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+
+	defer srv.Close()
+
+	c, _ := New(WithBaseURL(srv.URL), WithRetries(10, time.Hour))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+
+	defer cancel()
+
+	_, err := c.GetSun(ctx, o)
+
+	// Assert that the client stops retrying once the context is done:
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
+
+func TestGetBackoff(t *testing.T) {
+	c, _ := New(WithRetries(3, time.Second))
+
+	// Assert that the backoff doubles for each retry, with jitter:
+	for retry := 1; retry <= 3; retry++ {
+		backoff := c.getBackoff(retry, errors.New("error"))
+
+		assert.GreaterOrEqual(t, backoff, (time.Second<<(retry-1))/2)
+		assert.Less(t, backoff, time.Second<<(retry-1))
+	}
+
+	// Assert that the server's Retry-After is honoured:
+	assert.Equal(t, 5*time.Second, c.getBackoff(1, &Error{StatusCode: http.StatusTooManyRequests, RetryAfter: 5 * time.Second}))
+
+	// Assert that the backoff is capped:
+	assert.Equal(t, MAX_BACKOFF, c.getBackoff(1, &Error{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Hour}))
+}
@@ -0,0 +1,160 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/observerly/dusk/pkg/dusk"
+	"github.com/observerly/nocturnal/pkg/moon"
+	"github.com/observerly/nocturnal/pkg/observer"
+	"github.com/observerly/nocturnal/pkg/occultation"
+	"github.com/observerly/nocturnal/pkg/sun"
+	"github.com/observerly/nocturnal/pkg/transit"
+	"github.com/observerly/nocturnal/pkg/twilight"
+)
+
+// formatFloat formats the float with the fewest digits that parse back to the same float:
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// getObserverQuery returns the query parameters of the observer, where the datetime is sent as RFC3339 (to the whole
+// second, in the offset of the datetime):
+func getObserverQuery(o observer.Observer) url.Values {
+	query := url.Values{}
+
+	query.Set("datetime", o.Datetime.Format(time.RFC3339))
+
+	query.Set("longitude", formatFloat(o.Longitude))
+
+	query.Set("latitude", formatFloat(o.Latitude))
+
+	query.Set("elevation", formatFloat(o.Elevation))
+
+	return query
+}
+
+// getTargetQuery returns the query parameters of the observer and the equatorial coordinate of the target:
+func getTargetQuery(o observer.Observer, eq dusk.EquatorialCoordinate) url.Values {
+	query := getObserverQuery(o)
+
+	query.Set("ra", formatFloat(eq.RightAscension))
+
+	query.Set("dec", formatFloat(eq.Declination))
+
+	return query
+}
+
+// GetSun returns the position of the Sun at its rise, maximum and set on the day of the observer's datetime, from
+// GET /api/v2/sun:
+func (c *Client) GetSun(ctx context.Context, o observer.Observer) (*sun.Response, error) {
+	res := &sun.Response{}
+
+	if err := c.get(ctx, "/api/v2/sun", getObserverQuery(o), res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetMoon returns the position of the Moon at its rise, maximum and set on the day of the observer's datetime (where
+// any event that does not occur is nil), from GET /api/v2/moon:
+func (c *Client) GetMoon(ctx context.Context, o observer.Observer) (*moon.Response, error) {
+	res := &moon.Response{}
+
+	if err := c.get(ctx, "/api/v2/moon", getObserverQuery(o), res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// SearchMoon returns the position of the Moon at its next rise, maximum and set after the observer's datetime, searching
+// forward by up to the number of days, from GET /api/v2/moon?next=true:
+func (c *Client) SearchMoon(ctx context.Context, o observer.Observer, days int) (*moon.Response, error) {
+	query := getObserverQuery(o)
+
+	query.Set("next", "true")
+
+	query.Set("days", strconv.Itoa(days))
+
+	res := &moon.Response{}
+
+	if err := c.get(ctx, "/api/v2/moon", query, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetMoonLibration returns the libration of the Moon at the observer's datetime, from GET /api/v2/moon/libration:
+func (c *Client) GetMoonLibration(ctx context.Context, o observer.Observer) (*moon.LibrationResponse, error) {
+	res := &moon.LibrationResponse{}
+
+	if err := c.get(ctx, "/api/v2/moon/libration", getObserverQuery(o), res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetTransit returns the position of the target at the equatorial coordinate at its rise, maximum and set on the day
+// of the observer's datetime, and its path across the sky, from GET /api/v2/transit:
+func (c *Client) GetTransit(ctx context.Context, o observer.Observer, eq dusk.EquatorialCoordinate) (*transit.Response, error) {
+	res := &transit.Response{}
+
+	if err := c.get(ctx, "/api/v2/transit", getTargetQuery(o, eq), res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetOccultations returns the occultations by the Moon of the target at the equatorial coordinate within the number
+// of days of the observer's datetime (whether or not the Moon is above the horizon, see Visible), from
+// GET /api/v2/occultation:
+func (c *Client) GetOccultations(ctx context.Context, o observer.Observer, eq dusk.EquatorialCoordinate, days int) (*occultation.Response, error) {
+	query := getTargetQuery(o, eq)
+
+	query.Set("days", strconv.Itoa(days))
+
+	res := &occultation.Response{}
+
+	if err := c.get(ctx, "/api/v2/occultation", query, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetNamedOccultations returns the occultations by the Moon of the named planet (e.g., "mars") or bright star (e.g.,
+// "aldebaran"), as for GetOccultations, from GET /api/v2/occultation:
+func (c *Client) GetNamedOccultations(ctx context.Context, o observer.Observer, name string, days int) (*occultation.Response, error) {
+	query := getObserverQuery(o)
+
+	query.Set("target", name)
+
+	query.Set("days", strconv.Itoa(days))
+
+	res := &occultation.Response{}
+
+	if err := c.get(ctx, "/api/v2/occultation", query, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetTwilight returns the civil, nautical and astronomical twilight periods of the night of the observer's datetime,
+// from GET /api/v2/twilight:
+func (c *Client) GetTwilight(ctx context.Context, o observer.Observer) (*twilight.Response, error) {
+	res := &twilight.Response{}
+
+	if err := c.get(ctx, "/api/v2/twilight", getObserverQuery(o), res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
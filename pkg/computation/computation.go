@@ -0,0 +1,60 @@
+package computation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Span is an in-progress computation, which is ended once the computation is complete:
+type Span interface {
+	End()
+}
+
+// Tracer starts a span for each named dusk computation (e.g., "solar_rise_set"), e.g., to trace and time it:
+type Tracer interface {
+	Start(ctx context.Context, name string) Span
+}
+
+type tracerKey struct{}
+
+type noopSpan struct{}
+
+func (noopSpan) End() {}
+
+// WithTracer returns a copy of the context that carries the tracer, which starts every computation of the typed
+// functions that are called with the context:
+func WithTracer(ctx context.Context, tracer Tracer) context.Context {
+	return context.WithValue(ctx, tracerKey{}, tracer)
+}
+
+// Start starts the named computation with the tracer of the context, or otherwise returns a span that does nothing:
+func Start(ctx context.Context, name string) Span {
+	if tracer, ok := ctx.Value(tracerKey{}).(Tracer); ok && tracer != nil {
+		return tracer.Start(ctx, name)
+	}
+
+	return noopSpan{}
+}
+
+// ErrInvalidArgument is matched (with errors.Is) by every error of the typed functions that is due to an invalid
+// argument (e.g., a search window that is too long), rather than to a failure of the computation itself:
+var ErrInvalidArgument = errors.New("invalid argument")
+
+type invalidArgumentError struct {
+	message string
+}
+
+func (e invalidArgumentError) Error() string {
+	return e.message
+}
+
+func (invalidArgumentError) Is(target error) bool {
+	return target == ErrInvalidArgument
+}
+
+// InvalidArgument returns the error for an invalid argument, with the formatted message (e.g., "days must be between
+// 1 and 30"), which matches ErrInvalidArgument:
+func InvalidArgument(format string, a ...any) error {
+	return invalidArgumentError{message: fmt.Sprintf(format, a...)}
+}
@@ -0,0 +1,58 @@
+package computation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recorder is a tracer that records the name of every computation that is started and ended:
+type recorder struct {
+	started []string
+	ended   int
+}
+
+type recorderSpan struct {
+	r *recorder
+}
+
+func (s recorderSpan) End() {
+	s.r.ended++
+}
+
+func (r *recorder) Start(ctx context.Context, name string) Span {
+	r.started = append(r.started, name)
+	return recorderSpan{r: r}
+}
+
+func TestStart(t *testing.T) {
+	r := &recorder{}
+
+	ctx := WithTracer(context.Background(), r)
+
+	Start(ctx, "solar_rise_set").End()
+
+	// Assert that the computation is started and ended with the tracer of the context:
+	assert.Equal(t, []string{"solar_rise_set"}, r.started)
+	assert.Equal(t, 1, r.ended)
+}
+
+func TestStartWithoutTracer(t *testing.T) {
+	// Assert that a computation can be started and ended without a tracer:
+	assert.NotPanics(t, func() {
+		Start(context.Background(), "solar_rise_set").End()
+	})
+}
+
+func TestInvalidArgument(t *testing.T) {
+	err := InvalidArgument("days must be between 1 and %d", 30)
+
+	// Assert that the message is unchanged, and that the error is an invalid argument (even when wrapped):
+	assert.EqualError(t, err, "days must be between 1 and 30")
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	assert.ErrorIs(t, fmt.Errorf("moon: %w", err), ErrInvalidArgument)
+	assert.NotErrorIs(t, errors.New("unknown time zone"), ErrInvalidArgument)
+}
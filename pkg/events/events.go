@@ -0,0 +1,135 @@
+package events
+
+import (
+	"sort"
+	"time"
+
+	"github.com/observerly/dusk/pkg/dusk"
+)
+
+// The types of event of a body, where the rise, maximum and set are common to every body:
+const (
+	RISE              string = "rise"
+	MAXIMUM           string = "maximum"
+	SET               string = "set"
+	CIVIL_DUSK        string = "civil_dusk"
+	NAUTICAL_DUSK     string = "nautical_dusk"
+	ASTRONOMICAL_DUSK string = "astronomical_dusk"
+	ASTRONOMICAL_DAWN string = "astronomical_dawn"
+	NAUTICAL_DAWN     string = "nautical_dawn"
+	CIVIL_DAWN        string = "civil_dawn"
+)
+
+// The maximum interval between an event and the datetime that it was solved for, beyond which the event does not occur:
+const MAX_EVENT_INTERVAL time.Duration = 48 * time.Hour
+
+type Horizontal struct {
+	// The altitude of the body above the horizon (in degrees):
+	Altitude float64 `json:"alt"`
+	// The azimuth of the body, east of north (in degrees):
+	Azimuth float64 `json:"az"`
+}
+
+type Equatorial struct {
+	// The right ascension of the body (in degrees):
+	RightAscension float64 `json:"ra"`
+	// The declination of the body (in degrees):
+	Declination float64 `json:"dec"`
+}
+
+type Event struct {
+	// The type of the event, e.g., "rise":
+	Type string `json:"type"`
+	// The datetime of the event in UTC:
+	UTC time.Time `json:"utc"`
+	// The datetime of the event in the observer's local timezone:
+	Local time.Time `json:"local"`
+	// The position of the body in the observer's sky at the event:
+	Horizontal Horizontal `json:"horizontal"`
+	// The position of the body on the celestial sphere at the event:
+	Equatorial Equatorial `json:"equatorial"`
+}
+
+type Body struct {
+	// The name of the body, e.g., "sun", "moon", "mars" or "target":
+	Name string `json:"name"`
+	// The events of the body, in chronological order:
+	Events []Event `json:"events"`
+}
+
+type Observer struct {
+	// The datetime of the observation in UTC:
+	UTC time.Time `json:"utc"`
+	// The datetime of the observation in the observer's local timezone:
+	Local time.Time `json:"local"`
+	// The IANA timezone of the observer, e.g., "Pacific/Honolulu":
+	Timezone string `json:"timezone"`
+	// The longitude of the observer (in degrees east of the Greenwich meridian):
+	Longitude float64 `json:"longitude"`
+	// The latitude of the observer (in degrees north of the equator):
+	Latitude float64 `json:"latitude"`
+	// The elevation of the observer (in metres above sea level):
+	Elevation float64 `json:"elevation"`
+}
+
+type Response struct {
+	Observer Observer `json:"observer"`
+	Bodies   []Body   `json:"bodies"`
+}
+
+// NewObserver returns the observer at the datetime, whose local timezone is the location:
+func NewObserver(datetime time.Time, longitude float64, latitude float64, elevation float64, location *time.Location) Observer {
+	return Observer{
+		UTC:       datetime.UTC().Truncate(time.Second),
+		Local:     datetime.In(location).Truncate(time.Second),
+		Timezone:  location.String(),
+		Longitude: longitude,
+		Latitude:  latitude,
+		Elevation: elevation,
+	}
+}
+
+// Occurs returns whether the datetime that dusk solved for an event near the reference datetime is an occurrence of the
+// event, N.B. dusk returns either the zero time or a NaN converted to a time (i.e., 1677-09-21) rather than an error for
+// an event that does not occur, e.g., a sunrise during the polar day:
+func Occurs(datetime time.Time, reference time.Time) bool {
+	if datetime.IsZero() {
+		return false
+	}
+
+	return datetime.Sub(reference).Abs() <= MAX_EVENT_INTERVAL
+}
+
+// NewEvent returns the event of the given type at the datetime, when the body is at the equatorial coordinate, for
+// the observer at the longitude and latitude in the local timezone of the location:
+func NewEvent(kind string, datetime time.Time, eq dusk.EquatorialCoordinate, longitude float64, latitude float64, location *time.Location) Event {
+	hz := dusk.ConvertEquatorialCoordinateToHorizontal(datetime.UTC(), longitude, latitude, eq)
+
+	return Event{
+		Type:  kind,
+		UTC:   datetime.UTC().Truncate(time.Second),
+		Local: datetime.In(location).Truncate(time.Second),
+		Horizontal: Horizontal{
+			Altitude: hz.Altitude,
+			Azimuth:  hz.Azimuth,
+		},
+		Equatorial: Equatorial{
+			RightAscension: eq.RightAscension,
+			Declination:    eq.Declination,
+		},
+	}
+}
+
+// NewBody returns the named body with its events, in chronological order:
+func NewBody(name string, events ...Event) Body {
+	sorted := append([]Event{}, events...)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].UTC.Before(sorted[j].UTC)
+	})
+
+	return Body{
+		Name:   name,
+		Events: sorted,
+	}
+}
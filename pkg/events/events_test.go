@@ -0,0 +1,67 @@
+package events
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/observerly/dusk/pkg/dusk"
+	"github.com/stretchr/testify/assert"
+)
+
+var honolulu, _ = time.LoadLocation("Pacific/Honolulu")
+
+func TestNewObserver(t *testing.T) {
+	observer := NewObserver(time.Date(2021, 5, 14, 10, 0, 0, 500, time.UTC), -155.468094, 19.798484, 4205, honolulu)
+
+	b, err := json.Marshal(observer)
+
+	// Assert that the datetime is in both UTC and the local timezone, to the whole second:
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"utc":"2021-05-14T10:00:00Z","local":"2021-05-14T00:00:00-10:00","timezone":"Pacific/Honolulu","longitude":-155.468094,"latitude":19.798484,"elevation":4205}`, string(b))
+}
+
+func TestNewEvent(t *testing.T) {
+	eq := dusk.EquatorialCoordinate{RightAscension: 88.7929583, Declination: 7.4070639}
+
+	event := NewEvent(RISE, time.Date(2021, 5, 14, 10, 0, 0, 0, time.UTC), eq, -155.468094, 19.798484, honolulu)
+
+	hz := dusk.ConvertEquatorialCoordinateToHorizontal(time.Date(2021, 5, 14, 10, 0, 0, 0, time.UTC), -155.468094, 19.798484, eq)
+
+	assert.Equal(t, RISE, event.Type)
+	assert.Equal(t, "2021-05-14T00:00:00-10:00", event.Local.Format(time.RFC3339))
+	assert.Equal(t, Horizontal{Altitude: hz.Altitude, Azimuth: hz.Azimuth}, event.Horizontal)
+	assert.Equal(t, Equatorial{RightAscension: 88.7929583, Declination: 7.4070639}, event.Equatorial)
+}
+
+func TestOccurs(t *testing.T) {
+	reference := time.Date(2021, 5, 14, 10, 0, 0, 0, time.UTC)
+
+	assert.True(t, Occurs(reference.Add(-12*time.Hour), reference))
+	assert.True(t, Occurs(reference.Add(36*time.Hour), reference))
+
+	// Assert that the times that dusk returns for an event that does not occur are not occurrences:
+	assert.False(t, Occurs(time.Time{}, reference))
+	assert.False(t, Occurs(time.Unix(0, math.MinInt64), reference))
+	assert.False(t, Occurs(time.Unix(0, 0), reference))
+}
+
+func TestNewBody(t *testing.T) {
+	eq := dusk.EquatorialCoordinate{}
+
+	datetime := time.Date(2021, 5, 14, 10, 0, 0, 0, time.UTC)
+
+	body := NewBody(
+		"target",
+		NewEvent(SET, datetime.Add(12*time.Hour), eq, 0, 0, time.UTC),
+		NewEvent(RISE, datetime, eq, 0, 0, time.UTC),
+		NewEvent(MAXIMUM, datetime.Add(6*time.Hour), eq, 0, 0, time.UTC),
+	)
+
+	// Assert that the events are in chronological order:
+	assert.Equal(t, "target", body.Name)
+	assert.Equal(t, RISE, body.Events[0].Type)
+	assert.Equal(t, MAXIMUM, body.Events[1].Type)
+	assert.Equal(t, SET, body.Events[2].Type)
+}
@@ -0,0 +1,168 @@
+package moon
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/observerly/dusk/pkg/dusk"
+	"github.com/observerly/nocturnal/pkg/computation"
+	"github.com/observerly/nocturnal/pkg/observer"
+)
+
+// The inclination of the mean lunar equator to the ecliptic (in degrees):
+const LUNAR_EQUATOR_INCLINATION float64 = 1.54242
+
+// The mean distance between the centres of the Earth and the Sun (in km):
+const ASTRONOMICAL_UNIT float64 = 149597870.7
+
+type Libration struct {
+	// The optical libration in latitude (in degrees):
+	Latitude float64 `json:"latitude"`
+	// The optical libration in longitude (in degrees):
+	Longitude float64 `json:"longitude"`
+	// The position angle of the Moon's axis of rotation (in degrees):
+	Axis float64 `json:"axisAngle"`
+	// The position angle of the midpoint of the bright limb (in degrees):
+	Limb float64 `json:"limbAngle"`
+	// The selenographic colongitude of the Sun, i.e., of the morning terminator (in degrees):
+	Colongitude float64 `json:"colongitude"`
+}
+
+type LibrationProperties struct {
+	// The datetime in UTC:
+	UTC time.Time `json:"UTC"`
+	// The datetime in the local civil time of the datetime:
+	LCT time.Time `json:"LCT"`
+	Libration
+}
+
+type LibrationResponse struct {
+	Observer  observer.Observer   `json:"observer"`
+	Libration LibrationProperties `json:"libration"`
+}
+
+// normalise corrects an angle (in degrees) to be between [0°, 360°):
+func normalise(θ float64) float64 {
+	θ = math.Mod(θ, 360)
+
+	// Correct for negative angles:
+	if θ < 0 {
+		θ += 360
+	}
+
+	return θ
+}
+
+// getSelenographicPosition returns the selenographic longitude and latitude (in degrees) of the point on the
+// Moon's surface where a body at the geocentric ecliptic longitude λ and latitude β appears at the zenith.
+// @see eq.53.1 p.372 of Meeus, Jean. 1991. Astronomical algorithms. Richmond, Va: Willmann-Bell.
+func getSelenographicPosition(λ float64, β float64, Ω float64, F float64) (float64, float64) {
+	I := LUNAR_EQUATOR_INCLINATION * math.Pi / 180
+
+	W := (λ - Ω) * math.Pi / 180
+
+	b := β * math.Pi / 180
+
+	A := math.Atan2(math.Sin(W)*math.Cos(b)*math.Cos(I)-math.Sin(b)*math.Sin(I), math.Cos(W)*math.Cos(b))
+
+	l := normalise(A*180/math.Pi - F)
+
+	// Express the longitude as an offset either side of the mean centre of the disk:
+	if l > 180 {
+		l -= 360
+	}
+
+	return l, math.Asin(-math.Sin(W)*math.Cos(b)*math.Sin(I)-math.Sin(b)*math.Cos(I)) * 180 / math.Pi
+}
+
+// GetLunarLibration returns the optical libration of the Moon, the position angles of its axis and bright limb,
+// and the selenographic colongitude of the Sun, for a given datetime.
+// @see ch.48 p.345 & ch.53 p.371 of Meeus, Jean. 1991. Astronomical algorithms. Richmond, Va: Willmann-Bell.
+func GetLunarLibration(datetime time.Time) Libration {
+	T := dusk.GetCurrentJulianCenturyRelativeToJ2000(datetime.UTC())
+
+	// The longitude of the ascending node of the Moon's mean orbit:
+	Ω := dusk.GetLunarLongitudeOfTheAscendingNode(T)
+
+	// The Moon's argument of latitude:
+	F := dusk.GetLunarArgumentOfLatitude(T)
+
+	L := dusk.GetSolarMeanLongitude(T)
+
+	l := dusk.GetLunarMeanLongitude(T)
+
+	// The nutation in longitude and the true obliquity of the ecliptic:
+	Δψ := dusk.GetNutationInLongitudeOfTheEcliptic(L, l, Ω)
+
+	ε := (dusk.GetMeanObliquityOfTheEcliptic(T) + dusk.GetNutationInObliquityOfTheEcliptic(L, l, Ω)) * math.Pi / 180
+
+	ec := dusk.GetLunarEclipticPosition(datetime.UTC())
+
+	eq := dusk.ConvertEclipticCoordinateToEquatorial(datetime.UTC(), ec)
+
+	// The optical libration in longitude and latitude:
+	lʹ, bʹ := getSelenographicPosition(ec.Longitude, ec.Latitude, Ω, F)
+
+	// The position angle of the axis, neglecting the physical libration:
+	I := LUNAR_EQUATOR_INCLINATION * math.Pi / 180
+
+	V := (Ω + Δψ) * math.Pi / 180
+
+	X := math.Sin(I) * math.Sin(V)
+
+	Y := math.Sin(I)*math.Cos(V)*math.Cos(ε) - math.Cos(I)*math.Sin(ε)
+
+	ω := math.Atan2(X, Y)
+
+	α := eq.RightAscension * math.Pi / 180
+
+	δ := eq.Declination * math.Pi / 180
+
+	P := math.Asin(math.Sqrt(X*X+Y*Y)*math.Cos(α-ω)/math.Cos(bʹ*math.Pi/180)) * 180 / math.Pi
+
+	// The position angle of the midpoint of the bright limb:
+	sun := dusk.GetSolarEquatorialPosition(datetime.UTC())
+
+	α0 := sun.RightAscension * math.Pi / 180
+
+	δ0 := sun.Declination * math.Pi / 180
+
+	χ := math.Atan2(math.Cos(δ0)*math.Sin(α0-α), math.Sin(δ0)*math.Cos(δ)-math.Cos(δ0)*math.Sin(δ)*math.Cos(α0-α)) * 180 / math.Pi
+
+	// The heliocentric position of the Moon, as seen from the Sun:
+	λ0 := dusk.GetSolarEclipticPosition(datetime.UTC()).Longitude
+
+	λH := λ0 + 180 + (ec.Δ/ASTRONOMICAL_UNIT)*(180/math.Pi)*math.Cos(ec.Latitude*math.Pi/180)*math.Sin((λ0-ec.Longitude)*math.Pi/180)
+
+	βH := (ec.Δ / ASTRONOMICAL_UNIT) * ec.Latitude
+
+	// The selenographic longitude of the subsolar point:
+	l0, _ := getSelenographicPosition(λH, βH, Ω, F)
+
+	return Libration{
+		Latitude:    bʹ,
+		Longitude:   lʹ,
+		Axis:        normalise(P),
+		Limb:        normalise(χ),
+		Colongitude: normalise(90 - l0),
+	}
+}
+
+// GetLibration returns the libration of the Moon at the observer's datetime:
+func GetLibration(ctx context.Context, o observer.Observer) LibrationResponse {
+	span := computation.Start(ctx, "lunar_libration")
+
+	lb := GetLunarLibration(o.Datetime)
+
+	span.End()
+
+	return LibrationResponse{
+		Observer: o,
+		Libration: LibrationProperties{
+			UTC:       o.Datetime.UTC().Truncate(time.Second),
+			LCT:       o.Datetime.Truncate(time.Second),
+			Libration: lb,
+		},
+	}
+}
@@ -0,0 +1,36 @@
+package moon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/observerly/nocturnal/pkg/observer"
+)
+
+// The tolerance accounts for the truncated lunar series and the omission of the physical libration:
+var tolerance = 0.2
+
+func TestGetLunarLibration(t *testing.T) {
+	// The worked example 53.a of Meeus, Astronomical Algorithms:
+	lb := GetLunarLibration(time.Date(1992, 4, 12, 0, 0, 0, 0, time.UTC))
+
+	assert.InDelta(t, 4.194, lb.Latitude, tolerance)
+	assert.InDelta(t, -1.206, lb.Longitude, tolerance)
+	assert.InDelta(t, 15.08, lb.Axis, tolerance)
+	assert.InDelta(t, 285.0, lb.Limb, tolerance)
+	assert.InDelta(t, 22.10, lb.Colongitude, tolerance)
+}
+
+func TestGetLibration(t *testing.T) {
+	o := observer.New(time.Date(1992, 4, 12, 0, 0, 0, 0, time.UTC), -155.468094, 19.798484, 0)
+
+	res := GetLibration(context.Background(), o)
+
+	// Assert that the libration is of the observer's datetime:
+	assert.Equal(t, o, res.Observer)
+	assert.Equal(t, "1992-04-12T00:00:00Z", res.Libration.UTC.Format(time.RFC3339))
+	assert.Equal(t, GetLunarLibration(o.Datetime), res.Libration.Libration)
+}
@@ -1,145 +1,228 @@
 package moon
 
 import (
-	"net/http"
-	"strconv"
+	"context"
 	"time"
 
-	"github.com/gin-gonic/gin"
 	"github.com/observerly/dusk/pkg/dusk"
-	"github.com/observerly/nocturnal/internal/query"
-	"github.com/observerly/nocturnal/internal/utils"
+	"github.com/observerly/nocturnal/pkg/computation"
+	"github.com/observerly/nocturnal/pkg/events"
+	"github.com/observerly/nocturnal/pkg/observer"
 )
 
-// GET /moon
-func GetMoonDeprecatedV1(c *gin.Context) {
-	d, lon, lat := query.GetDefaultObserverParams(c)
+type Topocentric struct {
+	// The topocentric altitude of the Moon above the horizon (in degrees):
+	Altitude float64 `json:"alt"`
+	// The topocentric azimuth of the Moon, east of north (in degrees):
+	Azimuth float64 `json:"az"`
+	// The topocentric right ascension of the Moon (in degrees):
+	RightAscension float64 `json:"ra"`
+	// The topocentric declination of the Moon (in degrees):
+	Declination float64 `json:"dec"`
+	// The distance between the observer and the centre of the Moon (in km):
+	Distance float64 `json:"distance"`
+}
 
-	datetime, _ := utils.ParseDatetimeRFC3339(d)
+type Properties struct {
+	// The datetime in UTC:
+	UTC time.Time `json:"UTC"`
+	// The datetime in the local civil time of the datetime:
+	LCT time.Time `json:"LCT"`
+	// The geocentric altitude of the Moon above the horizon (in degrees):
+	Altitude float64 `json:"alt"`
+	// The geocentric azimuth of the Moon, east of north (in degrees):
+	Azimuth float64 `json:"az"`
+	// The geocentric right ascension of the Moon (in degrees):
+	RightAscension float64 `json:"ra"`
+	// The geocentric declination of the Moon (in degrees):
+	Declination float64 `json:"dec"`
+	// The age of the Moon (in days since the new Moon):
+	Age float64 `json:"age"`
+	// The phase angle of the Moon (in degrees):
+	Angle float64 `json:"angle"`
+	// The illuminated fraction of the Moon's disk:
+	Fraction float64 `json:"fraction"`
+	// The illuminated percentage of the Moon's disk:
+	Illumination float64 `json:"illumination"`
+	// The atmospheric refraction at the altitude of the Moon (in degrees), or nil if the Moon is below the horizon:
+	Refraction *float64 `json:"R"`
+	// The relative air mass at the altitude of the Moon, or nil if the Moon is below the horizon:
+	AirMass *float64 `json:"X"`
+	// The geocentric distance of the Moon (in km):
+	Distance float64 `json:"distance"`
+	// The apparent angular diameter of the Moon for the observer (in degrees):
+	Diameter float64 `json:"diameter"`
+	// The position of the Moon, corrected for the parallax of the observer:
+	Topocentric Topocentric `json:"topocentric"`
+}
 
-	longitude, _ := strconv.ParseFloat(lon, 64)
+type Response struct {
+	Observer observer.Observer `json:"observer"`
+	// The properties of the Moon at each event, or nil if the event does not occur:
+	Rise    *Properties `json:"rise"`
+	Maximum *Properties `json:"maximum"`
+	Set     *Properties `json:"set"`
+}
 
-	latitude, _ := strconv.ParseFloat(lat, 64)
+// GetStandardLunarProperties returns the position, phase and apparent size of the Moon at the datetime, for the
+// observer at the longitude, latitude and elevation, where the position and distance are both of the same model:
+// @see ch.47 p.337 of Meeus, Jean. 1991. Astronomical algorithms. Richmond, Va: Willmann-Bell.
+func GetStandardLunarProperties(datetime time.Time, longitude float64, latitude float64, elevation float64) Properties {
+	ec := dusk.GetLunarEclipticPosition(datetime.UTC())
 
-	ec := dusk.GetLunarEclipticPosition(datetime)
+	eq := dusk.ConvertEclipticCoordinateToEquatorial(datetime.UTC(), ec)
 
-	eq := dusk.GetLunarEquatorialPosition(datetime)
+	hz := dusk.ConvertEquatorialCoordinateToHorizontal(datetime.UTC(), longitude, latitude, eq)
 
-	hz := dusk.ConvertEquatorialCoordinateToHorizontal(datetime, longitude, latitude, eq)
+	distance := ec.Δ
 
-	ph := dusk.GetLunarPhase(datetime, longitude, ec)
+	// Correct the geocentric position for the parallax of the observer:
+	tp := GetTopocentricLunarPosition(datetime, longitude, latitude, elevation, eq, distance)
 
-	rs, _ := dusk.GetMoonriseMoonsetTimes(datetime, longitude, latitude)
+	ph := dusk.GetLunarPhase(datetime.UTC(), longitude, ec)
 
-	observer := gin.H{
-		"datetime":  datetime,
-		"longitude": longitude,
-		"latitude":  latitude,
+	return Properties{
+		UTC:            datetime.UTC().Truncate(time.Second),
+		LCT:            datetime.Truncate(time.Second),
+		Altitude:       hz.Altitude,
+		Azimuth:        hz.Azimuth,
+		RightAscension: eq.RightAscension,
+		Declination:    eq.Declination,
+		Age:            ph.Days,
+		Angle:          ph.Angle,
+		Fraction:       ph.Fraction,
+		Illumination:   ph.Illumination,
+		Refraction:     dusk.GetAtmosphericRefraction(hz.Altitude),
+		AirMass:        dusk.GetRelativeAirMass(hz.Altitude),
+		Distance:       distance,
+		Diameter:       GetLunarAngularDiameter(tp.Distance),
+		Topocentric: Topocentric{
+			Altitude:       tp.Horizontal.Altitude,
+			Azimuth:        tp.Horizontal.Azimuth,
+			RightAscension: tp.Equatorial.RightAscension,
+			Declination:    tp.Equatorial.Declination,
+			Distance:       tp.Distance,
+		},
 	}
+}
 
-	position := gin.H{
-		"alt": hz.Altitude,
-		"az":  hz.Azimuth,
-		"ra":  eq.RightAscension,
-		"dec": eq.Declination,
+// getOptionalLunarProperties returns the standard Lunar properties at the datetime, or nil if there is no datetime:
+func getOptionalLunarProperties(datetime *time.Time, longitude float64, latitude float64, elevation float64) *Properties {
+	if datetime == nil || datetime.IsZero() {
+		return nil
 	}
 
-	phase := gin.H{
-		"age":          ph.Days,
-		"angle":        ph.Angle,
-		"d":            ph.Age,
-		"fraction":     ph.Fraction,
-		"illumination": ph.Illumination,
-	}
+	properties := GetStandardLunarProperties(*datetime, longitude, latitude, elevation)
+
+	return &properties
+}
 
-	transit := gin.H{}
+// GetLunarTransit returns the properties of the Moon at its rise, upper culmination (maximum) and set on the local day
+// of the observer's datetime, where any event that does not occur on that day is nil:
+func GetLunarTransit(ctx context.Context, o observer.Observer) (Response, error) {
+	span := computation.Start(ctx, "lunar_rise_set")
 
-	if rs.Rise.IsZero() {
-		transit["rise"] = nil
-	} else {
-		transit["rise"] = rs.Rise.Format(time.RFC3339)
+	rs, err := dusk.GetMoonriseMoonsetTimes(o.Datetime, o.Longitude, o.Latitude)
+
+	span.End()
+
+	if err != nil {
+		return Response{}, err
 	}
 
-	if rs.Set.IsZero() {
-		transit["set"] = nil
-	} else {
-		transit["set"] = rs.Set.Format(time.RFC3339)
+	span = computation.Start(ctx, "lunar_upper_culmination")
+
+	mx, err := GetLunarUpperCulmination(o.Datetime, o.Longitude, o.Latitude)
+
+	span.End()
+
+	if err != nil {
+		return Response{}, err
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"observer": observer,
-		"position": position,
-		"phase":    phase,
-		"transit":  transit,
-	})
+	return Response{
+		Observer: o,
+		Rise:     getOptionalLunarProperties(&rs.Rise, o.Longitude, o.Latitude, o.Elevation),
+		Maximum:  getOptionalLunarProperties(mx, o.Longitude, o.Latitude, o.Elevation),
+		Set:      getOptionalLunarProperties(&rs.Set, o.Longitude, o.Latitude, o.Elevation),
+	}, nil
 }
 
-func GetStandardLunarProperties(datetime time.Time, longitude float64, latitude float64) gin.H {
-	ec := dusk.GetLunarEclipticPositionLawrence(datetime.UTC())
+// SearchLunarTransit returns the properties of the Moon at its next rise, upper culmination (maximum) and set after
+// the observer's datetime, searching forward for up to the given number of days, where any event not found is nil:
+func SearchLunarTransit(ctx context.Context, o observer.Observer, days int) (Response, error) {
+	if days < 1 || days > MAX_SEARCH_DAYS {
+		return Response{}, computation.InvalidArgument("days must be between 1 and %d", MAX_SEARCH_DAYS)
+	}
 
-	eq := dusk.ConvertEclipticCoordinateToEquatorial(datetime.UTC(), ec)
+	span := computation.Start(ctx, "lunar_next_transit")
 
-	hz := dusk.ConvertEquatorialCoordinateToHorizontal(datetime.UTC(), longitude, latitude, eq)
+	transit, err := GetNextLunarTransit(o.Datetime, o.Longitude, o.Latitude, days)
 
-	ph := dusk.GetLunarPhase(datetime.UTC(), longitude, ec)
+	span.End()
 
-	airmass := dusk.GetRelativeAirMass(hz.Altitude)
-
-	refraction := dusk.GetAtmosphericRefraction(hz.Altitude)
-
-	return gin.H{
-		"UTC":          datetime.UTC().Format(time.RFC3339),
-		"LCT":          datetime.Format(time.RFC3339),
-		"alt":          hz.Altitude,
-		"az":           hz.Azimuth,
-		"ra":           eq.RightAscension,
-		"dec":          eq.Declination,
-		"age":          ph.Days,
-		"angle":        ph.Angle,
-		"fraction":     ph.Fraction,
-		"illumination": ph.Illumination,
-		"R":            refraction,
-		"X":            airmass,
+	if err != nil {
+		return Response{}, err
 	}
+
+	return Response{
+		Observer: o,
+		Rise:     getOptionalLunarProperties(transit.Rise, o.Longitude, o.Latitude, o.Elevation),
+		Maximum:  getOptionalLunarProperties(transit.Maximum, o.Longitude, o.Latitude, o.Elevation),
+		Set:      getOptionalLunarProperties(transit.Set, o.Longitude, o.Latitude, o.Elevation),
+	}, nil
 }
 
-// GET /moon v2
-func GetMoon(c *gin.Context) {
-	d, lon, lat := query.GetDefaultObserverParams(c)
+// GetTopocentricLunarEquatorialPosition returns the equatorial coordinate of the Moon at the datetime, corrected for
+// the parallax of the observer:
+func GetTopocentricLunarEquatorialPosition(datetime time.Time, longitude float64, latitude float64, elevation float64) dusk.EquatorialCoordinate {
+	ec := dusk.GetLunarEclipticPosition(datetime.UTC())
 
-	datetime, _ := utils.ParseDatetimeRFC3339(d)
+	eq := dusk.ConvertEclipticCoordinateToEquatorial(datetime.UTC(), ec)
+
+	return GetTopocentricLunarPosition(datetime, longitude, latitude, elevation, eq, ec.Δ).Equatorial
+}
 
-	longitude, _ := strconv.ParseFloat(lon, 64)
+// GetLunarEvents returns the rise, maximum and set events (those that occur) of the Moon on the local day of the
+// datetime, for the observer in the local timezone of the location:
+func GetLunarEvents(ctx context.Context, datetime time.Time, longitude float64, latitude float64, elevation float64, location *time.Location) ([]events.Event, error) {
+	span := computation.Start(ctx, "lunar_rise_set")
 
-	latitude, _ := strconv.ParseFloat(lat, 64)
+	rs, err := dusk.GetMoonriseMoonsetTimes(datetime, longitude, latitude)
 
-	// Create the Observer gin.H JSON object representation:
-	observer := gin.H{
-		"datetime":  datetime,
-		"longitude": longitude,
-		"latitude":  latitude,
+	span.End()
+
+	if err != nil {
+		return nil, err
 	}
 
-	// Get the next Moon rise and set times:
-	rs, _ := dusk.GetMoonriseMoonsetTimes(datetime, longitude, latitude)
+	span = computation.Start(ctx, "lunar_upper_culmination")
 
-	// Calculate Lunar properties (e.g., phase) at the datetime of the next rise:
-	var rise gin.H = nil
+	mx, err := GetLunarUpperCulmination(datetime, longitude, latitude)
 
-	if !rs.Rise.IsZero() {
-		rise = GetStandardLunarProperties(rs.Rise, longitude, latitude)
-	}
+	span.End()
 
-	// Calculate Lunar properties (e.g., phase) at the datetime of the next set:
-	var set gin.H = nil
+	if err != nil {
+		return nil, err
+	}
 
-	if !rs.Set.IsZero() {
-		set = GetStandardLunarProperties(rs.Set, longitude, latitude)
+	evs := []events.Event{}
+
+	// The Moon rises, culminates and sets roughly every 24h50m, so any of the events may not occur on the local day:
+	for _, e := range []struct {
+		kind     string
+		datetime *time.Time
+	}{
+		{events.RISE, &rs.Rise},
+		{events.MAXIMUM, mx},
+		{events.SET, &rs.Set},
+	} {
+		if e.datetime != nil && events.Occurs(*e.datetime, datetime) {
+			eq := GetTopocentricLunarEquatorialPosition(*e.datetime, longitude, latitude, elevation)
+
+			evs = append(evs, events.NewEvent(e.kind, *e.datetime, eq, longitude, latitude, location))
+		}
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"observer": observer,
-		"rise":     rise,
-		"set":      set,
-	})
+	return evs, nil
 }
@@ -1,182 +1,158 @@
 package moon
 
 import (
-	"encoding/json"
-	"net/http"
-	"net/http/httptest"
-	"os"
+	"context"
 	"testing"
+	"time"
 
-	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
-)
 
-func SetupMoonRouter() *gin.Engine {
-	mode := os.Getenv("GIN_MODE")
+	"github.com/observerly/nocturnal/pkg/observer"
+)
 
-	if mode == "release" {
-		gin.SetMode(gin.ReleaseMode)
-	}
+var precision = 0.0001
 
-	// Create gin router
-	r := gin.Default()
+func TestGetLunarTransit(t *testing.T) {
+	o := observer.New(time.Date(2021, 5, 14, 0, 0, 0, 0, time.UTC), -155.468094, 19.798484, 0)
 
-	r.GET("/api/v1/moon", GetMoonDeprecatedV1)
+	res, err := GetLunarTransit(context.Background(), o)
 
-	return r
-}
+	assert.Nil(t, err)
+	assert.Equal(t, o, res.Observer)
 
-// Setup the Gin API router:
-var r = SetupMoonRouter()
+	// Assert on the rise, maximum and set of the Moon on 2021-05-14 at Mauna Kea:
+	assert.Equal(t, "2021-05-14T17:57:00Z", res.Rise.UTC.Format(time.RFC3339))
+	assert.InDelta(t, 0.09305867924297329, res.Rise.Altitude, precision)
+	assert.InDelta(t, 85.94574336741032, res.Rise.RightAscension, precision)
+	assert.InDelta(t, 24.805966424990554, res.Rise.Declination, precision)
+	assert.InDelta(t, 7.363059593509441, res.Rise.Illumination, precision)
+	assert.InDelta(t, -0.8113021112619615, res.Rise.Topocentric.Altitude, precision)
+	assert.InDelta(t, 403948.73127026996, res.Rise.Topocentric.Distance, precision)
 
-// Setup the base response struct:
-var response map[string]map[string]interface{}
+	assert.Equal(t, "2021-05-15T00:48:00Z", res.Maximum.UTC.Format(time.RFC3339))
+	assert.InDelta(t, 84.63865582057248, res.Maximum.Altitude, precision)
 
-func performRequest(r http.Handler, method, path string) *httptest.ResponseRecorder {
-	req, _ := http.NewRequest(method, path, nil)
-	w := httptest.NewRecorder()
-	r.ServeHTTP(w, req)
-	return w
-}
+	assert.Equal(t, "2021-05-15T07:42:00Z", res.Set.UTC.Format(time.RFC3339))
+	assert.InDelta(t, 0.04136661293157597, res.Set.Altitude, precision)
 
-// Perform a GET request with that handler.
-var w = performRequest(r, "GET", "/api/v1/moon?datetime=2021-05-14T00:00:00.000Z&longitude=-155.468094&latitude=19.798484")
+	// Assert that the refraction and air mass are nil when the Moon is well below the horizon:
+	below := GetStandardLunarProperties(time.Date(2021, 5, 14, 12, 0, 0, 0, time.UTC), o.Longitude, o.Latitude, o.Elevation)
 
-var precision = 0.0000001
+	assert.Less(t, below.Altitude, 0.0)
+	assert.Nil(t, below.Refraction)
+	assert.Nil(t, below.AirMass)
 
-func TestMoonRouteStatusCode(t *testing.T) {
-	// Assert we encoded correctly, the request gives a 200:
-	assert.Equal(t, http.StatusOK, w.Code)
+	// Assert that the Moon is closer to the observer than to the centre of the Earth when it is high in the sky:
+	assert.Less(t, res.Maximum.Topocentric.Distance, res.Maximum.Distance)
 }
 
-func TestGetMoonRouteObserver(t *testing.T) {
-	// Build our expected observer section of body
-	observer := gin.H{
-		"datetime":  "2021-05-14T00:00:00Z",
-		"latitude":  19.798484,
-		"longitude": -155.468094,
-	}
+func TestGetStandardLunarProperties(t *testing.T) {
+	datetime := time.Date(1992, 4, 12, 0, 0, 0, 0, time.UTC)
+
+	p := GetStandardLunarProperties(datetime, 0, 0, 0)
 
-	// Convert the JSON response:
-	err := json.Unmarshal(w.Body.Bytes(), &response)
+	// Assert that the position and distance agree with the reference values of the same model (to within the precision
+	// of dusk's truncated series):
+	// @see ex.47.a p.342 of Meeus, Jean. 1991. Astronomical algorithms. Richmond, Va: Willmann-Bell.
+	assert.InDelta(t, 134.688470, p.RightAscension, 0.15)
+	assert.InDelta(t, 13.768368, p.Declination, 0.15)
+	assert.InDelta(t, 368409.7, p.Distance, 10)
 
-	// Grab the observer & whether or not it exists
-	datetime, exists := response["observer"]["datetime"]
-	assert.True(t, exists)
+	// Assert that the observer is no further than the radius of the Earth from its centre, along the line of sight:
+	assert.InDelta(t, p.Distance, p.Topocentric.Distance, EARTH_EQUATORIAL_RADIUS)
+}
 
-	// Grab the observer & whether or not it exists
-	latitude, exists := response["observer"]["latitude"]
-	assert.True(t, exists)
+func TestGetLunarEvents(t *testing.T) {
+	location, _ := time.LoadLocation("Pacific/Honolulu")
 
-	// Grab the observer & whether or not it exists
-	longitude, exists := response["observer"]["longitude"]
-	assert.True(t, exists)
+	evs, err := GetLunarEvents(context.Background(), time.Date(2021, 5, 14, 0, 0, 0, 0, location), -155.468094, 19.798484, 4205, location)
 
-	// Assert on the correctness of the response:
 	assert.Nil(t, err)
-	assert.Equal(t, datetime, observer["datetime"])
-	assert.Equal(t, latitude, observer["latitude"])
-	assert.Equal(t, longitude, observer["longitude"])
-}
 
-func TestGetMoonRoutePhase(t *testing.T) {
-	// Build our expected phase section of body
-	phase := gin.H{
-		"age":          1.2222287803073832,
-		"angle":        156.46390817398918,
-		"d":            23.47659745538946,
-		"fraction":     0.041388566239529356,
-		"illumination": 4.1595644017041575,
+	// The rise, maximum and set of the Moon on 2021-05-14 at Mauna Kea (as for the v2 reference values), at its
+	// topocentric position:
+	expected := []struct {
+		kind  string
+		local string
+		alt   float64
+		ra    float64
+		dec   float64
+	}{
+		{"rise", "2021-05-14T07:57:00-10:00", -0.8118984853700544, 86.86918783166145, 24.467743185222186},
+		{"maximum", "2021-05-14T14:48:00-10:00", 84.55066014218706, 89.69469744182682, 25.245150437485993},
+		{"set", "2021-05-14T21:42:00-10:00", -0.866379609999194, 92.56328904303832, 25.074442173053228},
 	}
 
-	// Convert the JSON response:
-	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Len(t, evs, len(expected))
 
-	// Grab the phase & whether or not it exists
-	age, exists := response["phase"]["age"]
-	assert.True(t, exists)
+	for i, event := range evs {
+		assert.Equal(t, expected[i].kind, event.Type)
+		assert.Equal(t, expected[i].local, event.Local.Format(time.RFC3339))
+		assert.True(t, event.UTC.Equal(event.Local))
+		assert.InDelta(t, expected[i].alt, event.Horizontal.Altitude, precision)
+		assert.InDelta(t, expected[i].ra, event.Equatorial.RightAscension, precision)
+		assert.InDelta(t, expected[i].dec, event.Equatorial.Declination, precision)
+	}
+}
+
+func TestGetLunarUpperCulminationAtEdgeOfDay(t *testing.T) {
+	location, _ := time.LoadLocation("Pacific/Honolulu")
 
-	// Grab the phase & whether or not it exists
-	angle, exists := response["phase"]["angle"]
-	assert.True(t, exists)
+	datetime := time.Date(2021, 6, 23, 12, 0, 0, 0, location)
 
-	// Grab the phase & whether or not it exists
-	d, exists := response["phase"]["d"]
-	assert.True(t, exists)
+	maximum, err := GetLunarUpperCulmination(datetime, -155.468094, 19.798484)
 
-	// Grab the phase & whether or not it exists
-	fraction, exists := response["phase"]["fraction"]
-	assert.True(t, exists)
+	// Assert that a culmination in the last minute of the local day is reported:
+	assert.Nil(t, err)
+	assert.NotNil(t, maximum)
+	assert.Equal(t, "2021-06-23T23:59:00-10:00", maximum.Format(time.RFC3339))
 
-	// Grab the phase & whether or not it exists
-	illumination, exists := response["phase"]["illumination"]
-	assert.True(t, exists)
+	next, err := GetNextLunarTransit(datetime, -155.468094, 19.798484, 1)
 
-	// Assert on the correctness of the response:
+	// Assert that it agrees with the culmination found by the search forward from the datetime:
 	assert.Nil(t, err)
-	assert.InDelta(t, age, phase["age"], precision)
-	assert.InDelta(t, angle, phase["angle"], precision)
-	assert.InDelta(t, d, phase["d"], precision)
-	assert.InDelta(t, fraction, phase["fraction"], precision)
-	assert.InDelta(t, illumination, phase["illumination"], precision)
+	assert.True(t, next.Maximum.Equal(*maximum))
 }
 
-func TestGetMoonRoutePosition(t *testing.T) {
-	// Build our expected position section of body
-	position := gin.H{
-		"alt": 86.19250552553092,
-		"az":  3.475549831585049,
-		"dec": 23.598793298487617,
-		"ra":  76.2396240985571,
-	}
+func TestSearchLunarTransit(t *testing.T) {
+	o := observer.New(time.Date(2021, 5, 14, 0, 0, 0, 0, time.UTC), -155.468094, 19.798484, 0)
+
+	res, err := SearchLunarTransit(context.Background(), o, 2)
 
-	// Convert the JSON response:
-	err := json.Unmarshal(w.Body.Bytes(), &response)
+	// Assert that every event is found, after the observer's datetime:
+	assert.Nil(t, err)
+	assert.NotNil(t, res.Rise)
+	assert.NotNil(t, res.Maximum)
+	assert.NotNil(t, res.Set)
+	assert.False(t, res.Rise.UTC.Before(o.Datetime))
 
-	// Grab the position & whether or not it exists
-	alt, exists := response["position"]["alt"]
-	assert.True(t, exists)
+	_, err = SearchLunarTransit(context.Background(), o, MAX_SEARCH_DAYS+1)
 
-	// Grab the position & whether or not it exists
-	az, exists := response["position"]["az"]
-	assert.True(t, exists)
+	assert.ErrorContains(t, err, "days must be between 1 and 30")
+}
 
-	// Grab the position & whether or not it exists
-	ra, exists := response["position"]["ra"]
-	assert.True(t, exists)
+func TestGetNextLunarTransitBeforeLocalMidnight(t *testing.T) {
+	// 2021-05-22T00:00:00Z is 14:00 on the 21st in Hawaii (UTC-10), so the next events are before local midnight:
+	datetime := time.Date(2021, 5, 22, 0, 0, 0, 0, time.UTC)
 
-	// Grab the position & whether or not it exists
-	dec, exists := response["position"]["dec"]
-	assert.True(t, exists)
+	transit, err := GetNextLunarTransit(datetime, -155.468094, 19.798484, 2)
 
-	// Assert on the correctness of the response:
+	// Assert that the events between the datetime and the next local midnight are found:
 	assert.Nil(t, err)
-	assert.InDelta(t, alt, position["alt"], precision)
-	assert.InDelta(t, az, position["az"], precision)
-	assert.InDelta(t, ra, position["ra"], precision)
-	assert.InDelta(t, dec, position["dec"], precision)
+	assert.Equal(t, "2021-05-22T00:20:00Z", transit.Rise.UTC().Format(time.RFC3339))
+	assert.Equal(t, "2021-05-22T06:38:00Z", transit.Maximum.UTC().Format(time.RFC3339))
+	assert.Equal(t, "2021-05-22T12:54:00Z", transit.Set.UTC().Format(time.RFC3339))
 }
 
-func TestGetMoonRouteTransit(t *testing.T) {
-	// Build our expected transit section of body
-	transit := gin.H{
-		"rise": "2021-05-14T07:57:00-10:00",
-		"set":  "2021-05-14T21:42:00-10:00",
-	}
-
-	// Convert the JSON response:
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-
-	// Grab the transit & whether or not it exists
-	rise, exists := response["transit"]["rise"]
-	assert.True(t, exists)
+func TestGetNextLunarTransitSearchHorizon(t *testing.T) {
+	datetime := time.Date(2021, 5, 14, 0, 0, 0, 0, time.UTC)
 
-	// Grab the transit & whether or not it exists
-	set, exists := response["transit"]["set"]
-	assert.True(t, exists)
+	transit, err := GetNextLunarTransit(datetime, -155.468094, 19.798484, 1)
 
-	// Assert on the correctness of the response:
+	// Assert that only the events within exactly one day of the datetime are found (the next maximum is at
+	// 2021-05-15T00:48:00Z):
 	assert.Nil(t, err)
-	assert.Equal(t, rise, transit["rise"])
-	assert.Equal(t, set, transit["set"])
+	assert.Equal(t, "2021-05-14T17:57:00Z", transit.Rise.UTC().Format(time.RFC3339))
+	assert.Equal(t, "2021-05-14T06:49:00Z", transit.Set.UTC().Format(time.RFC3339))
+	assert.Nil(t, transit.Maximum)
 }
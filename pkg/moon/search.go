@@ -0,0 +1,121 @@
+package moon
+
+import (
+	"time"
+
+	"github.com/observerly/dusk/pkg/dusk"
+	"github.com/observerly/nocturnal/pkg/observer"
+)
+
+// The maximum number of days to search forward for the next rise, upper culmination and set of the Moon. The Moon
+// rises, culminates and sets roughly every 24h50m, so a search only exhausts this horizon at extreme latitudes where
+// the Moon remains above (or below) the horizon for days at a time:
+const MAX_SEARCH_DAYS int = 30
+
+// isUpperCulmination determines whether the current altitude of the Moon is a local maximum:
+func isUpperCulmination(previous dusk.TransitHorizontalCoordinate, current dusk.TransitHorizontalCoordinate, next dusk.TransitHorizontalCoordinate) bool {
+	return current.Altitude > previous.Altitude && current.Altitude >= next.Altitude
+}
+
+// getLunarHorizontalCoordinate returns the horizontal coordinate of the Moon at the datetime, as sampled by dusk's
+// GetLunarHorizontalCoordinatesForDay:
+func getLunarHorizontalCoordinate(datetime time.Time, longitude float64, latitude float64) dusk.TransitHorizontalCoordinate {
+	ec := dusk.GetLunarEclipticPositionLawrence(datetime.UTC())
+
+	eq := dusk.ConvertEclipticCoordinateToEquatorial(datetime.UTC(), ec)
+
+	hz := dusk.ConvertEquatorialCoordinateToHorizontal(datetime.UTC(), longitude, latitude, eq)
+
+	return dusk.TransitHorizontalCoordinate{
+		Datetime: datetime,
+		Altitude: hz.Altitude,
+		Azimuth:  hz.Azimuth,
+	}
+}
+
+// GetLunarUpperCulmination returns the datetime of the upper culmination of the Moon on the local day of the datetime,
+// or nil if the Moon does not culminate on that day (as it culminates roughly every 24h50m).
+func GetLunarUpperCulmination(datetime time.Time, longitude float64, latitude float64) (*time.Time, error) {
+	hz, err := dusk.GetLunarHorizontalCoordinatesForDay(datetime, longitude, latitude)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Widen the samples by a minute on each side of the day, so that a culmination at either edge is also reported:
+	first := getLunarHorizontalCoordinate(hz[0].Datetime.Add(-time.Minute), longitude, latitude)
+
+	last := getLunarHorizontalCoordinate(hz[len(hz)-1].Datetime.Add(time.Minute), longitude, latitude)
+
+	hz = append(append([]dusk.TransitHorizontalCoordinate{first}, hz...), last)
+
+	for i := 1; i < len(hz)-1; i++ {
+		if isUpperCulmination(hz[i-1], hz[i], hz[i+1]) {
+			return &hz[i].Datetime, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// GetNextLunarTransit searches forward (minute by minute) from the datetime for the next rise, upper culmination and
+// set of the Moon, for up to the given number of days. Any event not found within the search horizon is returned as
+// nil.
+func GetNextLunarTransit(datetime time.Time, longitude float64, latitude float64, days int) (*dusk.Transit, error) {
+	transit := &dusk.Transit{}
+
+	location, err := observer.GetLocation(longitude, latitude)
+
+	if err != nil {
+		return nil, err
+	}
+
+	end := datetime.Add(time.Duration(days) * 24 * time.Hour)
+
+	local := datetime.In(location)
+
+	// The previous two horizontal coordinates of the Moon, required to determine a local maximum in altitude:
+	var previous, current *dusk.TransitHorizontalCoordinate
+
+	// dusk samples from local midnight of the calendar date of the datetime it is given, so start from local midnight
+	// of the day before the datetime, to cover the minutes between the datetime and the next local midnight (e.g., for
+	// a UTC datetime west of Greenwich):
+	for day := time.Date(local.Year(), local.Month(), local.Day()-1, 0, 0, 0, 0, location); day.Before(end); day = day.AddDate(0, 0, 1) {
+		if transit.Rise != nil && transit.Maximum != nil && transit.Set != nil {
+			break
+		}
+
+		hz, err := dusk.GetLunarHorizontalCoordinatesForDay(day, longitude, latitude)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for j := range hz {
+			next := &hz[j]
+
+			// Skip any minute that has already passed (or that overlaps with the previous day), or is beyond the
+			// search horizon:
+			if next.Datetime.Before(datetime) || next.Datetime.After(end) || (current != nil && !next.Datetime.After(current.Datetime)) {
+				continue
+			}
+
+			if next.IsRise && transit.Rise == nil {
+				transit.Rise = &next.Datetime
+			}
+
+			if next.IsSet && transit.Set == nil {
+				transit.Set = &next.Datetime
+			}
+
+			// The upper culmination is the local maximum in the altitude of the Moon:
+			if previous != nil && transit.Maximum == nil && isUpperCulmination(*previous, *current, *next) {
+				transit.Maximum = &current.Datetime
+			}
+
+			previous, current = current, next
+		}
+	}
+
+	return transit, nil
+}
@@ -0,0 +1,85 @@
+package moon
+
+import (
+	"math"
+	"time"
+
+	"github.com/observerly/dusk/pkg/dusk"
+)
+
+// The equatorial radius of the Earth (in km):
+const EARTH_EQUATORIAL_RADIUS float64 = 6378.14
+
+// The flattening ratio (b/a) of the Earth's figure:
+const EARTH_POLAR_RATIO float64 = 0.99664719
+
+// The mean radius of the Moon (in km):
+const LUNAR_RADIUS float64 = 1737.4
+
+type TopocentricPosition struct {
+	// The topocentric equatorial coordinate of the Moon for the observer:
+	Equatorial dusk.EquatorialCoordinate
+	// The topocentric horizontal coordinate of the Moon for the observer:
+	Horizontal dusk.HorizontalCoordinate
+	// The distance between the observer and the centre of the Moon (in km):
+	Distance float64
+}
+
+// GetLunarDistance returns the geocentric distance of the Moon (in km).
+// @see ch.47 p.337 of Meeus, Jean. 1991. Astronomical algorithms. Richmond, Va: Willmann-Bell.
+func GetLunarDistance(datetime time.Time) float64 {
+	return dusk.GetLunarEclipticPosition(datetime.UTC()).Δ
+}
+
+// GetLunarAngularDiameter returns the apparent angular diameter of the Moon (in degrees) for a given distance (in km).
+func GetLunarAngularDiameter(distance float64) float64 {
+	return 2 * math.Asin(LUNAR_RADIUS/distance) * 180 / math.Pi
+}
+
+// GetTopocentricLunarPosition corrects the geocentric equatorial coordinate of the Moon at a given distance (in km)
+// for the parallax of an observer at the given longitude, latitude and elevation (in metres above sea level).
+// @see ch.39 p.263 of Meeus, Jean. 1991. Astronomical algorithms. Richmond, Va: Willmann-Bell.
+func GetTopocentricLunarPosition(datetime time.Time, longitude float64, latitude float64, elevation float64, eq dusk.EquatorialCoordinate, distance float64) TopocentricPosition {
+	φ := latitude * math.Pi / 180
+
+	// The geocentric latitude of the observer, accounting for the flattening of the Earth:
+	u := math.Atan(EARTH_POLAR_RATIO * math.Tan(φ))
+
+	ρsinφ := EARTH_POLAR_RATIO*math.Sin(u) + (elevation/1000/EARTH_EQUATORIAL_RADIUS)*math.Sin(φ)
+
+	ρcosφ := math.Cos(u) + (elevation/1000/EARTH_EQUATORIAL_RADIUS)*math.Cos(φ)
+
+	// The local sidereal time of the observer (in radians):
+	θ := dusk.GetLocalSiderealTime(datetime.UTC(), longitude) * 15 * math.Pi / 180
+
+	α := eq.RightAscension * math.Pi / 180
+
+	δ := eq.Declination * math.Pi / 180
+
+	// Subtract the geocentric position of the observer from the geocentric position of the Moon (in km):
+	x := distance*math.Cos(δ)*math.Cos(α) - EARTH_EQUATORIAL_RADIUS*ρcosφ*math.Cos(θ)
+
+	y := distance*math.Cos(δ)*math.Sin(α) - EARTH_EQUATORIAL_RADIUS*ρcosφ*math.Sin(θ)
+
+	z := distance*math.Sin(δ) - EARTH_EQUATORIAL_RADIUS*ρsinφ
+
+	Δ := math.Sqrt(x*x + y*y + z*z)
+
+	ra := math.Mod(math.Atan2(y, x)*180/math.Pi, 360)
+
+	// Correct for negative angles:
+	if ra < 0 {
+		ra += 360
+	}
+
+	topocentric := dusk.EquatorialCoordinate{
+		RightAscension: ra,
+		Declination:    math.Asin(z/Δ) * 180 / math.Pi,
+	}
+
+	return TopocentricPosition{
+		Equatorial: topocentric,
+		Horizontal: dusk.ConvertEquatorialCoordinateToHorizontal(datetime.UTC(), longitude, latitude, topocentric),
+		Distance:   Δ,
+	}
+}
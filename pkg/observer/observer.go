@@ -0,0 +1,53 @@
+package observer
+
+import (
+	"time"
+
+	tzm "github.com/zsefvlol/timezonemapper"
+
+	"github.com/observerly/nocturnal/pkg/computation"
+)
+
+type Observer struct {
+	// The datetime of the observation:
+	Datetime time.Time `json:"datetime"`
+	// The longitude of the observer (in degrees east of the Greenwich meridian):
+	Longitude float64 `json:"longitude"`
+	// The latitude of the observer (in degrees north of the equator):
+	Latitude float64 `json:"latitude"`
+	// The elevation of the observer (in metres above sea level):
+	Elevation float64 `json:"elevation"`
+}
+
+// New returns the observer at the longitude, latitude and elevation, at the datetime:
+func New(datetime time.Time, longitude float64, latitude float64, elevation float64) Observer {
+	return Observer{
+		Datetime:  datetime,
+		Longitude: longitude,
+		Latitude:  latitude,
+		Elevation: elevation,
+	}
+}
+
+// Validate returns an error if the longitude or latitude of the observer is out of range:
+func (o Observer) Validate() error {
+	if o.Longitude < -180 || o.Longitude > 180 {
+		return computation.InvalidArgument("longitude: %v must be a number between -180 and 180", o.Longitude)
+	}
+
+	if o.Latitude < -90 || o.Latitude > 90 {
+		return computation.InvalidArgument("latitude: %v must be a number between -90 and 90", o.Latitude)
+	}
+
+	return nil
+}
+
+// GetLocation returns the IANA timezone (e.g., "Pacific/Honolulu") at the longitude and latitude:
+func GetLocation(longitude float64, latitude float64) (*time.Location, error) {
+	return time.LoadLocation(tzm.LatLngToTimezoneString(latitude, longitude))
+}
+
+// Location returns the IANA timezone of the observer, from its longitude and latitude:
+func (o Observer) Location() (*time.Location, error) {
+	return GetLocation(o.Longitude, o.Latitude)
+}
@@ -0,0 +1,31 @@
+package observer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	datetime := time.Date(2021, 5, 14, 0, 0, 0, 0, time.UTC)
+
+	assert.Nil(t, New(datetime, -155.468094, 19.798484, 4205).Validate())
+	assert.ErrorContains(t, New(datetime, -180.5, 19.798484, 0).Validate(), "longitude")
+	assert.ErrorContains(t, New(datetime, -155.468094, 90.5, 0).Validate(), "latitude")
+}
+
+func TestLocation(t *testing.T) {
+	location, err := New(time.Now(), -155.468094, 19.798484, 4205).Location()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Pacific/Honolulu", location.String())
+}
+
+func TestGetLocation(t *testing.T) {
+	location, err := GetLocation(-155.468094, 19.798484)
+
+	// Assert we get the correct timezone for Mauna Kea, Hawaii:
+	assert.Nil(t, err)
+	assert.Equal(t, "Pacific/Honolulu", location.String())
+}
@@ -0,0 +1,378 @@
+package occultation
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/observerly/dusk/pkg/dusk"
+	"github.com/observerly/nocturnal/pkg/catalogue"
+	"github.com/observerly/nocturnal/pkg/computation"
+	"github.com/observerly/nocturnal/pkg/moon"
+	"github.com/observerly/nocturnal/pkg/observer"
+	"github.com/observerly/nocturnal/pkg/planets"
+)
+
+// The maximum number of days that can be searched for occultations in a single request:
+const MAX_SEARCH_DAYS int = 31
+
+// The interval at which the separation between the Moon and the target is sampled, this is short enough
+// that the Moon (moving ~0.5° per hour) cannot pass a target without a local minimum being sampled:
+const SEARCH_INTERVAL time.Duration = 10 * time.Minute
+
+// The precision to which the times of disappearance and reappearance are determined:
+const CONTACT_PRECISION time.Duration = time.Second
+
+type Contact struct {
+	// The datetime (in UTC) of the contact:
+	Datetime time.Time
+	// The position angle of the target, measured from the centre of the Moon's disk eastwards from north (in degrees):
+	PositionAngle float64
+	// The horizontal coordinate of the target for the observer at the time of contact:
+	Horizontal dusk.HorizontalCoordinate
+}
+
+type Occultation struct {
+	// The disappearance of the target behind the limb of the Moon:
+	Disappearance Contact
+	// The reappearance of the target from behind the limb of the Moon:
+	Reappearance Contact
+	// The minimum separation between the target and the centre of the Moon (in degrees):
+	Separation float64
+	// The apparent angular radius of the Moon at the time of minimum separation (in degrees):
+	Radius float64
+}
+
+// Position returns the equatorial coordinate of a (possibly moving) target at the datetime:
+type Position func(datetime time.Time) dusk.EquatorialCoordinate
+
+// GetFixedPosition returns the position of a target that is fixed at the equatorial coordinate:
+func GetFixedPosition(eq dusk.EquatorialCoordinate) Position {
+	return func(datetime time.Time) dusk.EquatorialCoordinate {
+		return eq
+	}
+}
+
+type site struct {
+	longitude float64
+	latitude  float64
+	elevation float64
+}
+
+// getTopocentricLunarPosition returns the position of the Moon as seen by the observer at the given datetime:
+func (o site) getTopocentricLunarPosition(datetime time.Time) moon.TopocentricPosition {
+	// N.B. dusk's sidereal time does not scale fractions of a second correctly, so only whole seconds are evaluated:
+	datetime = datetime.Truncate(time.Second)
+
+	ec := dusk.GetLunarEclipticPosition(datetime)
+
+	eq := dusk.ConvertEclipticCoordinateToEquatorial(datetime, ec)
+
+	return moon.GetTopocentricLunarPosition(datetime, o.longitude, o.latitude, o.elevation, eq, ec.Δ)
+}
+
+// getLimbDistance returns the angular distance of the target from the limb of the Moon (in degrees), which is
+// negative when the target is behind the Moon, along with the angular separation from the centre of the Moon:
+func (o site) getLimbDistance(datetime time.Time, position Position) (float64, float64) {
+	tp := o.getTopocentricLunarPosition(datetime)
+
+	eq := position(datetime)
+
+	separation := dusk.GetAngularSeparation(
+		dusk.Coordinate{Latitude: eq.Declination, Longitude: eq.RightAscension},
+		dusk.Coordinate{Latitude: tp.Equatorial.Declination, Longitude: tp.Equatorial.RightAscension},
+	)
+
+	radius := moon.GetLunarAngularDiameter(tp.Distance) / 2
+
+	return separation - radius, radius
+}
+
+// getContact returns the position angle and horizontal coordinate of the target at the given datetime:
+func (o site) getContact(datetime time.Time, position Position) Contact {
+	datetime = datetime.Truncate(time.Second)
+
+	tp := o.getTopocentricLunarPosition(datetime)
+
+	eq := position(datetime)
+
+	α := tp.Equatorial.RightAscension * math.Pi / 180
+
+	δ := tp.Equatorial.Declination * math.Pi / 180
+
+	α0 := eq.RightAscension * math.Pi / 180
+
+	δ0 := eq.Declination * math.Pi / 180
+
+	// The position angle of the target relative to the centre of the Moon:
+	PA := math.Mod(math.Atan2(math.Cos(δ0)*math.Sin(α0-α), math.Cos(δ)*math.Sin(δ0)-math.Sin(δ)*math.Cos(δ0)*math.Cos(α0-α))*180/math.Pi, 360)
+
+	// Correct for negative angles:
+	if PA < 0 {
+		PA += 360
+	}
+
+	return Contact{
+		Datetime:      datetime,
+		PositionAngle: PA,
+		Horizontal:    dusk.ConvertEquatorialCoordinateToHorizontal(datetime, o.longitude, o.latitude, eq),
+	}
+}
+
+// getMinimumSeparation refines the datetime of closest approach between the Moon and the target within
+// the bracket [from, until] using a golden-section search:
+func (o site) getMinimumSeparation(from time.Time, until time.Time, position Position) time.Time {
+	φ := (math.Sqrt(5) - 1) / 2
+
+	a, b := from, until
+
+	for b.Sub(a) > CONTACT_PRECISION {
+		step := time.Duration(float64(b.Sub(a)) * φ)
+
+		c, d := b.Add(-step), a.Add(step)
+
+		fc, _ := o.getLimbDistance(c, position)
+
+		fd, _ := o.getLimbDistance(d, position)
+
+		if fc < fd {
+			b = d
+		} else {
+			a = c
+		}
+	}
+
+	return a.Add(b.Sub(a) / 2)
+}
+
+// getContactTime bisects for the datetime at which the target crosses the limb of the Moon, where the target is
+// outside of the limb at the datetime outside, and behind the Moon at the datetime inside:
+func (o site) getContactTime(outside time.Time, inside time.Time, position Position) time.Time {
+	for outside.Sub(inside) > CONTACT_PRECISION || inside.Sub(outside) > CONTACT_PRECISION {
+		mid := outside.Add(inside.Sub(outside) / 2)
+
+		if d, _ := o.getLimbDistance(mid, position); d < 0 {
+			inside = mid
+		} else {
+			outside = mid
+		}
+	}
+
+	return inside.Add(outside.Sub(inside) / 2)
+}
+
+// getLimbCrossing steps away from the datetime of minimum separation (in the given direction) until the target
+// is clear of the limb of the Moon, and returns the datetime at which the target crossed the limb:
+func (o site) getLimbCrossing(minimum time.Time, direction time.Duration, position Position) time.Time {
+	inside := minimum
+
+	outside := minimum.Add(direction)
+
+	for d, _ := o.getLimbDistance(outside, position); d < 0; d, _ = o.getLimbDistance(outside, position) {
+		inside = outside
+		outside = outside.Add(direction)
+	}
+
+	return o.getContactTime(outside, inside, position)
+}
+
+// GetLunarOccultations returns every occultation of the target by the Moon, as seen by an observer at the given
+// longitude, latitude and elevation (in metres), whose closest approach falls within the given number of days
+// after the datetime.
+func GetLunarOccultations(datetime time.Time, days int, eq dusk.EquatorialCoordinate, longitude float64, latitude float64, elevation float64) []Occultation {
+	return GetLunarOccultationsOf(datetime, days, GetFixedPosition(eq), longitude, latitude, elevation)
+}
+
+// GetLunarOccultationsOf returns every occultation by the Moon of the target at the position (e.g., of a planet,
+// which moves appreciably over the search window), as for GetLunarOccultations:
+func GetLunarOccultationsOf(datetime time.Time, days int, position Position, longitude float64, latitude float64, elevation float64) []Occultation {
+	o := site{longitude: longitude, latitude: latitude, elevation: elevation}
+
+	from := datetime.UTC()
+
+	until := from.Add(time.Duration(days) * 24 * time.Hour)
+
+	occultations := []Occultation{}
+
+	// Sample one interval either side of the search window so that minima on its boundaries are found:
+	t := from.Add(-SEARCH_INTERVAL)
+
+	previous, _ := o.getLimbDistance(t, position)
+
+	current, _ := o.getLimbDistance(t.Add(SEARCH_INTERVAL), position)
+
+	for t = t.Add(SEARCH_INTERVAL); !t.After(until); t = t.Add(SEARCH_INTERVAL) {
+		next, _ := o.getLimbDistance(t.Add(SEARCH_INTERVAL), position)
+
+		// Only consider local minima of the distance between the target and the limb of the Moon:
+		if current <= previous && current < next {
+			minimum := o.getMinimumSeparation(t.Add(-SEARCH_INTERVAL), t.Add(SEARCH_INTERVAL), position)
+
+			distance, radius := o.getLimbDistance(minimum, position)
+
+			if distance < 0 && !minimum.Before(from) && !minimum.After(until) {
+				occultations = append(occultations, Occultation{
+					Disappearance: o.getContact(o.getLimbCrossing(minimum, -SEARCH_INTERVAL, position), position),
+					Reappearance:  o.getContact(o.getLimbCrossing(minimum, SEARCH_INTERVAL, position), position),
+					Separation:    distance + radius,
+					Radius:        radius,
+				})
+			}
+		}
+
+		previous, current = current, next
+	}
+
+	return occultations
+}
+
+type ContactProperties struct {
+	// The datetime of the contact in UTC:
+	UTC time.Time `json:"UTC"`
+	// The datetime of the contact in the observer's local timezone:
+	LCT time.Time `json:"LCT"`
+	// The altitude of the target above the horizon at the contact (in degrees):
+	Altitude float64 `json:"alt"`
+	// The azimuth of the target, east of north, at the contact (in degrees):
+	Azimuth float64 `json:"az"`
+	// The position angle of the target, measured from the centre of the Moon's disk eastwards from north (in degrees):
+	Angle float64 `json:"angle"`
+}
+
+type OccultationProperties struct {
+	Disappearance ContactProperties `json:"disappearance"`
+	Reappearance  ContactProperties `json:"reappearance"`
+	// The duration between the disappearance and reappearance (in hours):
+	Duration float64 `json:"duration"`
+	// The minimum separation between the target and the centre of the Moon (in degrees):
+	Separation float64 `json:"separation"`
+	// The apparent angular radius of the Moon at the time of minimum separation (in degrees):
+	Radius float64 `json:"radius"`
+	// Whether the target is above the observer's horizon at either contact, i.e., whether any of it can be observed:
+	Visible bool `json:"visible"`
+}
+
+type Target struct {
+	// The name of the target (if it was given by name), e.g., "aldebaran" or "mars":
+	Name string `json:"name,omitempty"`
+	// The right ascension of the target at the observer's datetime (in degrees):
+	RightAscension float64 `json:"ra"`
+	// The declination of the target at the observer's datetime (in degrees):
+	Declination float64 `json:"dec"`
+}
+
+type Response struct {
+	Observer     observer.Observer       `json:"observer"`
+	Target       Target                  `json:"target"`
+	Occultations []OccultationProperties `json:"occultations"`
+}
+
+// GetStandardContactProperties returns the time and position of the target at the contact, in the local timezone of
+// the location (to the whole second, as RFC3339):
+func GetStandardContactProperties(contact Contact, location *time.Location) ContactProperties {
+	return ContactProperties{
+		UTC:      contact.Datetime.UTC().Truncate(time.Second),
+		LCT:      contact.Datetime.In(location).Truncate(time.Second),
+		Altitude: contact.Horizontal.Altitude,
+		Azimuth:  contact.Horizontal.Azimuth,
+		Angle:    contact.PositionAngle,
+	}
+}
+
+// GetTargetPosition returns the position of the named target, which is either a planet (e.g., "mars") or a star of
+// the catalogue (e.g., "aldebaran"), referred to the equinox of date:
+func GetTargetPosition(name string) (Position, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	if _, exists := planets.PLANETS[name]; exists {
+		return func(datetime time.Time) dusk.EquatorialCoordinate {
+			eq, _ := planets.GetPlanetaryEquatorialPosition(name, datetime)
+			return eq
+		}, nil
+	}
+
+	if _, exists := catalogue.STARS[name]; exists {
+		return func(datetime time.Time) dusk.EquatorialCoordinate {
+			eq, _ := catalogue.GetEquatorialPosition(name, datetime)
+			return eq
+		}, nil
+	}
+
+	return nil, computation.InvalidArgument("target: %q must be a planet (%s) or a star (%s)", name, strings.Join(planets.NAMES, ", "), strings.Join(catalogue.GetNames(), ", "))
+}
+
+// getOccultations returns every occultation of the target at the position by the Moon, as seen by the observer,
+// whose closest approach falls within the given number of days after the observer's datetime:
+func getOccultations(ctx context.Context, o observer.Observer, target Target, position Position, days int) (Response, error) {
+	if days < 1 || days > MAX_SEARCH_DAYS {
+		return Response{}, computation.InvalidArgument("days must be between 1 and %d", MAX_SEARCH_DAYS)
+	}
+
+	location, err := o.Location()
+
+	if err != nil {
+		return Response{}, err
+	}
+
+	span := computation.Start(ctx, "lunar_occultations")
+
+	predictions := GetLunarOccultationsOf(o.Datetime, days, position, o.Longitude, o.Latitude, o.Elevation)
+
+	span.End()
+
+	occultations := []OccultationProperties{}
+
+	for _, p := range predictions {
+		occultations = append(occultations, OccultationProperties{
+			Disappearance: GetStandardContactProperties(p.Disappearance, location),
+			Reappearance:  GetStandardContactProperties(p.Reappearance, location),
+			Duration:      p.Reappearance.Datetime.Sub(p.Disappearance.Datetime).Hours(),
+			Separation:    p.Separation,
+			Radius:        p.Radius,
+			Visible:       p.Disappearance.Horizontal.Altitude > 0 || p.Reappearance.Horizontal.Altitude > 0,
+		})
+	}
+
+	return Response{
+		Observer:     o,
+		Target:       target,
+		Occultations: occultations,
+	}, nil
+}
+
+// GetOccultations returns every occultation of the target at the equatorial coordinate by the Moon, whether or not
+// the Moon is above the observer's horizon (see Visible), whose closest approach falls within the given number of
+// days after the observer's datetime:
+func GetOccultations(ctx context.Context, o observer.Observer, eq dusk.EquatorialCoordinate, days int) (Response, error) {
+	if eq.RightAscension < 0 || eq.RightAscension >= 360 {
+		return Response{}, computation.InvalidArgument("ra: must be a number of degrees between 0 and 360")
+	}
+
+	if eq.Declination < -90 || eq.Declination > 90 {
+		return Response{}, computation.InvalidArgument("dec: must be a number of degrees between -90 and 90")
+	}
+
+	return getOccultations(ctx, o, Target{
+		RightAscension: eq.RightAscension,
+		Declination:    eq.Declination,
+	}, GetFixedPosition(eq), days)
+}
+
+// GetNamedOccultations returns every occultation of the named planet or star (see GetTargetPosition) by the Moon, as
+// for GetOccultations, where the target's position is that at the observer's datetime:
+func GetNamedOccultations(ctx context.Context, o observer.Observer, name string, days int) (Response, error) {
+	position, err := GetTargetPosition(name)
+
+	if err != nil {
+		return Response{}, err
+	}
+
+	eq := position(o.Datetime)
+
+	return getOccultations(ctx, o, Target{
+		Name:           strings.ToLower(strings.TrimSpace(name)),
+		RightAscension: eq.RightAscension,
+		Declination:    eq.Declination,
+	}, position, days)
+}
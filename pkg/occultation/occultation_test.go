@@ -0,0 +1,108 @@
+package occultation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/observerly/dusk/pkg/dusk"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/observerly/nocturnal/pkg/catalogue"
+	"github.com/observerly/nocturnal/pkg/observer"
+	"github.com/observerly/nocturnal/pkg/planets"
+)
+
+func TestGetOccultations(t *testing.T) {
+	o := observer.New(time.Date(2021, 5, 14, 0, 0, 0, 0, time.UTC), -155.468094, 19.798484, 0)
+
+	eq := dusk.EquatorialCoordinate{RightAscension: 78.55984348656054, Declination: 23.81131183003557}
+
+	res, err := GetOccultations(context.Background(), o, eq, 1)
+
+	assert.Nil(t, err)
+	assert.Equal(t, Target{RightAscension: eq.RightAscension, Declination: eq.Declination}, res.Target)
+	assert.Len(t, res.Occultations, 1)
+
+	// Assert that the contacts are in the observer's local timezone:
+	_, offset := res.Occultations[0].Disappearance.LCT.Zone()
+
+	assert.Equal(t, -10*60*60, offset)
+
+	// Assert on the contacts of the target 0.1° north of the Moon's topocentric centre at 06:00 UTC:
+	assert.Equal(t, "2021-05-14T05:31:00Z", res.Occultations[0].Disappearance.UTC.Format(time.RFC3339))
+	assert.Equal(t, "2021-05-14T06:27:07Z", res.Occultations[0].Reappearance.UTC.Format(time.RFC3339))
+	assert.InDelta(t, 0.1, res.Occultations[0].Separation, 0.001)
+	assert.True(t, res.Occultations[0].Visible)
+
+	_, err = GetOccultations(context.Background(), o, eq, MAX_SEARCH_DAYS+1)
+
+	assert.ErrorContains(t, err, "days must be between 1 and 31")
+}
+
+func TestGetNamedOccultations(t *testing.T) {
+	o := observer.New(time.Date(2022, 12, 7, 0, 0, 0, 0, time.UTC), -104.9903, 39.7392, 0)
+
+	res, err := GetNamedOccultations(context.Background(), o, "Mars", 3)
+
+	assert.Nil(t, err)
+
+	// Assert that the target is named, with its position at the observer's datetime:
+	assert.Equal(t, "mars", res.Target.Name)
+	assert.InDelta(t, 75.17, res.Target.RightAscension, 0.01)
+	assert.InDelta(t, 24.99, res.Target.Declination, 0.01)
+	assert.Len(t, res.Occultations, 1)
+
+	// Assert on the disappearance and reappearance of Mars, as seen from Denver in the evening of 2022-12-07:
+	assert.Equal(t, "2022-12-07T19:53:38-07:00", res.Occultations[0].Disappearance.LCT.Format(time.RFC3339))
+	assert.Equal(t, "2022-12-07T20:56:25-07:00", res.Occultations[0].Reappearance.LCT.Format(time.RFC3339))
+	assert.True(t, res.Occultations[0].Visible)
+
+	_, err = GetNamedOccultations(context.Background(), o, "vulcan", 3)
+
+	assert.ErrorContains(t, err, `target: "vulcan" must be a planet`)
+}
+
+func TestGetOccultationsBelowHorizon(t *testing.T) {
+	o := observer.New(time.Date(2021, 5, 14, 0, 0, 0, 0, time.UTC), -155.468094, 19.798484, 0)
+
+	// A target 0.1° north of the Moon's topocentric centre at 15:00 UTC, before the Moon rises at 17:57 UTC:
+	eq := dusk.EquatorialCoordinate{RightAscension: 84.90824200958491, Declination: 24.172964614652912}
+
+	res, err := GetOccultations(context.Background(), o, eq, 1)
+
+	assert.Nil(t, err)
+	assert.Len(t, res.Occultations, 1)
+
+	// Assert that the occultation is returned, but flagged as not visible to the observer:
+	assert.Less(t, res.Occultations[0].Disappearance.Altitude, 0.0)
+	assert.Less(t, res.Occultations[0].Reappearance.Altitude, 0.0)
+	assert.False(t, res.Occultations[0].Visible)
+}
+
+func TestGetTargetPosition(t *testing.T) {
+	datetime := time.Date(2022, 12, 8, 3, 0, 0, 0, time.UTC)
+
+	position, err := GetTargetPosition(" Mars ")
+
+	assert.Nil(t, err)
+
+	expected, _ := planets.GetPlanetaryEquatorialPosition("mars", datetime)
+
+	// Assert that a planet moves with its ephemeris:
+	assert.Equal(t, expected, position(datetime))
+	assert.NotEqual(t, position(datetime), position(datetime.AddDate(0, 0, 1)))
+
+	position, err = GetTargetPosition("Aldebaran")
+
+	assert.Nil(t, err)
+
+	expected, _ = catalogue.GetEquatorialPosition("aldebaran", datetime)
+
+	// Assert that a star is precessed to the equinox of date:
+	assert.Equal(t, expected, position(datetime))
+
+	_, err = GetTargetPosition("vulcan")
+
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,211 @@
+package planets
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/observerly/dusk/pkg/dusk"
+	"github.com/observerly/nocturnal/pkg/computation"
+	"github.com/observerly/nocturnal/pkg/events"
+	"github.com/observerly/nocturnal/pkg/transit"
+)
+
+// The obliquity of the ecliptic at the J2000 epoch (in degrees):
+const J2000_OBLIQUITY float64 = 23.43928
+
+// The general precession in ecliptic longitude (in degrees per Julian century):
+const PRECESSION_RATE float64 = 1.396971
+
+type OrbitalElements struct {
+	// The semi-major axis (in AU):
+	A float64
+	// The eccentricity:
+	E float64
+	// The inclination to the ecliptic (in degrees):
+	I float64
+	// The mean longitude (in degrees):
+	L float64
+	// The longitude of perihelion (in degrees):
+	Perihelion float64
+	// The longitude of the ascending node (in degrees):
+	Node float64
+}
+
+type Planet struct {
+	// The Keplerian elements of the orbit at the J2000 epoch, referred to the mean ecliptic and equinox of J2000:
+	Elements OrbitalElements
+	// The rate of change of each element (per Julian century):
+	Rates OrbitalElements
+}
+
+// The approximate Keplerian elements of the Earth-Moon barycentre and the planets, valid between 1800 and 2050 AD.
+// @see Table 1 of Standish, E.M. 1992. Keplerian Elements for Approximate Positions of the Major Planets. JPL.
+var EARTH_MOON_BARYCENTRE = Planet{
+	Elements: OrbitalElements{1.00000261, 0.01671123, -0.00001531, 100.46457166, 102.93768193, 0.0},
+	Rates:    OrbitalElements{0.00000562, -0.00004392, -0.01294668, 35999.37244981, 0.32327364, 0.0},
+}
+
+var PLANETS = map[string]Planet{
+	"mercury": {
+		Elements: OrbitalElements{0.38709927, 0.20563593, 7.00497902, 252.25032350, 77.45779628, 48.33076593},
+		Rates:    OrbitalElements{0.00000037, 0.00001906, -0.00594749, 149472.67411175, 0.16047689, -0.12534081},
+	},
+	"venus": {
+		Elements: OrbitalElements{0.72333566, 0.00677672, 3.39467605, 181.97909950, 131.60246718, 76.67984255},
+		Rates:    OrbitalElements{0.00000390, -0.00004107, -0.00078890, 58517.81538729, 0.00268329, -0.27769418},
+	},
+	"mars": {
+		Elements: OrbitalElements{1.52371034, 0.09339410, 1.84969142, -4.55343205, -23.94362959, 49.55953891},
+		Rates:    OrbitalElements{0.00001847, 0.00007882, -0.00813131, 19140.30268499, 0.44441088, -0.29257343},
+	},
+	"jupiter": {
+		Elements: OrbitalElements{5.20288700, 0.04838624, 1.30439695, 34.39644051, 14.72847983, 100.47390909},
+		Rates:    OrbitalElements{-0.00011607, -0.00013253, -0.00183714, 3034.74612775, 0.21252668, 0.20469106},
+	},
+	"saturn": {
+		Elements: OrbitalElements{9.53667594, 0.05386179, 2.48599187, 49.95424423, 92.59887831, 113.66242448},
+		Rates:    OrbitalElements{-0.00125060, -0.00050991, 0.00193609, 1222.49362201, -0.41897216, -0.28867794},
+	},
+	"uranus": {
+		Elements: OrbitalElements{19.18916464, 0.04725744, 0.77263783, 313.23810451, 170.95427630, 74.01692503},
+		Rates:    OrbitalElements{-0.00196176, -0.00004397, -0.00242939, 428.48202785, 0.40805281, 0.04240589},
+	},
+	"neptune": {
+		Elements: OrbitalElements{30.06992276, 0.00859048, 1.77004347, -55.12002969, 44.96476227, 131.78422574},
+		Rates:    OrbitalElements{0.00026291, 0.00005105, 0.00035372, 218.45945325, -0.32241464, -0.00508664},
+	},
+}
+
+// The names of the planets, in order of their distance from the Sun:
+var NAMES = []string{"mercury", "venus", "mars", "jupiter", "saturn", "uranus", "neptune"}
+
+// getJulianCenturies returns the number of Julian centuries since the J2000 epoch:
+func getJulianCenturies(datetime time.Time) float64 {
+	return (dusk.GetJulianDate(datetime.UTC()) - 2451545.0) / 36525
+}
+
+// solveKeplersEquation returns the eccentric anomaly E (in radians) for the mean anomaly M (in radians), i.e., the
+// solution of M = E - e sin(E), by Newton's method:
+func solveKeplersEquation(M float64, e float64) float64 {
+	E := M + e*math.Sin(M)
+
+	for i := 0; i < 10; i++ {
+		ΔE := (E - e*math.Sin(E) - M) / (1 - e*math.Cos(E))
+
+		E -= ΔE
+
+		if math.Abs(ΔE) < 1e-12 {
+			break
+		}
+	}
+
+	return E
+}
+
+// GetHeliocentricEclipticPosition returns the heliocentric rectangular coordinates (in AU) of the planet at the
+// datetime, referred to the mean ecliptic and equinox of J2000.
+// @see Section 8.10 of Standish, E.M. 1992. Keplerian Elements for Approximate Positions of the Major Planets. JPL.
+func GetHeliocentricEclipticPosition(planet Planet, datetime time.Time) (float64, float64, float64) {
+	T := getJulianCenturies(datetime)
+
+	a := planet.Elements.A + planet.Rates.A*T
+
+	e := planet.Elements.E + planet.Rates.E*T
+
+	I := (planet.Elements.I + planet.Rates.I*T) * math.Pi / 180
+
+	L := planet.Elements.L + planet.Rates.L*T
+
+	ϖ := planet.Elements.Perihelion + planet.Rates.Perihelion*T
+
+	Ω := (planet.Elements.Node + planet.Rates.Node*T) * math.Pi / 180
+
+	// The argument of perihelion:
+	ω := ϖ*math.Pi/180 - Ω
+
+	// The mean anomaly, modulo 360° to within (-180°, 180°]:
+	M := math.Remainder(L-ϖ, 360) * math.Pi / 180
+
+	E := solveKeplersEquation(M, e)
+
+	// The coordinates in the plane of the orbit, with the x-axis towards perihelion:
+	x := a * (math.Cos(E) - e)
+
+	y := a * math.Sqrt(1-e*e) * math.Sin(E)
+
+	return (math.Cos(ω)*math.Cos(Ω)-math.Sin(ω)*math.Sin(Ω)*math.Cos(I))*x + (-math.Sin(ω)*math.Cos(Ω)-math.Cos(ω)*math.Sin(Ω)*math.Cos(I))*y,
+		(math.Cos(ω)*math.Sin(Ω)+math.Sin(ω)*math.Cos(Ω)*math.Cos(I))*x + (-math.Sin(ω)*math.Sin(Ω)+math.Cos(ω)*math.Cos(Ω)*math.Cos(I))*y,
+		(math.Sin(ω)*math.Sin(I))*x + (math.Cos(ω)*math.Sin(I))*y
+}
+
+// GetPlanetaryEquatorialPosition returns the geocentric equatorial coordinate of the named planet at the datetime,
+// referred to the equinox of date (neglecting nutation, aberration and light-time, to within a few arcminutes).
+func GetPlanetaryEquatorialPosition(name string, datetime time.Time) (dusk.EquatorialCoordinate, error) {
+	planet, exists := PLANETS[name]
+
+	if !exists {
+		return dusk.EquatorialCoordinate{}, computation.InvalidArgument("planet: %q must be one of %s", name, strings.Join(NAMES, ", "))
+	}
+
+	px, py, pz := GetHeliocentricEclipticPosition(planet, datetime)
+
+	ex, ey, ez := GetHeliocentricEclipticPosition(EARTH_MOON_BARYCENTRE, datetime)
+
+	// The geocentric ecliptic coordinates of the planet (in AU):
+	x, y, z := px-ex, py-ey, pz-ez
+
+	// Precess the ecliptic longitude from the equinox of J2000 to the equinox of date:
+	λ := math.Atan2(y, x) + PRECESSION_RATE*getJulianCenturies(datetime)*math.Pi/180
+
+	r := math.Hypot(x, y)
+
+	x, y = r*math.Cos(λ), r*math.Sin(λ)
+
+	ε := J2000_OBLIQUITY * math.Pi / 180
+
+	// Rotate the ecliptic coordinates about the x-axis (towards the equinox) by the obliquity of the ecliptic:
+	xeq, yeq, zeq := x, y*math.Cos(ε)-z*math.Sin(ε), y*math.Sin(ε)+z*math.Cos(ε)
+
+	ra := math.Atan2(yeq, xeq) * 180 / math.Pi
+
+	// Correct for negative angles:
+	if ra < 0 {
+		ra += 360
+	}
+
+	return dusk.EquatorialCoordinate{
+		RightAscension: ra,
+		Declination:    math.Atan2(zeq, math.Hypot(xeq, yeq)) * 180 / math.Pi,
+	}, nil
+}
+
+// GetPlanetaryEvents returns each of the named planets (or every planet, if none are named) with its rise, maximum and
+// set events (those that occur) on the local day of the datetime, for the observer in the local timezone of the
+// location:
+func GetPlanetaryEvents(ctx context.Context, datetime time.Time, names []string, longitude float64, latitude float64, location *time.Location) ([]events.Body, error) {
+	if len(names) == 0 {
+		names = NAMES
+	}
+
+	bodies := []events.Body{}
+
+	for _, name := range names {
+		eq, err := GetPlanetaryEquatorialPosition(strings.ToLower(name), datetime)
+
+		if err != nil {
+			return nil, err
+		}
+
+		evs, err := transit.GetTransitEvents(ctx, datetime, eq, longitude, latitude, location)
+
+		if err != nil {
+			return nil, err
+		}
+
+		bodies = append(bodies, events.NewBody(strings.ToLower(name), evs...))
+	}
+
+	return bodies, nil
+}
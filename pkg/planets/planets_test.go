@@ -0,0 +1,88 @@
+package planets
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSolveKeplersEquation(t *testing.T) {
+	// @see Example 30.a p.196 of Meeus, Jean. 1991. Astronomical algorithms. Richmond, Va: Willmann-Bell.
+	E := solveKeplersEquation(5*math.Pi/180, 0.1)
+
+	assert.InDelta(t, 5.554589, E*180/math.Pi, 0.000001)
+}
+
+func TestGetPlanetaryEquatorialPositionVenus(t *testing.T) {
+	// @see Example 33.a p.225 of Meeus, Jean. 1991. Astronomical algorithms. Richmond, Va: Willmann-Bell.
+	eq, err := GetPlanetaryEquatorialPosition("venus", time.Date(1992, 12, 20, 0, 0, 0, 0, time.UTC))
+
+	assert.Nil(t, err)
+
+	// Assert that the position is within a few arcminutes of the apparent position, α = 21h04m41.454s, δ = -18°53'16.84":
+	assert.InDelta(t, 316.17273, eq.RightAscension, 0.05)
+	assert.InDelta(t, -18.88801, eq.Declination, 0.05)
+}
+
+func TestGetPlanetaryEquatorialPositionUnknown(t *testing.T) {
+	_, err := GetPlanetaryEquatorialPosition("pluto", time.Now())
+
+	assert.ErrorContains(t, err, `planet: "pluto" must be one of mercury, venus, mars, jupiter, saturn, uranus, neptune`)
+}
+
+func TestGetPlanetaryEvents(t *testing.T) {
+	location, _ := time.LoadLocation("Pacific/Honolulu")
+
+	datetime := time.Date(2021, 5, 14, 0, 0, 0, 0, location)
+
+	bodies, err := GetPlanetaryEvents(context.Background(), datetime, []string{"Mars"}, -155.468094, 19.798484, location)
+
+	assert.Nil(t, err)
+	assert.Len(t, bodies, 1)
+	assert.Equal(t, "mars", bodies[0].Name)
+
+	// The rise, maximum and set of Mars (in Gemini) on 2021-05-14 at Mauna Kea:
+	expected := []struct {
+		kind  string
+		local string
+		alt   float64
+	}{
+		{"rise", "2021-05-14T09:09:35-10:00", 1.2110920842304695},
+		{"maximum", "2021-05-14T13:40:35-10:00", 61.92588633753707},
+		{"set", "2021-05-14T22:22:01-10:00", -1.3362605671154406},
+	}
+
+	assert.Len(t, bodies[0].Events, len(expected))
+
+	for i, event := range bodies[0].Events {
+		assert.Equal(t, expected[i].kind, event.Type)
+		assert.Equal(t, expected[i].local, event.Local.Format(time.RFC3339))
+		assert.InDelta(t, expected[i].alt, event.Horizontal.Altitude, 0.0001)
+		assert.InDelta(t, 104.00081, event.Equatorial.RightAscension, 0.0001)
+		assert.InDelta(t, 24.23385, event.Equatorial.Declination, 0.0001)
+	}
+}
+
+func TestGetPlanetaryEventsDefault(t *testing.T) {
+	location, _ := time.LoadLocation("Pacific/Honolulu")
+
+	bodies, err := GetPlanetaryEvents(context.Background(), time.Date(2021, 5, 14, 0, 0, 0, 0, location), nil, -155.468094, 19.798484, location)
+
+	assert.Nil(t, err)
+
+	names := []string{}
+
+	for _, body := range bodies {
+		names = append(names, body.Name)
+	}
+
+	// Assert that every planet is returned when none are given:
+	assert.Equal(t, NAMES, names)
+
+	_, err = GetPlanetaryEvents(context.Background(), time.Now(), []string{"pluto"}, -155.468094, 19.798484, location)
+
+	assert.ErrorContains(t, err, `planet: "pluto" must be one of`)
+}
@@ -0,0 +1,1473 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        (unknown)
+// source: nocturnal/v1/nocturnal.proto
+
+package nocturnalv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// EventType is the type of an event of a body.
+type EventType int32
+
+const (
+	EventType_EVENT_TYPE_UNSPECIFIED       EventType = 0
+	EventType_EVENT_TYPE_RISE              EventType = 1
+	EventType_EVENT_TYPE_MAXIMUM           EventType = 2
+	EventType_EVENT_TYPE_SET               EventType = 3
+	EventType_EVENT_TYPE_CIVIL_DUSK        EventType = 4
+	EventType_EVENT_TYPE_NAUTICAL_DUSK     EventType = 5
+	EventType_EVENT_TYPE_ASTRONOMICAL_DUSK EventType = 6
+	EventType_EVENT_TYPE_ASTRONOMICAL_DAWN EventType = 7
+	EventType_EVENT_TYPE_NAUTICAL_DAWN     EventType = 8
+	EventType_EVENT_TYPE_CIVIL_DAWN        EventType = 9
+)
+
+// Enum value maps for EventType.
+var (
+	EventType_name = map[int32]string{
+		0: "EVENT_TYPE_UNSPECIFIED",
+		1: "EVENT_TYPE_RISE",
+		2: "EVENT_TYPE_MAXIMUM",
+		3: "EVENT_TYPE_SET",
+		4: "EVENT_TYPE_CIVIL_DUSK",
+		5: "EVENT_TYPE_NAUTICAL_DUSK",
+		6: "EVENT_TYPE_ASTRONOMICAL_DUSK",
+		7: "EVENT_TYPE_ASTRONOMICAL_DAWN",
+		8: "EVENT_TYPE_NAUTICAL_DAWN",
+		9: "EVENT_TYPE_CIVIL_DAWN",
+	}
+	EventType_value = map[string]int32{
+		"EVENT_TYPE_UNSPECIFIED":       0,
+		"EVENT_TYPE_RISE":              1,
+		"EVENT_TYPE_MAXIMUM":           2,
+		"EVENT_TYPE_SET":               3,
+		"EVENT_TYPE_CIVIL_DUSK":        4,
+		"EVENT_TYPE_NAUTICAL_DUSK":     5,
+		"EVENT_TYPE_ASTRONOMICAL_DUSK": 6,
+		"EVENT_TYPE_ASTRONOMICAL_DAWN": 7,
+		"EVENT_TYPE_NAUTICAL_DAWN":     8,
+		"EVENT_TYPE_CIVIL_DAWN":        9,
+	}
+)
+
+func (x EventType) Enum() *EventType {
+	p := new(EventType)
+	*p = x
+	return p
+}
+
+func (x EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_nocturnal_v1_nocturnal_proto_enumTypes[0].Descriptor()
+}
+
+func (EventType) Type() protoreflect.EnumType {
+	return &file_nocturnal_v1_nocturnal_proto_enumTypes[0]
+}
+
+func (x EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventType.Descriptor instead.
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return file_nocturnal_v1_nocturnal_proto_rawDescGZIP(), []int{0}
+}
+
+// Observer is the observer's datetime and geographic coordinates.
+type Observer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The datetime of the observation, defaulting to the current time.
+	Datetime *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=datetime,proto3" json:"datetime,omitempty"`
+	// The longitude of the observer, in degrees east of the Greenwich meridian.
+	Longitude float64 `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	// The latitude of the observer, in degrees north of the equator.
+	Latitude float64 `protobuf:"fixed64,3,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	// The elevation of the observer, in metres above sea level.
+	Elevation float64 `protobuf:"fixed64,4,opt,name=elevation,proto3" json:"elevation,omitempty"`
+	// The IANA timezone of the observer (e.g., "Pacific/Honolulu"), resolved from its coordinates in every response.
+	Timezone string `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"`
+}
+
+func (x *Observer) Reset() {
+	*x = Observer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nocturnal_v1_nocturnal_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Observer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Observer) ProtoMessage() {}
+
+func (x *Observer) ProtoReflect() protoreflect.Message {
+	mi := &file_nocturnal_v1_nocturnal_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Observer.ProtoReflect.Descriptor instead.
+func (*Observer) Descriptor() ([]byte, []int) {
+	return file_nocturnal_v1_nocturnal_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Observer) GetDatetime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Datetime
+	}
+	return nil
+}
+
+func (x *Observer) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *Observer) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Observer) GetElevation() float64 {
+	if x != nil {
+		return x.Elevation
+	}
+	return 0
+}
+
+func (x *Observer) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+// HorizontalCoordinate is the position of a body in the observer's sky.
+type HorizontalCoordinate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The altitude of the body above the horizon, in degrees.
+	Altitude float64 `protobuf:"fixed64,1,opt,name=altitude,proto3" json:"altitude,omitempty"`
+	// The azimuth of the body east of north, in degrees.
+	Azimuth float64 `protobuf:"fixed64,2,opt,name=azimuth,proto3" json:"azimuth,omitempty"`
+}
+
+func (x *HorizontalCoordinate) Reset() {
+	*x = HorizontalCoordinate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nocturnal_v1_nocturnal_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HorizontalCoordinate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HorizontalCoordinate) ProtoMessage() {}
+
+func (x *HorizontalCoordinate) ProtoReflect() protoreflect.Message {
+	mi := &file_nocturnal_v1_nocturnal_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HorizontalCoordinate.ProtoReflect.Descriptor instead.
+func (*HorizontalCoordinate) Descriptor() ([]byte, []int) {
+	return file_nocturnal_v1_nocturnal_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *HorizontalCoordinate) GetAltitude() float64 {
+	if x != nil {
+		return x.Altitude
+	}
+	return 0
+}
+
+func (x *HorizontalCoordinate) GetAzimuth() float64 {
+	if x != nil {
+		return x.Azimuth
+	}
+	return 0
+}
+
+// EquatorialCoordinate is the position of a body on the celestial sphere.
+type EquatorialCoordinate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The right ascension of the body, in degrees.
+	RightAscension float64 `protobuf:"fixed64,1,opt,name=right_ascension,json=rightAscension,proto3" json:"right_ascension,omitempty"`
+	// The declination of the body, in degrees.
+	Declination float64 `protobuf:"fixed64,2,opt,name=declination,proto3" json:"declination,omitempty"`
+}
+
+func (x *EquatorialCoordinate) Reset() {
+	*x = EquatorialCoordinate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nocturnal_v1_nocturnal_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EquatorialCoordinate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EquatorialCoordinate) ProtoMessage() {}
+
+func (x *EquatorialCoordinate) ProtoReflect() protoreflect.Message {
+	mi := &file_nocturnal_v1_nocturnal_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EquatorialCoordinate.ProtoReflect.Descriptor instead.
+func (*EquatorialCoordinate) Descriptor() ([]byte, []int) {
+	return file_nocturnal_v1_nocturnal_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EquatorialCoordinate) GetRightAscension() float64 {
+	if x != nil {
+		return x.RightAscension
+	}
+	return 0
+}
+
+func (x *EquatorialCoordinate) GetDeclination() float64 {
+	if x != nil {
+		return x.Declination
+	}
+	return 0
+}
+
+// Event is an event of a body, with its position at the time of the event.
+type Event struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The type of the event.
+	Type EventType `protobuf:"varint,1,opt,name=type,proto3,enum=nocturnal.v1.EventType" json:"type,omitempty"`
+	// The datetime of the event.
+	Time *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	// The datetime of the event in the observer's local timezone, as an RFC3339 timestamp.
+	LocalTime string `protobuf:"bytes,3,opt,name=local_time,json=localTime,proto3" json:"local_time,omitempty"`
+	// The position of the body in the observer's sky at the event.
+	Horizontal *HorizontalCoordinate `protobuf:"bytes,4,opt,name=horizontal,proto3" json:"horizontal,omitempty"`
+	// The position of the body on the celestial sphere at the event.
+	Equatorial *EquatorialCoordinate `protobuf:"bytes,5,opt,name=equatorial,proto3" json:"equatorial,omitempty"`
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nocturnal_v1_nocturnal_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_nocturnal_v1_nocturnal_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_nocturnal_v1_nocturnal_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Event) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *Event) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *Event) GetLocalTime() string {
+	if x != nil {
+		return x.LocalTime
+	}
+	return ""
+}
+
+func (x *Event) GetHorizontal() *HorizontalCoordinate {
+	if x != nil {
+		return x.Horizontal
+	}
+	return nil
+}
+
+func (x *Event) GetEquatorial() *EquatorialCoordinate {
+	if x != nil {
+		return x.Equatorial
+	}
+	return nil
+}
+
+// Body is a body (e.g., the Sun) and its events, in chronological order.
+type Body struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the body, e.g., "sun", "moon" or "target".
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The events of the body, in chronological order.
+	Events []*Event `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+}
+
+func (x *Body) Reset() {
+	*x = Body{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nocturnal_v1_nocturnal_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Body) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Body) ProtoMessage() {}
+
+func (x *Body) ProtoReflect() protoreflect.Message {
+	mi := &file_nocturnal_v1_nocturnal_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Body.ProtoReflect.Descriptor instead.
+func (*Body) Descriptor() ([]byte, []int) {
+	return file_nocturnal_v1_nocturnal_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Body) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Body) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type GetSunRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Observer *Observer `protobuf:"bytes,1,opt,name=observer,proto3" json:"observer,omitempty"`
+}
+
+func (x *GetSunRequest) Reset() {
+	*x = GetSunRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nocturnal_v1_nocturnal_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSunRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSunRequest) ProtoMessage() {}
+
+func (x *GetSunRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nocturnal_v1_nocturnal_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSunRequest.ProtoReflect.Descriptor instead.
+func (*GetSunRequest) Descriptor() ([]byte, []int) {
+	return file_nocturnal_v1_nocturnal_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetSunRequest) GetObserver() *Observer {
+	if x != nil {
+		return x.Observer
+	}
+	return nil
+}
+
+type GetSunResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Observer *Observer `protobuf:"bytes,1,opt,name=observer,proto3" json:"observer,omitempty"`
+	Body     *Body     `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+}
+
+func (x *GetSunResponse) Reset() {
+	*x = GetSunResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nocturnal_v1_nocturnal_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSunResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSunResponse) ProtoMessage() {}
+
+func (x *GetSunResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nocturnal_v1_nocturnal_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSunResponse.ProtoReflect.Descriptor instead.
+func (*GetSunResponse) Descriptor() ([]byte, []int) {
+	return file_nocturnal_v1_nocturnal_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetSunResponse) GetObserver() *Observer {
+	if x != nil {
+		return x.Observer
+	}
+	return nil
+}
+
+func (x *GetSunResponse) GetBody() *Body {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+type GetMoonRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Observer *Observer `protobuf:"bytes,1,opt,name=observer,proto3" json:"observer,omitempty"`
+}
+
+func (x *GetMoonRequest) Reset() {
+	*x = GetMoonRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nocturnal_v1_nocturnal_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetMoonRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMoonRequest) ProtoMessage() {}
+
+func (x *GetMoonRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nocturnal_v1_nocturnal_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMoonRequest.ProtoReflect.Descriptor instead.
+func (*GetMoonRequest) Descriptor() ([]byte, []int) {
+	return file_nocturnal_v1_nocturnal_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetMoonRequest) GetObserver() *Observer {
+	if x != nil {
+		return x.Observer
+	}
+	return nil
+}
+
+type GetMoonResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Observer *Observer `protobuf:"bytes,1,opt,name=observer,proto3" json:"observer,omitempty"`
+	Body     *Body     `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+}
+
+func (x *GetMoonResponse) Reset() {
+	*x = GetMoonResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nocturnal_v1_nocturnal_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetMoonResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMoonResponse) ProtoMessage() {}
+
+func (x *GetMoonResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nocturnal_v1_nocturnal_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMoonResponse.ProtoReflect.Descriptor instead.
+func (*GetMoonResponse) Descriptor() ([]byte, []int) {
+	return file_nocturnal_v1_nocturnal_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetMoonResponse) GetObserver() *Observer {
+	if x != nil {
+		return x.Observer
+	}
+	return nil
+}
+
+func (x *GetMoonResponse) GetBody() *Body {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+type GetTwilightRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Observer *Observer `protobuf:"bytes,1,opt,name=observer,proto3" json:"observer,omitempty"`
+}
+
+func (x *GetTwilightRequest) Reset() {
+	*x = GetTwilightRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nocturnal_v1_nocturnal_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTwilightRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTwilightRequest) ProtoMessage() {}
+
+func (x *GetTwilightRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nocturnal_v1_nocturnal_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTwilightRequest.ProtoReflect.Descriptor instead.
+func (*GetTwilightRequest) Descriptor() ([]byte, []int) {
+	return file_nocturnal_v1_nocturnal_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetTwilightRequest) GetObserver() *Observer {
+	if x != nil {
+		return x.Observer
+	}
+	return nil
+}
+
+type GetTwilightResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Observer *Observer `protobuf:"bytes,1,opt,name=observer,proto3" json:"observer,omitempty"`
+	Body     *Body     `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+}
+
+func (x *GetTwilightResponse) Reset() {
+	*x = GetTwilightResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nocturnal_v1_nocturnal_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTwilightResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTwilightResponse) ProtoMessage() {}
+
+func (x *GetTwilightResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nocturnal_v1_nocturnal_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTwilightResponse.ProtoReflect.Descriptor instead.
+func (*GetTwilightResponse) Descriptor() ([]byte, []int) {
+	return file_nocturnal_v1_nocturnal_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetTwilightResponse) GetObserver() *Observer {
+	if x != nil {
+		return x.Observer
+	}
+	return nil
+}
+
+func (x *GetTwilightResponse) GetBody() *Body {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+type GetTransitRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Observer *Observer `protobuf:"bytes,1,opt,name=observer,proto3" json:"observer,omitempty"`
+	// The position of the target on the celestial sphere.
+	Target *EquatorialCoordinate `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+}
+
+func (x *GetTransitRequest) Reset() {
+	*x = GetTransitRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nocturnal_v1_nocturnal_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTransitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransitRequest) ProtoMessage() {}
+
+func (x *GetTransitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nocturnal_v1_nocturnal_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransitRequest.ProtoReflect.Descriptor instead.
+func (*GetTransitRequest) Descriptor() ([]byte, []int) {
+	return file_nocturnal_v1_nocturnal_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetTransitRequest) GetObserver() *Observer {
+	if x != nil {
+		return x.Observer
+	}
+	return nil
+}
+
+func (x *GetTransitRequest) GetTarget() *EquatorialCoordinate {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+type GetTransitResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Observer *Observer `protobuf:"bytes,1,opt,name=observer,proto3" json:"observer,omitempty"`
+	Body     *Body     `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+}
+
+func (x *GetTransitResponse) Reset() {
+	*x = GetTransitResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nocturnal_v1_nocturnal_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTransitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransitResponse) ProtoMessage() {}
+
+func (x *GetTransitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nocturnal_v1_nocturnal_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransitResponse.ProtoReflect.Descriptor instead.
+func (*GetTransitResponse) Descriptor() ([]byte, []int) {
+	return file_nocturnal_v1_nocturnal_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetTransitResponse) GetObserver() *Observer {
+	if x != nil {
+		return x.Observer
+	}
+	return nil
+}
+
+func (x *GetTransitResponse) GetBody() *Body {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+type StreamEphemerisRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Observer *Observer `protobuf:"bytes,1,opt,name=observer,proto3" json:"observer,omitempty"`
+	// The name of the body, i.e., "sun", "moon", any planet (e.g., "mars"), or "target" for the target below.
+	Body string `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	// The position of the target on the celestial sphere, when the body is "target".
+	Target *EquatorialCoordinate `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`
+	// The interval between each position, defaulting to 1 hour.
+	Interval *durationpb.Duration `protobuf:"bytes,4,opt,name=interval,proto3" json:"interval,omitempty"`
+	// The number of positions to stream, defaulting to 24.
+	Count uint32 `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *StreamEphemerisRequest) Reset() {
+	*x = StreamEphemerisRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nocturnal_v1_nocturnal_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamEphemerisRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamEphemerisRequest) ProtoMessage() {}
+
+func (x *StreamEphemerisRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nocturnal_v1_nocturnal_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamEphemerisRequest.ProtoReflect.Descriptor instead.
+func (*StreamEphemerisRequest) Descriptor() ([]byte, []int) {
+	return file_nocturnal_v1_nocturnal_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *StreamEphemerisRequest) GetObserver() *Observer {
+	if x != nil {
+		return x.Observer
+	}
+	return nil
+}
+
+func (x *StreamEphemerisRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *StreamEphemerisRequest) GetTarget() *EquatorialCoordinate {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *StreamEphemerisRequest) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *StreamEphemerisRequest) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type StreamEphemerisResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The datetime of the position.
+	Time *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	// The position of the body in the observer's sky.
+	Horizontal *HorizontalCoordinate `protobuf:"bytes,2,opt,name=horizontal,proto3" json:"horizontal,omitempty"`
+	// The position of the body on the celestial sphere.
+	Equatorial *EquatorialCoordinate `protobuf:"bytes,3,opt,name=equatorial,proto3" json:"equatorial,omitempty"`
+}
+
+func (x *StreamEphemerisResponse) Reset() {
+	*x = StreamEphemerisResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nocturnal_v1_nocturnal_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamEphemerisResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamEphemerisResponse) ProtoMessage() {}
+
+func (x *StreamEphemerisResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nocturnal_v1_nocturnal_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamEphemerisResponse.ProtoReflect.Descriptor instead.
+func (*StreamEphemerisResponse) Descriptor() ([]byte, []int) {
+	return file_nocturnal_v1_nocturnal_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *StreamEphemerisResponse) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *StreamEphemerisResponse) GetHorizontal() *HorizontalCoordinate {
+	if x != nil {
+		return x.Horizontal
+	}
+	return nil
+}
+
+func (x *StreamEphemerisResponse) GetEquatorial() *EquatorialCoordinate {
+	if x != nil {
+		return x.Equatorial
+	}
+	return nil
+}
+
+var File_nocturnal_v1_nocturnal_proto protoreflect.FileDescriptor
+
+var file_nocturnal_v1_nocturnal_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x6e, 0x6f, 0x63, 0x74, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x76, 0x31, 0x2f, 0x6e,
+	0x6f, 0x63, 0x74, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c,
+	0x6e, 0x6f, 0x63, 0x74, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x1a, 0x1e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb6, 0x01,
+	0x0a, 0x08, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x36, 0x0a, 0x08, 0x64, 0x61,
+	0x74, 0x65, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x64, 0x61, 0x74, 0x65, 0x74, 0x69,
+	0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09,
+	0x65, 0x6c, 0x65, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x09, 0x65, 0x6c, 0x65, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x69,
+	0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69,
+	0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x22, 0x4c, 0x0a, 0x14, 0x48, 0x6f, 0x72, 0x69, 0x7a, 0x6f,
+	0x6e, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x61, 0x6c, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x08, 0x61, 0x6c, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x7a,
+	0x69, 0x6d, 0x75, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x61, 0x7a, 0x69,
+	0x6d, 0x75, 0x74, 0x68, 0x22, 0x61, 0x0a, 0x14, 0x45, 0x71, 0x75, 0x61, 0x74, 0x6f, 0x72, 0x69,
+	0x61, 0x6c, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x12, 0x27, 0x0a, 0x0f,
+	0x72, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x61, 0x73, 0x63, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x72, 0x69, 0x67, 0x68, 0x74, 0x41, 0x73, 0x63, 0x65,
+	0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x63, 0x6c, 0x69, 0x6e, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x64, 0x65, 0x63, 0x6c,
+	0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x8b, 0x02, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x12, 0x2b, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x17, 0x2e, 0x6e, 0x6f, 0x63, 0x74, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x2e,
+	0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x42, 0x0a,
+	0x0a, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x6f, 0x6e, 0x74, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x22, 0x2e, 0x6e, 0x6f, 0x63, 0x74, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31,
+	0x2e, 0x48, 0x6f, 0x72, 0x69, 0x7a, 0x6f, 0x6e, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x6f, 0x72, 0x64,
+	0x69, 0x6e, 0x61, 0x74, 0x65, 0x52, 0x0a, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x6f, 0x6e, 0x74, 0x61,
+	0x6c, 0x12, 0x42, 0x0a, 0x0a, 0x65, 0x71, 0x75, 0x61, 0x74, 0x6f, 0x72, 0x69, 0x61, 0x6c, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6e, 0x6f, 0x63, 0x74, 0x75, 0x72, 0x6e, 0x61,
+	0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x71, 0x75, 0x61, 0x74, 0x6f, 0x72, 0x69, 0x61, 0x6c, 0x43,
+	0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x52, 0x0a, 0x65, 0x71, 0x75, 0x61, 0x74,
+	0x6f, 0x72, 0x69, 0x61, 0x6c, 0x22, 0x47, 0x0a, 0x04, 0x42, 0x6f, 0x64, 0x79, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x2b, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x6e, 0x6f, 0x63, 0x74, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31,
+	0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x43,
+	0x0a, 0x0d, 0x47, 0x65, 0x74, 0x53, 0x75, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x32, 0x0a, 0x08, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x6e, 0x6f, 0x63, 0x74, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31,
+	0x2e, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x08, 0x6f, 0x62, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x22, 0x6c, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x53, 0x75, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x08, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6e, 0x6f, 0x63, 0x74, 0x75, 0x72,
+	0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52,
+	0x08, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x04, 0x62, 0x6f, 0x64,
+	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6e, 0x6f, 0x63, 0x74, 0x75, 0x72,
+	0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6f, 0x64, 0x79, 0x52, 0x04, 0x62, 0x6f, 0x64,
+	0x79, 0x22, 0x44, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x32, 0x0a, 0x08, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6e, 0x6f, 0x63, 0x74, 0x75, 0x72, 0x6e, 0x61,
+	0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x08, 0x6f,
+	0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x22, 0x6d, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4d, 0x6f,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x08, 0x6f, 0x62,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6e,
+	0x6f, 0x63, 0x74, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x62, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x52, 0x08, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x26,
+	0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6e,
+	0x6f, 0x63, 0x74, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6f, 0x64, 0x79,
+	0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x22, 0x48, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x54, 0x77, 0x69,
+	0x6c, 0x69, 0x67, 0x68, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x32, 0x0a, 0x08,
+	0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x6e, 0x6f, 0x63, 0x74, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x62,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x08, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x22, 0x71, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x54, 0x77, 0x69, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x08, 0x6f, 0x62, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6e, 0x6f, 0x63, 0x74,
+	0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x52, 0x08, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x04, 0x62,
+	0x6f, 0x64, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6e, 0x6f, 0x63, 0x74,
+	0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6f, 0x64, 0x79, 0x52, 0x04, 0x62,
+	0x6f, 0x64, 0x79, 0x22, 0x83, 0x01, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x32, 0x0a, 0x08, 0x6f, 0x62, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6e, 0x6f,
+	0x63, 0x74, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x62, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x52, 0x08, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x3a, 0x0a,
+	0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e,
+	0x6e, 0x6f, 0x63, 0x74, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x71, 0x75,
+	0x61, 0x74, 0x6f, 0x72, 0x69, 0x61, 0x6c, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74,
+	0x65, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0x70, 0x0a, 0x12, 0x47, 0x65, 0x74,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x32, 0x0a, 0x08, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x6e, 0x6f, 0x63, 0x74, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31,
+	0x2e, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x08, 0x6f, 0x62, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x6e, 0x6f, 0x63, 0x74, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31,
+	0x2e, 0x42, 0x6f, 0x64, 0x79, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x22, 0xe9, 0x01, 0x0a, 0x16,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x45, 0x70, 0x68, 0x65, 0x6d, 0x65, 0x72, 0x69, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x32, 0x0a, 0x08, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6e, 0x6f, 0x63, 0x74, 0x75,
+	0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x52, 0x08, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6f,
+	0x64, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x12, 0x3a,
+	0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22,
+	0x2e, 0x6e, 0x6f, 0x63, 0x74, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x71,
+	0x75, 0x61, 0x74, 0x6f, 0x72, 0x69, 0x61, 0x6c, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61,
+	0x74, 0x65, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x35, 0x0a, 0x08, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xd1, 0x01, 0x0a, 0x17, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x45, 0x70, 0x68, 0x65, 0x6d, 0x65, 0x72, 0x69, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74,
+	0x69, 0x6d, 0x65, 0x12, 0x42, 0x0a, 0x0a, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x6f, 0x6e, 0x74, 0x61,
+	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6e, 0x6f, 0x63, 0x74, 0x75, 0x72,
+	0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f, 0x72, 0x69, 0x7a, 0x6f, 0x6e, 0x74, 0x61,
+	0x6c, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x52, 0x0a, 0x68, 0x6f, 0x72,
+	0x69, 0x7a, 0x6f, 0x6e, 0x74, 0x61, 0x6c, 0x12, 0x42, 0x0a, 0x0a, 0x65, 0x71, 0x75, 0x61, 0x74,
+	0x6f, 0x72, 0x69, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6e, 0x6f,
+	0x63, 0x74, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x71, 0x75, 0x61, 0x74,
+	0x6f, 0x72, 0x69, 0x61, 0x6c, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x52,
+	0x0a, 0x65, 0x71, 0x75, 0x61, 0x74, 0x6f, 0x72, 0x69, 0x61, 0x6c, 0x2a, 0x9e, 0x02, 0x0a, 0x09,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x16, 0x45, 0x56, 0x45,
+	0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54,
+	0x59, 0x50, 0x45, 0x5f, 0x52, 0x49, 0x53, 0x45, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12, 0x45, 0x56,
+	0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4d, 0x41, 0x58, 0x49, 0x4d, 0x55, 0x4d,
+	0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x53, 0x45, 0x54, 0x10, 0x03, 0x12, 0x19, 0x0a, 0x15, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x49, 0x56, 0x49, 0x4c, 0x5f, 0x44, 0x55, 0x53, 0x4b, 0x10,
+	0x04, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x4e, 0x41, 0x55, 0x54, 0x49, 0x43, 0x41, 0x4c, 0x5f, 0x44, 0x55, 0x53, 0x4b, 0x10, 0x05, 0x12,
+	0x20, 0x0a, 0x1c, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x41, 0x53,
+	0x54, 0x52, 0x4f, 0x4e, 0x4f, 0x4d, 0x49, 0x43, 0x41, 0x4c, 0x5f, 0x44, 0x55, 0x53, 0x4b, 0x10,
+	0x06, 0x12, 0x20, 0x0a, 0x1c, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x41, 0x53, 0x54, 0x52, 0x4f, 0x4e, 0x4f, 0x4d, 0x49, 0x43, 0x41, 0x4c, 0x5f, 0x44, 0x41, 0x57,
+	0x4e, 0x10, 0x07, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50,
+	0x45, 0x5f, 0x4e, 0x41, 0x55, 0x54, 0x49, 0x43, 0x41, 0x4c, 0x5f, 0x44, 0x41, 0x57, 0x4e, 0x10,
+	0x08, 0x12, 0x19, 0x0a, 0x15, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x43, 0x49, 0x56, 0x49, 0x4c, 0x5f, 0x44, 0x41, 0x57, 0x4e, 0x10, 0x09, 0x32, 0xa6, 0x03, 0x0a,
+	0x10, 0x4e, 0x6f, 0x63, 0x74, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x43, 0x0a, 0x06, 0x47, 0x65, 0x74, 0x53, 0x75, 0x6e, 0x12, 0x1b, 0x2e, 0x6e, 0x6f,
+	0x63, 0x74, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x75,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x6e, 0x6f, 0x63, 0x74, 0x75,
+	0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x75, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x6f,
+	0x6e, 0x12, 0x1c, 0x2e, 0x6e, 0x6f, 0x63, 0x74, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1d, 0x2e, 0x6e, 0x6f, 0x63, 0x74, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x4d, 0x6f, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52,
+	0x0a, 0x0b, 0x47, 0x65, 0x74, 0x54, 0x77, 0x69, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x12, 0x20, 0x2e,
+	0x6e, 0x6f, 0x63, 0x74, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x54, 0x77, 0x69, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x21, 0x2e, 0x6e, 0x6f, 0x63, 0x74, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x54, 0x77, 0x69, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x4f, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74,
+	0x12, 0x1f, 0x2e, 0x6e, 0x6f, 0x63, 0x74, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x20, 0x2e, 0x6e, 0x6f, 0x63, 0x74, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x60, 0x0a, 0x0f, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x45, 0x70, 0x68,
+	0x65, 0x6d, 0x65, 0x72, 0x69, 0x73, 0x12, 0x24, 0x2e, 0x6e, 0x6f, 0x63, 0x74, 0x75, 0x72, 0x6e,
+	0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x45, 0x70, 0x68, 0x65,
+	0x6d, 0x65, 0x72, 0x69, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x6e,
+	0x6f, 0x63, 0x74, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x45, 0x70, 0x68, 0x65, 0x6d, 0x65, 0x72, 0x69, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x30, 0x01, 0x42, 0x44, 0x5a, 0x42, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x6c, 0x79, 0x2f, 0x6e,
+	0x6f, 0x63, 0x74, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2f, 0x6e, 0x6f, 0x63, 0x74, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x76, 0x31, 0x3b,
+	0x6e, 0x6f, 0x63, 0x74, 0x75, 0x72, 0x6e, 0x61, 0x6c, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_nocturnal_v1_nocturnal_proto_rawDescOnce sync.Once
+	file_nocturnal_v1_nocturnal_proto_rawDescData = file_nocturnal_v1_nocturnal_proto_rawDesc
+)
+
+func file_nocturnal_v1_nocturnal_proto_rawDescGZIP() []byte {
+	file_nocturnal_v1_nocturnal_proto_rawDescOnce.Do(func() {
+		file_nocturnal_v1_nocturnal_proto_rawDescData = protoimpl.X.CompressGZIP(file_nocturnal_v1_nocturnal_proto_rawDescData)
+	})
+	return file_nocturnal_v1_nocturnal_proto_rawDescData
+}
+
+var file_nocturnal_v1_nocturnal_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_nocturnal_v1_nocturnal_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_nocturnal_v1_nocturnal_proto_goTypes = []interface{}{
+	(EventType)(0),                  // 0: nocturnal.v1.EventType
+	(*Observer)(nil),                // 1: nocturnal.v1.Observer
+	(*HorizontalCoordinate)(nil),    // 2: nocturnal.v1.HorizontalCoordinate
+	(*EquatorialCoordinate)(nil),    // 3: nocturnal.v1.EquatorialCoordinate
+	(*Event)(nil),                   // 4: nocturnal.v1.Event
+	(*Body)(nil),                    // 5: nocturnal.v1.Body
+	(*GetSunRequest)(nil),           // 6: nocturnal.v1.GetSunRequest
+	(*GetSunResponse)(nil),          // 7: nocturnal.v1.GetSunResponse
+	(*GetMoonRequest)(nil),          // 8: nocturnal.v1.GetMoonRequest
+	(*GetMoonResponse)(nil),         // 9: nocturnal.v1.GetMoonResponse
+	(*GetTwilightRequest)(nil),      // 10: nocturnal.v1.GetTwilightRequest
+	(*GetTwilightResponse)(nil),     // 11: nocturnal.v1.GetTwilightResponse
+	(*GetTransitRequest)(nil),       // 12: nocturnal.v1.GetTransitRequest
+	(*GetTransitResponse)(nil),      // 13: nocturnal.v1.GetTransitResponse
+	(*StreamEphemerisRequest)(nil),  // 14: nocturnal.v1.StreamEphemerisRequest
+	(*StreamEphemerisResponse)(nil), // 15: nocturnal.v1.StreamEphemerisResponse
+	(*timestamppb.Timestamp)(nil),   // 16: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),     // 17: google.protobuf.Duration
+}
+var file_nocturnal_v1_nocturnal_proto_depIdxs = []int32{
+	16, // 0: nocturnal.v1.Observer.datetime:type_name -> google.protobuf.Timestamp
+	0,  // 1: nocturnal.v1.Event.type:type_name -> nocturnal.v1.EventType
+	16, // 2: nocturnal.v1.Event.time:type_name -> google.protobuf.Timestamp
+	2,  // 3: nocturnal.v1.Event.horizontal:type_name -> nocturnal.v1.HorizontalCoordinate
+	3,  // 4: nocturnal.v1.Event.equatorial:type_name -> nocturnal.v1.EquatorialCoordinate
+	4,  // 5: nocturnal.v1.Body.events:type_name -> nocturnal.v1.Event
+	1,  // 6: nocturnal.v1.GetSunRequest.observer:type_name -> nocturnal.v1.Observer
+	1,  // 7: nocturnal.v1.GetSunResponse.observer:type_name -> nocturnal.v1.Observer
+	5,  // 8: nocturnal.v1.GetSunResponse.body:type_name -> nocturnal.v1.Body
+	1,  // 9: nocturnal.v1.GetMoonRequest.observer:type_name -> nocturnal.v1.Observer
+	1,  // 10: nocturnal.v1.GetMoonResponse.observer:type_name -> nocturnal.v1.Observer
+	5,  // 11: nocturnal.v1.GetMoonResponse.body:type_name -> nocturnal.v1.Body
+	1,  // 12: nocturnal.v1.GetTwilightRequest.observer:type_name -> nocturnal.v1.Observer
+	1,  // 13: nocturnal.v1.GetTwilightResponse.observer:type_name -> nocturnal.v1.Observer
+	5,  // 14: nocturnal.v1.GetTwilightResponse.body:type_name -> nocturnal.v1.Body
+	1,  // 15: nocturnal.v1.GetTransitRequest.observer:type_name -> nocturnal.v1.Observer
+	3,  // 16: nocturnal.v1.GetTransitRequest.target:type_name -> nocturnal.v1.EquatorialCoordinate
+	1,  // 17: nocturnal.v1.GetTransitResponse.observer:type_name -> nocturnal.v1.Observer
+	5,  // 18: nocturnal.v1.GetTransitResponse.body:type_name -> nocturnal.v1.Body
+	1,  // 19: nocturnal.v1.StreamEphemerisRequest.observer:type_name -> nocturnal.v1.Observer
+	3,  // 20: nocturnal.v1.StreamEphemerisRequest.target:type_name -> nocturnal.v1.EquatorialCoordinate
+	17, // 21: nocturnal.v1.StreamEphemerisRequest.interval:type_name -> google.protobuf.Duration
+	16, // 22: nocturnal.v1.StreamEphemerisResponse.time:type_name -> google.protobuf.Timestamp
+	2,  // 23: nocturnal.v1.StreamEphemerisResponse.horizontal:type_name -> nocturnal.v1.HorizontalCoordinate
+	3,  // 24: nocturnal.v1.StreamEphemerisResponse.equatorial:type_name -> nocturnal.v1.EquatorialCoordinate
+	6,  // 25: nocturnal.v1.NocturnalService.GetSun:input_type -> nocturnal.v1.GetSunRequest
+	8,  // 26: nocturnal.v1.NocturnalService.GetMoon:input_type -> nocturnal.v1.GetMoonRequest
+	10, // 27: nocturnal.v1.NocturnalService.GetTwilight:input_type -> nocturnal.v1.GetTwilightRequest
+	12, // 28: nocturnal.v1.NocturnalService.GetTransit:input_type -> nocturnal.v1.GetTransitRequest
+	14, // 29: nocturnal.v1.NocturnalService.StreamEphemeris:input_type -> nocturnal.v1.StreamEphemerisRequest
+	7,  // 30: nocturnal.v1.NocturnalService.GetSun:output_type -> nocturnal.v1.GetSunResponse
+	9,  // 31: nocturnal.v1.NocturnalService.GetMoon:output_type -> nocturnal.v1.GetMoonResponse
+	11, // 32: nocturnal.v1.NocturnalService.GetTwilight:output_type -> nocturnal.v1.GetTwilightResponse
+	13, // 33: nocturnal.v1.NocturnalService.GetTransit:output_type -> nocturnal.v1.GetTransitResponse
+	15, // 34: nocturnal.v1.NocturnalService.StreamEphemeris:output_type -> nocturnal.v1.StreamEphemerisResponse
+	30, // [30:35] is the sub-list for method output_type
+	25, // [25:30] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
+}
+
+func init() { file_nocturnal_v1_nocturnal_proto_init() }
+func file_nocturnal_v1_nocturnal_proto_init() {
+	if File_nocturnal_v1_nocturnal_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_nocturnal_v1_nocturnal_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Observer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nocturnal_v1_nocturnal_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HorizontalCoordinate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nocturnal_v1_nocturnal_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EquatorialCoordinate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nocturnal_v1_nocturnal_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Event); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nocturnal_v1_nocturnal_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Body); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nocturnal_v1_nocturnal_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSunRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nocturnal_v1_nocturnal_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSunResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nocturnal_v1_nocturnal_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetMoonRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nocturnal_v1_nocturnal_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetMoonResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nocturnal_v1_nocturnal_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTwilightRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nocturnal_v1_nocturnal_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTwilightResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nocturnal_v1_nocturnal_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTransitRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nocturnal_v1_nocturnal_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTransitResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nocturnal_v1_nocturnal_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamEphemerisRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nocturnal_v1_nocturnal_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamEphemerisResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_nocturnal_v1_nocturnal_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_nocturnal_v1_nocturnal_proto_goTypes,
+		DependencyIndexes: file_nocturnal_v1_nocturnal_proto_depIdxs,
+		EnumInfos:         file_nocturnal_v1_nocturnal_proto_enumTypes,
+		MessageInfos:      file_nocturnal_v1_nocturnal_proto_msgTypes,
+	}.Build()
+	File_nocturnal_v1_nocturnal_proto = out.File
+	file_nocturnal_v1_nocturnal_proto_rawDesc = nil
+	file_nocturnal_v1_nocturnal_proto_goTypes = nil
+	file_nocturnal_v1_nocturnal_proto_depIdxs = nil
+}
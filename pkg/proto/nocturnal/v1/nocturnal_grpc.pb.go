@@ -0,0 +1,299 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: nocturnal/v1/nocturnal.proto
+
+package nocturnalv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	NocturnalService_GetSun_FullMethodName          = "/nocturnal.v1.NocturnalService/GetSun"
+	NocturnalService_GetMoon_FullMethodName         = "/nocturnal.v1.NocturnalService/GetMoon"
+	NocturnalService_GetTwilight_FullMethodName     = "/nocturnal.v1.NocturnalService/GetTwilight"
+	NocturnalService_GetTransit_FullMethodName      = "/nocturnal.v1.NocturnalService/GetTransit"
+	NocturnalService_StreamEphemeris_FullMethodName = "/nocturnal.v1.NocturnalService/StreamEphemeris"
+)
+
+// NocturnalServiceClient is the client API for NocturnalService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type NocturnalServiceClient interface {
+	// GetSun returns the rise, upper culmination and set events of the Sun on the local day of the observer.
+	GetSun(ctx context.Context, in *GetSunRequest, opts ...grpc.CallOption) (*GetSunResponse, error)
+	// GetMoon returns the rise, upper culmination and set events of the Moon on the local day of the observer.
+	GetMoon(ctx context.Context, in *GetMoonRequest, opts ...grpc.CallOption) (*GetMoonResponse, error)
+	// GetTwilight returns the civil, nautical and astronomical dusk and dawn events of the Sun on the night of the
+	// observer.
+	GetTwilight(ctx context.Context, in *GetTwilightRequest, opts ...grpc.CallOption) (*GetTwilightResponse, error)
+	// GetTransit returns the rise, upper culmination and set events of a target on the local day of the observer.
+	GetTransit(ctx context.Context, in *GetTransitRequest, opts ...grpc.CallOption) (*GetTransitResponse, error)
+	// StreamEphemeris streams the position of a body in the observer's sky, from the observer's datetime at each
+	// interval.
+	StreamEphemeris(ctx context.Context, in *StreamEphemerisRequest, opts ...grpc.CallOption) (NocturnalService_StreamEphemerisClient, error)
+}
+
+type nocturnalServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNocturnalServiceClient(cc grpc.ClientConnInterface) NocturnalServiceClient {
+	return &nocturnalServiceClient{cc}
+}
+
+func (c *nocturnalServiceClient) GetSun(ctx context.Context, in *GetSunRequest, opts ...grpc.CallOption) (*GetSunResponse, error) {
+	out := new(GetSunResponse)
+	err := c.cc.Invoke(ctx, NocturnalService_GetSun_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nocturnalServiceClient) GetMoon(ctx context.Context, in *GetMoonRequest, opts ...grpc.CallOption) (*GetMoonResponse, error) {
+	out := new(GetMoonResponse)
+	err := c.cc.Invoke(ctx, NocturnalService_GetMoon_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nocturnalServiceClient) GetTwilight(ctx context.Context, in *GetTwilightRequest, opts ...grpc.CallOption) (*GetTwilightResponse, error) {
+	out := new(GetTwilightResponse)
+	err := c.cc.Invoke(ctx, NocturnalService_GetTwilight_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nocturnalServiceClient) GetTransit(ctx context.Context, in *GetTransitRequest, opts ...grpc.CallOption) (*GetTransitResponse, error) {
+	out := new(GetTransitResponse)
+	err := c.cc.Invoke(ctx, NocturnalService_GetTransit_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nocturnalServiceClient) StreamEphemeris(ctx context.Context, in *StreamEphemerisRequest, opts ...grpc.CallOption) (NocturnalService_StreamEphemerisClient, error) {
+	stream, err := c.cc.NewStream(ctx, &NocturnalService_ServiceDesc.Streams[0], NocturnalService_StreamEphemeris_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &nocturnalServiceStreamEphemerisClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type NocturnalService_StreamEphemerisClient interface {
+	Recv() (*StreamEphemerisResponse, error)
+	grpc.ClientStream
+}
+
+type nocturnalServiceStreamEphemerisClient struct {
+	grpc.ClientStream
+}
+
+func (x *nocturnalServiceStreamEphemerisClient) Recv() (*StreamEphemerisResponse, error) {
+	m := new(StreamEphemerisResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// NocturnalServiceServer is the server API for NocturnalService service.
+// All implementations must embed UnimplementedNocturnalServiceServer
+// for forward compatibility
+type NocturnalServiceServer interface {
+	// GetSun returns the rise, upper culmination and set events of the Sun on the local day of the observer.
+	GetSun(context.Context, *GetSunRequest) (*GetSunResponse, error)
+	// GetMoon returns the rise, upper culmination and set events of the Moon on the local day of the observer.
+	GetMoon(context.Context, *GetMoonRequest) (*GetMoonResponse, error)
+	// GetTwilight returns the civil, nautical and astronomical dusk and dawn events of the Sun on the night of the
+	// observer.
+	GetTwilight(context.Context, *GetTwilightRequest) (*GetTwilightResponse, error)
+	// GetTransit returns the rise, upper culmination and set events of a target on the local day of the observer.
+	GetTransit(context.Context, *GetTransitRequest) (*GetTransitResponse, error)
+	// StreamEphemeris streams the position of a body in the observer's sky, from the observer's datetime at each
+	// interval.
+	StreamEphemeris(*StreamEphemerisRequest, NocturnalService_StreamEphemerisServer) error
+	mustEmbedUnimplementedNocturnalServiceServer()
+}
+
+// UnimplementedNocturnalServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedNocturnalServiceServer struct {
+}
+
+func (UnimplementedNocturnalServiceServer) GetSun(context.Context, *GetSunRequest) (*GetSunResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSun not implemented")
+}
+func (UnimplementedNocturnalServiceServer) GetMoon(context.Context, *GetMoonRequest) (*GetMoonResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMoon not implemented")
+}
+func (UnimplementedNocturnalServiceServer) GetTwilight(context.Context, *GetTwilightRequest) (*GetTwilightResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTwilight not implemented")
+}
+func (UnimplementedNocturnalServiceServer) GetTransit(context.Context, *GetTransitRequest) (*GetTransitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransit not implemented")
+}
+func (UnimplementedNocturnalServiceServer) StreamEphemeris(*StreamEphemerisRequest, NocturnalService_StreamEphemerisServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamEphemeris not implemented")
+}
+func (UnimplementedNocturnalServiceServer) mustEmbedUnimplementedNocturnalServiceServer() {}
+
+// UnsafeNocturnalServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NocturnalServiceServer will
+// result in compilation errors.
+type UnsafeNocturnalServiceServer interface {
+	mustEmbedUnimplementedNocturnalServiceServer()
+}
+
+func RegisterNocturnalServiceServer(s grpc.ServiceRegistrar, srv NocturnalServiceServer) {
+	s.RegisterService(&NocturnalService_ServiceDesc, srv)
+}
+
+func _NocturnalService_GetSun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NocturnalServiceServer).GetSun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NocturnalService_GetSun_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NocturnalServiceServer).GetSun(ctx, req.(*GetSunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NocturnalService_GetMoon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMoonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NocturnalServiceServer).GetMoon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NocturnalService_GetMoon_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NocturnalServiceServer).GetMoon(ctx, req.(*GetMoonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NocturnalService_GetTwilight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTwilightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NocturnalServiceServer).GetTwilight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NocturnalService_GetTwilight_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NocturnalServiceServer).GetTwilight(ctx, req.(*GetTwilightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NocturnalService_GetTransit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NocturnalServiceServer).GetTransit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NocturnalService_GetTransit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NocturnalServiceServer).GetTransit(ctx, req.(*GetTransitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NocturnalService_StreamEphemeris_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamEphemerisRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NocturnalServiceServer).StreamEphemeris(m, &nocturnalServiceStreamEphemerisServer{stream})
+}
+
+type NocturnalService_StreamEphemerisServer interface {
+	Send(*StreamEphemerisResponse) error
+	grpc.ServerStream
+}
+
+type nocturnalServiceStreamEphemerisServer struct {
+	grpc.ServerStream
+}
+
+func (x *nocturnalServiceStreamEphemerisServer) Send(m *StreamEphemerisResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// NocturnalService_ServiceDesc is the grpc.ServiceDesc for NocturnalService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var NocturnalService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "nocturnal.v1.NocturnalService",
+	HandlerType: (*NocturnalServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSun",
+			Handler:    _NocturnalService_GetSun_Handler,
+		},
+		{
+			MethodName: "GetMoon",
+			Handler:    _NocturnalService_GetMoon_Handler,
+		},
+		{
+			MethodName: "GetTwilight",
+			Handler:    _NocturnalService_GetTwilight_Handler,
+		},
+		{
+			MethodName: "GetTransit",
+			Handler:    _NocturnalService_GetTransit_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamEphemeris",
+			Handler:       _NocturnalService_StreamEphemeris_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "nocturnal/v1/nocturnal.proto",
+}
@@ -1,106 +1,112 @@
 package sun
 
 import (
-	"net/http"
-	"strconv"
+	"context"
 	"time"
 
-	"github.com/gin-gonic/gin"
 	"github.com/observerly/dusk/pkg/dusk"
-	"github.com/observerly/nocturnal/internal/query"
-	"github.com/observerly/nocturnal/internal/utils"
+	"github.com/observerly/nocturnal/pkg/computation"
+	"github.com/observerly/nocturnal/pkg/events"
+	"github.com/observerly/nocturnal/pkg/observer"
 )
 
-// GET Sun
-func GetSunDeprecatedV1(c *gin.Context) {
-	d, lon, lat := query.GetDefaultObserverParams(c)
-
-	datetime, _ := utils.ParseDatetimeRFC3339(d)
-
-	longitude, _ := strconv.ParseFloat(lon, 64)
-
-	latitude, _ := strconv.ParseFloat(lat, 64)
-
-	eq := dusk.GetSolarEquatorialPosition(datetime)
-
-	hz := dusk.ConvertEquatorialCoordinateToHorizontal(datetime, longitude, latitude, eq)
-
-	rstoday, _ := dusk.GetSunriseSunsetTimes(datetime, 0, longitude, latitude, 0)
-
-	rstomorrow, _ := dusk.GetSunriseSunsetTimes(datetime.Add(time.Hour*24), 0, longitude, latitude, 0)
-
-	observer := gin.H{
-		"datetime":  datetime,
-		"longitude": longitude,
-		"latitude":  latitude,
-	}
-
-	position := gin.H{
-		"alt": hz.Altitude,
-		"az":  hz.Azimuth,
-		"ra":  eq.RightAscension,
-		"dec": eq.Declination,
-	}
-
-	transit := gin.H{
-		"rise": rstoday.Rise.Format(time.RFC3339),
-		"set":  rstoday.Set.Format(time.RFC3339),
-	}
-
-	tomorrow := gin.H{
-		"rise": rstomorrow.Rise.Format(time.RFC3339),
-		"set":  rstomorrow.Set.Format(time.RFC3339),
-	}
+type Properties struct {
+	// The datetime in UTC:
+	UTC time.Time `json:"UTC"`
+	// The datetime in the local civil time of the datetime:
+	LCT time.Time `json:"LCT"`
+	// The altitude of the Sun above the horizon (in degrees):
+	Altitude float64 `json:"alt"`
+	// The azimuth of the Sun, east of north (in degrees):
+	Azimuth float64 `json:"az"`
+	// The right ascension of the Sun (in degrees):
+	RightAscension float64 `json:"ra"`
+	// The declination of the Sun (in degrees):
+	Declination float64 `json:"dec"`
+	// The atmospheric refraction at the altitude of the Sun (in degrees), or nil if the Sun is below the horizon:
+	Refraction *float64 `json:"R"`
+	// The relative air mass at the altitude of the Sun, or nil if the Sun is below the horizon:
+	AirMass *float64 `json:"X"`
+}
 
-	c.JSON(http.StatusOK, gin.H{
-		"observer": observer,
-		"position": position,
-		"transit":  transit,
-		"tomorrow": tomorrow,
-	})
+type Response struct {
+	Observer observer.Observer `json:"observer"`
+	Rise     Properties        `json:"rise"`
+	Maximum  Properties        `json:"maximum"`
+	Set      Properties        `json:"set"`
 }
 
-func GetStandardSolarProperties(datetime time.Time, longitude float64, latitude float64) gin.H {
+// GetStandardSolarProperties returns the position of the Sun at the datetime, for the observer at the longitude and
+// latitude (to the whole second, as RFC3339), along with the refraction and air mass at its altitude:
+func GetStandardSolarProperties(datetime time.Time, longitude float64, latitude float64) Properties {
 	eq := dusk.GetSolarEquatorialPosition(datetime.UTC())
 
 	hz := dusk.ConvertEquatorialCoordinateToHorizontal(datetime.UTC(), longitude, latitude, eq)
 
-	return gin.H{
-		"UTC": datetime.UTC().Format(time.RFC3339),
-		"LCT": datetime.Format(time.RFC3339),
-		"alt": hz.Altitude,
-		"az":  hz.Azimuth,
-		"ra":  eq.RightAscension,
-		"dec": eq.Declination,
+	return Properties{
+		UTC:            datetime.UTC().Truncate(time.Second),
+		LCT:            datetime.Truncate(time.Second),
+		Altitude:       hz.Altitude,
+		Azimuth:        hz.Azimuth,
+		RightAscension: eq.RightAscension,
+		Declination:    eq.Declination,
+		Refraction:     dusk.GetAtmosphericRefraction(hz.Altitude),
+		AirMass:        dusk.GetRelativeAirMass(hz.Altitude),
 	}
 }
 
-// GET /sun v2
-func GetSun(c *gin.Context) {
-	d, lon, lat := query.GetDefaultObserverParams(c)
-
-	datetime, _ := utils.ParseDatetimeRFC3339(d)
+// GetSolarTransit returns the position of the Sun at its rise, upper culmination (maximum) and set on the day of the
+// observer's datetime:
+func GetSolarTransit(ctx context.Context, o observer.Observer) (Response, error) {
+	span := computation.Start(ctx, "solar_rise_set")
 
-	longitude, _ := strconv.ParseFloat(lon, 64)
+	rs, err := dusk.GetSunriseSunsetTimes(o.Datetime, 0, o.Longitude, o.Latitude, 0)
 
-	latitude, _ := strconv.ParseFloat(lat, 64)
+	span.End()
 
-	// Create the Observer gin.H JSON object representation:
-	observer := gin.H{
-		"datetime":  datetime,
-		"longitude": longitude,
-		"latitude":  latitude,
+	if err != nil {
+		return Response{}, err
 	}
 
-	rs, _ := dusk.GetSunriseSunsetTimes(datetime, 0, longitude, latitude, 0)
+	return Response{
+		Observer: o,
+		Rise:     GetStandardSolarProperties(rs.Rise, o.Longitude, o.Latitude),
+		// The upper culmination (maximum) of the Sun is at local solar noon, N.B. dusk's sidereal time does not scale
+		// fractions of a second correctly, so the noon is truncated to the whole second:
+		Maximum: GetStandardSolarProperties(rs.Noon.Truncate(time.Second), o.Longitude, o.Latitude),
+		Set:     GetStandardSolarProperties(rs.Set, o.Longitude, o.Latitude),
+	}, nil
+}
 
-	rise := GetStandardSolarProperties(rs.Rise, longitude, latitude)
+// GetSolarEvents returns the rise, maximum and set events (those that occur) of the Sun on the local day of the datetime, for the
+// observer in the local timezone of the location:
+func GetSolarEvents(ctx context.Context, datetime time.Time, longitude float64, latitude float64, location *time.Location) ([]events.Event, error) {
+	span := computation.Start(ctx, "solar_rise_set")
 
-	set := GetStandardSolarProperties(rs.Set, longitude, latitude)
+	rs, err := dusk.GetSunriseSunsetTimes(datetime, 0, longitude, latitude, 0)
+
+	span.End()
+
+	if err != nil {
+		return nil, err
+	}
+
+	evs := []events.Event{}
+
+	// The Sun neither rises nor sets during the polar day or night, but the upper culmination (maximum) at local solar
+	// noon (truncated to the whole second, as for v2) always occurs:
+	for _, e := range []struct {
+		kind     string
+		datetime time.Time
+	}{
+		{events.RISE, rs.Rise},
+		{events.MAXIMUM, rs.Noon.Truncate(time.Second)},
+		{events.SET, rs.Set},
+	} {
+		if events.Occurs(e.datetime, datetime) {
+			evs = append(evs, events.NewEvent(e.kind, e.datetime, dusk.GetSolarEquatorialPosition(e.datetime.UTC()), longitude, latitude, location))
+		}
+	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"observer": observer,
-		"rise":     rise,
-		"set":      set,
-	})
+	return evs, nil
 }
@@ -1,165 +1,102 @@
 package sun
 
 import (
-	"encoding/json"
-	"net/http"
-	"net/http/httptest"
-	"os"
+	"context"
 	"testing"
+	"time"
 
-	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
-)
-
-func SetupSunRouter() *gin.Engine {
-	mode := os.Getenv("GIN_MODE")
-
-	if mode == "release" {
-		gin.SetMode(gin.ReleaseMode)
-	}
-
-	// Create gin router
-	r := gin.Default()
-
-	r.GET("/api/v1/sun", GetSunDeprecatedV1)
-
-	return r
-}
 
-// Setup the Gin API router:
-var r = SetupSunRouter()
+	"github.com/observerly/nocturnal/pkg/events"
+	"github.com/observerly/nocturnal/pkg/observer"
+)
 
-// Setup the base response struct:
-var response map[string]map[string]interface{}
+var precision = 0.0001
 
-func performRequest(r http.Handler, method, path string) *httptest.ResponseRecorder {
-	req, _ := http.NewRequest(method, path, nil)
-	w := httptest.NewRecorder()
-	r.ServeHTTP(w, req)
-	return w
-}
+func TestGetSolarTransit(t *testing.T) {
+	o := observer.New(time.Date(2021, 5, 14, 0, 0, 0, 0, time.UTC), -155.468094, 19.798484, 0)
 
-// Perform a GET request with that handler.
-var w = performRequest(r, "GET", "/api/v1/sun?datetime=2021-05-14T00:00:00.000Z&longitude=-155.468094&latitude=19.798484")
+	res, err := GetSolarTransit(context.Background(), o)
 
-func TestSunRouteStatusCode(t *testing.T) {
-	// Assert we encoded correctly, the request gives a 200:
-	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Nil(t, err)
+	assert.Equal(t, o, res.Observer)
+
+	// Assert on the rise, maximum and set of the Sun on 2021-05-14 at Mauna Kea:
+	assert.Equal(t, "2021-05-14T15:49:45Z", res.Rise.UTC.Format(time.RFC3339))
+	assert.InDelta(t, 1.3101013887429336, res.Rise.Altitude, precision)
+	assert.InDelta(t, 70.47433002623417, res.Rise.Azimuth, precision)
+
+	assert.Equal(t, "2021-05-14T22:18:18Z", res.Maximum.UTC.Format(time.RFC3339))
+	assert.InDelta(t, 89.05753502404762, res.Maximum.Altitude, precision)
+	assert.InDelta(t, 51.9828435538231, res.Maximum.RightAscension, precision)
+	assert.InDelta(t, 18.856019134273545, res.Maximum.Declination, precision)
+	assert.InDelta(t, 0.00024719885065677144, *res.Maximum.Refraction, precision)
+	assert.InDelta(t, 1.0001250800806323, *res.Maximum.AirMass, precision)
+
+	assert.Equal(t, "2021-05-15T04:46:50Z", res.Set.UTC.Format(time.RFC3339))
+	assert.InDelta(t, -3.2429444558408953, res.Set.Altitude, precision)
+
+	// Assert that the refraction and air mass are nil when the Sun is below the horizon, i.e., at its set:
+	assert.Nil(t, res.Set.Refraction)
+	assert.Nil(t, res.Set.AirMass)
 }
 
-var precision = 0.0000001
-
-func TestGetSunRouteObserver(t *testing.T) {
-	// Build our expected observer section of body
-	observer := gin.H{
-		"datetime":  "2021-05-14T00:00:00Z",
-		"latitude":  19.798484,
-		"longitude": -155.468094,
-	}
-
-	// Convert the JSON response:
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-
-	// Grab the observer & whether or not it exists
-	datetime, exists := response["observer"]["datetime"]
-	assert.True(t, exists)
+func TestGetSolarEvents(t *testing.T) {
+	location, _ := time.LoadLocation("Pacific/Honolulu")
 
-	// Grab the observer & whether or not it exists
-	latitude, exists := response["observer"]["latitude"]
-	assert.True(t, exists)
+	evs, err := GetSolarEvents(context.Background(), time.Date(2021, 5, 14, 0, 0, 0, 0, location), -155.468094, 19.798484, location)
 
-	// Grab the observer & whether or not it exists
-	longitude, exists := response["observer"]["longitude"]
-	assert.True(t, exists)
-
-	// Assert on the correctness of the response:
 	assert.Nil(t, err)
-	assert.Equal(t, datetime, observer["datetime"])
-	assert.Equal(t, latitude, observer["latitude"])
-	assert.Equal(t, longitude, observer["longitude"])
-}
 
-func TestGetSunRoutePosition(t *testing.T) {
-	// Build our expected position section of body
-	position := gin.H{
-		"alt": 65.98487307697896,
-		"az":  88.4839666699854,
-		"dec": 18.634152331055457,
-		"ra":  51.065497132296336,
+	// The rise, maximum and set of the Sun on 2021-05-14 at Mauna Kea (as for the v2 reference values):
+	expected := []struct {
+		kind  string
+		local string
+		alt   float64
+		az    float64
+		ra    float64
+		dec   float64
+	}{
+		{"rise", "2021-05-14T05:49:45-10:00", 1.3101013887429336, 70.47433002623417, 51.71630525455092, 18.792075895178936},
+		{"maximum", "2021-05-14T12:18:18-10:00", 89.05753502404762, 179.97220865980813, 51.9828435538231, 18.856019134273545},
+		{"set", "2021-05-14T18:46:50-10:00", -3.2429444558408953, 68.55790252346988, 52.24955492872896, 18.919575832754642},
 	}
 
-	// Convert the JSON response:
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-
-	// Grab the position & whether or not it exists
-	alt, exists := response["position"]["alt"]
-	assert.True(t, exists)
-
-	// Grab the position & whether or not it exists
-	az, exists := response["position"]["az"]
-	assert.True(t, exists)
+	assert.Len(t, evs, len(expected))
 
-	// Grab the position & whether or not it exists
-	ra, exists := response["position"]["ra"]
-	assert.True(t, exists)
-
-	// Grab the position & whether or not it exists
-	dec, exists := response["position"]["dec"]
-	assert.True(t, exists)
-
-	// Assert on the correctness of the response:
-	assert.Nil(t, err)
-	assert.InDelta(t, alt, position["alt"], precision)
-	assert.InDelta(t, az, position["az"], precision)
-	assert.InDelta(t, ra, position["ra"], precision)
-	assert.InDelta(t, dec, position["dec"], precision)
-}
-
-func TestGetSunRouteTransit(t *testing.T) {
-	// Build our expected transit section of body
-	transit := gin.H{
-		"rise": "2021-05-14T05:49:45-10:00",
-		"set":  "2021-05-14T18:46:50-10:00",
+	for i, event := range evs {
+		assert.Equal(t, expected[i].kind, event.Type)
+		assert.Equal(t, expected[i].local, event.Local.Format(time.RFC3339))
+		assert.True(t, event.UTC.Equal(event.Local))
+		assert.InDelta(t, expected[i].alt, event.Horizontal.Altitude, precision)
+		assert.InDelta(t, expected[i].az, event.Horizontal.Azimuth, precision)
+		assert.InDelta(t, expected[i].ra, event.Equatorial.RightAscension, precision)
+		assert.InDelta(t, expected[i].dec, event.Equatorial.Declination, precision)
 	}
+}
 
-	// Convert the JSON response:
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-
-	// Grab the transit & whether or not it exists
-	rise, exists := response["transit"]["rise"]
-	assert.True(t, exists)
+func TestGetSolarEventsPolarDay(t *testing.T) {
+	location, _ := time.LoadLocation("Arctic/Longyearbyen")
 
-	// Grab the transit & whether or not it exists
-	set, exists := response["transit"]["set"]
-	assert.True(t, exists)
+	evs, err := GetSolarEvents(context.Background(), time.Date(2021, 6, 21, 0, 0, 0, 0, time.UTC), 15.63, 78.22, location)
 
-	// Assert on the correctness of the response:
+	// Assert that the Sun neither rises nor sets at Longyearbyen on the summer solstice, but culminates above the horizon:
 	assert.Nil(t, err)
-
-	assert.Equal(t, rise, transit["rise"])
-	assert.Equal(t, set, transit["set"])
+	assert.Len(t, evs, 1)
+	assert.Equal(t, events.MAXIMUM, evs[0].Type)
+	assert.Equal(t, "2021-06-21T12:59:13+02:00", evs[0].Local.Format(time.RFC3339))
+	assert.Greater(t, evs[0].Horizontal.Altitude, 0.0)
 }
 
-func TestGetSunRouteTomorrow(t *testing.T) {
-	// Build our expected transit section of body
-	tomorrow := gin.H{
-		"rise": "2021-05-15T05:49:30-10:00",
-		"set":  "2021-05-15T18:47:08-10:00",
-	}
-
-	// Convert the JSON response:
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-
-	// Grab the tomorrow & whether or not it exists
-	rise, exists := response["tomorrow"]["rise"]
-	assert.True(t, exists)
+func TestGetSolarEventsNeverRises(t *testing.T) {
+	location, _ := time.LoadLocation("Arctic/Longyearbyen")
 
-	// Grab the tomorrow & whether or not it exists
-	set, exists := response["tomorrow"]["set"]
-	assert.True(t, exists)
+	evs, err := GetSolarEvents(context.Background(), time.Date(2021, 12, 21, 0, 0, 0, 0, time.UTC), 15.63, 78.22, location)
 
-	// Assert on the correctness of the response:
+	// Assert that the Sun neither rises nor sets at Longyearbyen on the winter solstice, and culminates below the horizon:
 	assert.Nil(t, err)
-	assert.Equal(t, rise, tomorrow["rise"])
-	assert.Equal(t, set, tomorrow["set"])
+	assert.Len(t, evs, 1)
+	assert.Equal(t, events.MAXIMUM, evs[0].Type)
+	assert.Equal(t, "2021-12-21T11:55:33+01:00", evs[0].Local.Format(time.RFC3339))
+	assert.Less(t, evs[0].Horizontal.Altitude, 0.0)
 }
@@ -1,94 +1,58 @@
 package transit
 
 import (
-	"net/http"
-	"strconv"
+	"context"
 	"time"
 
-	"github.com/gin-gonic/gin"
 	"github.com/observerly/dusk/pkg/dusk"
-	"github.com/observerly/nocturnal/internal/query"
-	"github.com/observerly/nocturnal/internal/utils"
+	"github.com/observerly/nocturnal/pkg/computation"
+	"github.com/observerly/nocturnal/pkg/events"
+	"github.com/observerly/nocturnal/pkg/observer"
 )
 
-// GET /transit
-func GetTransitDeprecatedV1(c *gin.Context) {
-	d, lon, lat := query.GetDefaultObserverParams(c)
-
-	ra := c.DefaultQuery("ra", strconv.Itoa(0))
-
-	dec := c.DefaultQuery("dec", strconv.Itoa(0))
-
-	datetime, _ := utils.ParseDatetimeRFC3339(d)
-
-	longitude, _ := strconv.ParseFloat(lon, 64)
-
-	latitude, _ := strconv.ParseFloat(lat, 64)
-
-	rightAscension, _ := strconv.ParseFloat(ra, 64)
-
-	declination, _ := strconv.ParseFloat(dec, 64)
-
-	eq := dusk.EquatorialCoordinate{RightAscension: rightAscension, Declination: declination}
-
-	hz := dusk.ConvertEquatorialCoordinateToHorizontal(datetime, longitude, latitude, eq)
-
-	mec := dusk.GetLunarEclipticPosition(datetime)
-
-	meq := dusk.GetLunarEquatorialPosition(datetime)
-
-	mph := dusk.GetLunarPhase(datetime, longitude, mec)
-
-	tr, _ := dusk.GetObjectTransit(datetime, eq, latitude, longitude)
-
-	path, _ := dusk.GetObjectHorizontalCoordinatesForDay(datetime, eq, longitude, latitude)
-
-	airmass := dusk.GetRelativeAirMass(hz.Altitude)
-
-	refraction := dusk.GetAtmosphericRefraction(hz.Altitude)
-
-	separation := dusk.GetAngularSeparation(dusk.Coordinate{Latitude: eq.Declination, Longitude: eq.RightAscension}, dusk.Coordinate{Latitude: meq.Declination, Longitude: meq.RightAscension})
-
-	observer := gin.H{
-		"datetime":  datetime,
-		"longitude": longitude,
-		"latitude":  latitude,
-	}
-
-	phase := gin.H{
-		"age":          mph.Days,
-		"angle":        mph.Angle,
-		"d":            mph.Age,
-		"fraction":     mph.Fraction,
-		"illumination": mph.Illumination,
-		"separation":   separation,
-	}
-
-	position := gin.H{
-		"alt": hz.Altitude,
-		"az":  hz.Azimuth,
-		"ra":  rightAscension,
-		"dec": declination,
-		"R":   refraction,
-		"X":   airmass,
-	}
-
-	properties := gin.H{
-		"maximum": utils.FormatDatetimeRFC3339(tr.Maximum),
-		"rise":    utils.FormatDatetimeRFC3339(tr.Rise),
-		"set":     utils.FormatDatetimeRFC3339(tr.Set),
-	}
+type Properties struct {
+	// The datetime in UTC:
+	UTC time.Time `json:"UTC"`
+	// The datetime in the local civil time of the datetime:
+	LCT time.Time `json:"LCT"`
+	// The altitude of the target above the horizon (in degrees):
+	Altitude float64 `json:"alt"`
+	// The azimuth of the target, east of north (in degrees):
+	Azimuth float64 `json:"az"`
+	// The right ascension of the target (in degrees):
+	RightAscension float64 `json:"ra"`
+	// The declination of the target (in degrees):
+	Declination float64 `json:"dec"`
+	// The age of the Moon (in days since the new Moon):
+	Age float64 `json:"age"`
+	// The phase angle of the Moon (in degrees):
+	Angle float64 `json:"angle"`
+	// The illuminated fraction of the Moon's disk:
+	Fraction float64 `json:"fraction"`
+	// The illuminated percentage of the Moon's disk:
+	Illumination float64 `json:"illumination"`
+	// The atmospheric refraction at the altitude of the target (in degrees), or nil if it is below the horizon:
+	Refraction *float64 `json:"R"`
+	// The relative air mass at the altitude of the target, or nil if it is below the horizon:
+	AirMass *float64 `json:"X"`
+	// The angular separation between the target and the Moon (in degrees):
+	Separation float64 `json:"separation"`
+}
 
-	c.JSON(http.StatusOK, gin.H{
-		"phase":      phase,
-		"observer":   observer,
-		"position":   position,
-		"properties": properties,
-		"path":       path,
-	})
+type Response struct {
+	Observer observer.Observer `json:"observer"`
+	// The properties of the target at each event, or nil if the event does not occur:
+	Rise    *Properties `json:"rise"`
+	Maximum *Properties `json:"maximum"`
+	Set     *Properties `json:"set"`
+	// The horizontal coordinate of the target at each minute of the day:
+	Path []dusk.TransitHorizontalCoordinate `json:"path"`
 }
 
-func GetStandardTransitProperties(datetime *time.Time, eq dusk.EquatorialCoordinate, longitude float64, latitude float64) gin.H {
+// GetStandardTransitProperties returns the position of the target at the equatorial coordinate at the datetime, for
+// the observer at the longitude and latitude, along with the phase of and separation from the Moon (or nil if there is
+// no datetime):
+func GetStandardTransitProperties(datetime *time.Time, eq dusk.EquatorialCoordinate, longitude float64, latitude float64) *Properties {
 	if datetime == nil {
 		return nil
 	}
@@ -99,107 +63,116 @@ func GetStandardTransitProperties(datetime *time.Time, eq dusk.EquatorialCoordin
 
 	ph := dusk.GetLunarPhase(datetime.UTC(), longitude, ec)
 
-	airmass := dusk.GetRelativeAirMass(hz.Altitude)
+	meq := dusk.ConvertEclipticCoordinateToEquatorial(datetime.UTC(), ec)
 
-	refraction := dusk.GetAtmosphericRefraction(hz.Altitude)
+	separation := dusk.GetAngularSeparation(dusk.Coordinate{Latitude: eq.Declination, Longitude: eq.RightAscension}, dusk.Coordinate{Latitude: meq.Declination, Longitude: meq.RightAscension})
 
-	mec := dusk.GetLunarEclipticPositionLawrence(datetime.UTC())
+	return &Properties{
+		UTC:            datetime.UTC().Truncate(time.Second),
+		LCT:            datetime.Truncate(time.Second),
+		Altitude:       hz.Altitude,
+		Azimuth:        hz.Azimuth,
+		RightAscension: eq.RightAscension,
+		Declination:    eq.Declination,
+		Age:            ph.Days,
+		Angle:          ph.Angle,
+		Fraction:       ph.Fraction,
+		Illumination:   ph.Illumination,
+		Refraction:     dusk.GetAtmosphericRefraction(hz.Altitude),
+		AirMass:        dusk.GetRelativeAirMass(hz.Altitude),
+		Separation:     separation,
+	}
+}
 
-	meq := dusk.ConvertEclipticCoordinateToEquatorial(datetime.UTC(), mec)
+// GetObjectTransit returns the properties of the target at the equatorial coordinate at its rise, upper culmination
+// (maximum) and set on the day of the observer's datetime, along with its path across the observer's sky:
+func GetObjectTransit(ctx context.Context, o observer.Observer, eq dusk.EquatorialCoordinate) (Response, error) {
+	span := computation.Start(ctx, "object_transit")
 
-	separation := dusk.GetAngularSeparation(dusk.Coordinate{Latitude: eq.Declination, Longitude: eq.RightAscension}, dusk.Coordinate{Latitude: meq.Declination, Longitude: meq.RightAscension})
+	transit, err := dusk.GetObjectTransit(o.Datetime, eq, o.Latitude, o.Longitude)
+
+	span.End()
 
-	return gin.H{
-		"UTC":          datetime.UTC().Format(time.RFC3339),
-		"LCT":          datetime.Format(time.RFC3339),
-		"alt":          hz.Altitude,
-		"az":           hz.Azimuth,
-		"ra":           eq.RightAscension,
-		"dec":          eq.Declination,
-		"age":          ph.Days,
-		"angle":        ph.Angle,
-		"fraction":     ph.Fraction,
-		"illumination": ph.Illumination,
-		"R":            refraction,
-		"X":            airmass,
-		"separation":   separation,
+	if err != nil {
+		return Response{}, err
 	}
-}
 
-// GET /transit v2
-func GetTransit(c *gin.Context) {
-	d, lon, lat := query.GetDefaultObserverParams(c)
+	// A target that never rises or sets still culminates, e.g., a circumpolar star:
+	if transit.Maximum == nil {
+		span := computation.Start(ctx, "object_transit_maxima")
 
-	datetime, _ := utils.ParseDatetimeRFC3339(d)
+		maxima, err := dusk.GetObjectTransitMaximaTime(o.Datetime, eq, o.Latitude, o.Longitude)
 
-	longitude, _ := strconv.ParseFloat(lon, 64)
+		span.End()
 
-	latitude, _ := strconv.ParseFloat(lat, 64)
+		if err != nil {
+			return Response{}, err
+		}
 
-	// Create the Observer gin.H JSON object representation:
-	observer := gin.H{
-		"datetime":  datetime,
-		"longitude": longitude,
-		"latitude":  latitude,
+		transit.Maximum = maxima
 	}
 
-	// Parse the Right Ascension from the request query:
-	ra, err := strconv.ParseFloat(c.DefaultQuery("ra", strconv.Itoa(0)), 64)
+	span = computation.Start(ctx, "object_horizontal_path")
 
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
-		return
-	}
+	path, err := dusk.GetObjectHorizontalCoordinatesForDay(o.Datetime, eq, o.Longitude, o.Latitude)
 
-	// Parse the Declination from the request query:
-	dec, err := strconv.ParseFloat(c.DefaultQuery("dec", strconv.Itoa(0)), 64)
+	span.End()
 
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
-		return
+		return Response{}, err
 	}
 
-	eq := dusk.EquatorialCoordinate{
-		RightAscension: ra,
-		Declination:    dec,
-	}
+	return Response{
+		Observer: o,
+		Rise:     GetStandardTransitProperties(transit.Rise, eq, o.Longitude, o.Latitude),
+		Maximum:  GetStandardTransitProperties(transit.Maximum, eq, o.Longitude, o.Latitude),
+		Set:      GetStandardTransitProperties(transit.Set, eq, o.Longitude, o.Latitude),
+		Path:     path,
+	}, nil
+}
+
+// GetTransitEvents returns the rise, maximum and set events (those that occur) of a body at the equatorial coordinate
+// on the local day of the datetime, for the observer in the local timezone of the location:
+func GetTransitEvents(ctx context.Context, datetime time.Time, eq dusk.EquatorialCoordinate, longitude float64, latitude float64, location *time.Location) ([]events.Event, error) {
+	span := computation.Start(ctx, "object_transit")
 
-	// Get the transit times:
-	transit, _ := dusk.GetObjectTransit(datetime, eq, latitude, longitude)
+	transit, err := dusk.GetObjectTransit(datetime, eq, latitude, longitude)
 
-	// Create the Rise gin.H JSON object representation:
-	rise := GetStandardTransitProperties(transit.Rise, eq, longitude, latitude)
+	span.End()
+
+	if err != nil {
+		return nil, err
+	}
 
+	// A body that never rises or sets still culminates, e.g., a circumpolar star:
 	if transit.Maximum == nil {
+		span := computation.Start(ctx, "object_transit_maxima")
+
 		maxima, err := dusk.GetObjectTransitMaximaTime(datetime, eq, latitude, longitude)
 
+		span.End()
+
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": err.Error(),
-			})
-			return
+			return nil, err
 		}
 
 		transit.Maximum = maxima
 	}
 
-	// Create the Maximum gin.H JSON object representation:
-	maximum := GetStandardTransitProperties(transit.Maximum, eq, longitude, latitude)
-
-	// Create the Set gin.H JSON object representation:
-	set := GetStandardTransitProperties(transit.Set, eq, longitude, latitude)
-
-	path, _ := dusk.GetObjectHorizontalCoordinatesForDay(datetime, eq, longitude, latitude)
+	evs := []events.Event{}
+
+	for _, e := range []struct {
+		kind     string
+		datetime *time.Time
+	}{
+		{events.RISE, transit.Rise},
+		{events.MAXIMUM, transit.Maximum},
+		{events.SET, transit.Set},
+	} {
+		if e.datetime != nil {
+			evs = append(evs, events.NewEvent(e.kind, *e.datetime, eq, longitude, latitude, location))
+		}
+	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"observer": observer,
-		"rise":     rise,
-		"maximum":  maximum,
-		"set":      set,
-		"path":     path,
-	})
+	return evs, nil
 }
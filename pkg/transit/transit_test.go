@@ -1,444 +1,79 @@
 package transit
 
 import (
-	"encoding/json"
-	"net/http"
-	"net/http/httptest"
-	"os"
+	"context"
 	"testing"
+	"time"
 
-	"github.com/gin-gonic/gin"
+	"github.com/observerly/dusk/pkg/dusk"
 	"github.com/stretchr/testify/assert"
-)
-
-func SetupTransitRouter() *gin.Engine {
-	mode := os.Getenv("GIN_MODE")
-
-	if mode == "release" {
-		gin.SetMode(gin.ReleaseMode)
-	}
-
-	// Create gin router
-	r := gin.Default()
-
-	r.GET("/api/v2/transit", GetTransit)
-
-	return r
-}
-
-// Setup the Gin API router:
-var r = SetupTransitRouter()
-
-// Setup the base response struct:
-var response struct {
-	Observer map[string]interface{} `json:"observer"`
-	Rise     map[string]interface{} `json:"rise"`
-	Set      map[string]interface{} `json:"set"`
-	Maximum  map[string]interface{} `json:"maximum"`
-	Path     []interface{}          `json:"path"`
-}
 
-func performRequest(r http.Handler, method, path string) *httptest.ResponseRecorder {
-	req, _ := http.NewRequest(method, path, nil)
-	w := httptest.NewRecorder()
-	r.ServeHTTP(w, req)
-	return w
-}
-
-// Perform a GET request with that handler.
-var w = performRequest(r, "GET", "/api/v2/transit?datetime=2021-05-14T00:00:00.000Z&longitude=-155.468094&latitude=19.798484&ra=88.792958&dec=7.407064")
-
-// Perform a GET request with that handler.
-var x = performRequest(r, "GET", "/api/v2/transit?datetime=2021-05-14T00:00:00.000Z&longitude=-155.468094&latitude=45.798484&ra=88.792958&dec=-77.407064")
-
-// Perform a GET request with that handler.
-var y = performRequest(r, "GET", "/api/v2/transit?datetime=2021-05-14T00:00:00.000Z&longitude=-155.468094&latitude=19.798484&ra=88.792958&dec=77.407064")
-
-var precision = 0.0000001
-
-func TestTransitRouteStatusCode(t *testing.T) {
-	// Assert we encoded correctly, the request gives a 200:
-	assert.Equal(t, http.StatusOK, w.Code)
-}
-
-func TestGetTransitRouteObserver(t *testing.T) {
-	// Build our expected observer section of body
-	observer := gin.H{
-		"datetime":  "2021-05-14T00:00:00Z",
-		"latitude":  19.798484,
-		"longitude": -155.468094,
-	}
+	"github.com/observerly/nocturnal/pkg/events"
+	"github.com/observerly/nocturnal/pkg/observer"
+)
 
-	// Convert the JSON response:
-	err := json.Unmarshal(w.Body.Bytes(), &response)
+var precision = 0.0001
 
-	// Grab the observer & whether or not it exists
-	datetime, exists := response.Observer["datetime"]
-	assert.True(t, exists)
+func TestGetObjectTransit(t *testing.T) {
+	o := observer.New(time.Date(2021, 5, 14, 0, 0, 0, 0, time.UTC), -155.468094, 19.798484, 0)
 
-	// Grab the observer & whether or not it exists
-	latitude, exists := response.Observer["latitude"]
-	assert.True(t, exists)
+	// Betelgeuse:
+	eq := dusk.EquatorialCoordinate{RightAscension: 88.792958, Declination: 7.407064}
 
-	// Grab the observer & whether or not it exists
-	longitude, exists := response.Observer["longitude"]
-	assert.True(t, exists)
+	res, err := GetObjectTransit(context.Background(), o, eq)
 
-	// Assert on the correctness of the response:
 	assert.Nil(t, err)
-	assert.Equal(t, datetime, observer["datetime"])
-	assert.Equal(t, latitude, observer["latitude"])
-	assert.Equal(t, longitude, observer["longitude"])
-}
-
-func TestGetTransitRouteRise(t *testing.T) {
-	// Build our expected rise section of body
-	rise := gin.H{
-		"LCT":          "2021-05-14T08:35:25-10:00",
-		"R":            0.31247646372444293,
-		"UTC":          "2021-05-14T18:35:25Z",
-		"X":            22.21853513271382,
-		"age":          2.185508160545753,
-		"alt":          1.5727806816314758,
-		"angle":        148.34943756923096,
-		"az":           82.69308817455995,
-		"dec":          7.407064,
-		"fraction":     0.07400827956860168,
-		"illumination": 7.436790249940451,
-		"ra":           88.792958,
-		"separation":   17.489602973521922,
-	}
-
-	// Convert the JSON response:
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-
-	// Obtain the Local Civil Time rise of the rise and test whether or not it exists:
-	LCT, exists := response.Rise["LCT"]
-	assert.True(t, exists)
-
-	// Obtain the refraction of the rise and test whether or not it exists:
-	R, exists := response.Rise["R"]
-	assert.True(t, exists)
-
-	// Obtain the Universal Time rise of the rise and test whether or not it exists:
-	UTC, exists := response.Rise["UTC"]
-	assert.True(t, exists)
-
-	// Obtain the airmass (X) of the rise and test whether or not it exists:
-	X, exists := response.Rise["X"]
-	assert.True(t, exists)
-
-	// Obtain the age at the the rise and test whether or not it exists:
-	age, exists := response.Rise["age"]
-	assert.True(t, exists)
-
-	// Obtain the altitude of the rise and test whether or not it exists:
-	alt, exists := response.Rise["alt"]
-	assert.True(t, exists)
-
-	// Obtain the angle of the rise and test whether or not it exists:
-	angle, exists := response.Rise["angle"]
-	assert.True(t, exists)
+	assert.Equal(t, o, res.Observer)
+	assert.NotEmpty(t, res.Path)
 
-	// Obtain the azimuth of the rise and test whether or not it exists:
-	az, exists := response.Rise["az"]
-	assert.True(t, exists)
+	// Assert on the rise, maximum and set of Betelgeuse on 2021-05-14 at Mauna Kea:
+	assert.Equal(t, "2021-05-14T18:35:25Z", res.Rise.UTC.Format(time.RFC3339))
+	assert.InDelta(t, 1.5727806816314758, res.Rise.Altitude, precision)
+	assert.InDelta(t, 82.69308817455995, res.Rise.Azimuth, precision)
+	assert.InDelta(t, 17.489602973521922, res.Rise.Separation, precision)
 
-	// Obtain the declination of the rise and test whether or not it exists:
-	dec, exists := response.Rise["dec"]
-	assert.True(t, exists)
+	assert.Equal(t, "2021-05-14T22:39:25Z", res.Maximum.UTC.Format(time.RFC3339))
+	assert.InDelta(t, 58.54930192457176, res.Maximum.Altitude, precision)
+	assert.InDelta(t, 1.1715008193883227, *res.Maximum.AirMass, precision)
 
-	// Obtain the fraction of the rise and test whether or not it exists:
-	fraction, exists := response.Rise["fraction"]
-	assert.True(t, exists)
+	assert.Equal(t, "2021-05-15T06:54:51Z", res.Set.UTC.Format(time.RFC3339))
+	assert.InDelta(t, -1.8540744329511798, res.Set.Altitude, precision)
 
-	// Obtain the illumination of the rise and test whether or not it exists:
-	illumination, exists := response.Rise["illumination"]
-	assert.True(t, exists)
-
-	// Obtain the right ascension of the rise and test whether or not it exists:
-	ra, exists := response.Rise["ra"]
-	assert.True(t, exists)
-
-	// Assert on the correctness of the response:
-	assert.Nil(t, err)
-	assert.InDelta(t, R, rise["R"], precision)
-	assert.Equal(t, LCT, rise["LCT"])
-	assert.Equal(t, UTC, rise["UTC"])
-	assert.InDelta(t, X, rise["X"], precision)
-	assert.InDelta(t, age, rise["age"], precision)
-	assert.InDelta(t, alt, rise["alt"], precision)
-	assert.InDelta(t, angle, rise["angle"], precision)
-	assert.InDelta(t, az, rise["az"], precision)
-	assert.InDelta(t, dec, rise["dec"], precision)
-	assert.InDelta(t, fraction, rise["fraction"], precision)
-	assert.InDelta(t, illumination, rise["illumination"], precision)
-	assert.InDelta(t, ra, rise["ra"], precision)
+	// Assert that the refraction and air mass are nil when the target is below the horizon, i.e., at its set:
+	assert.Nil(t, res.Set.Refraction)
+	assert.Nil(t, res.Set.AirMass)
 }
 
-func TestGetTransitRouteMaximum(t *testing.T) {
-	// Build our expected maximum section of body
-	maximum := gin.H{
-		"LCT":          "2021-05-14T12:39:25-10:00",
-		"R":            0.010331651302290006,
-		"UTC":          "2021-05-14T22:39:25Z",
-		"X":            1.1715008193883227,
-		"age":          2.467644467039966,
-		"alt":          58.54930192457176,
-		"angle":        146.31204868601841,
-		"az":           109.02539763910731,
-		"dec":          7.407064,
-		"fraction":     0.08356231511256518,
-		"illumination": 8.396460922585103,
-		"ra":           88.792958,
-		"separation":   17.52318422105279,
-	}
-
-	// Convert the JSON response:
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-
-	// Obtain the Local Civil Time maximum of the maximum and test whether or not it exists:
-	LCT, exists := response.Maximum["LCT"]
-	assert.True(t, exists)
-
-	// Obtain the refraction of the maximum and test whether or not it exists:
-	R, exists := response.Maximum["R"]
-	assert.True(t, exists)
-
-	// Obtain the Universal Time maximum of the maximum and test whether or not it exists:
-	UTC, exists := response.Maximum["UTC"]
-	assert.True(t, exists)
-
-	// Obtain the airmass (X) of the maximum and test whether or not it exists:
-	X, exists := response.Maximum["X"]
-	assert.True(t, exists)
-
-	// Obtain the age at the the maximum and test whether or not it exists:
-	age, exists := response.Maximum["age"]
-	assert.True(t, exists)
+func TestGetTransitEvents(t *testing.T) {
+	location, _ := time.LoadLocation("Pacific/Honolulu")
 
-	// Obtain the altitude of the maximum and test whether or not it exists:
-	alt, exists := response.Maximum["alt"]
-	assert.True(t, exists)
+	// Betelgeuse:
+	eq := dusk.EquatorialCoordinate{RightAscension: 88.7929583, Declination: 7.4070639}
 
-	// Obtain the angle of the maximum and test whether or not it exists:
-	angle, exists := response.Maximum["angle"]
-	assert.True(t, exists)
+	evs, err := GetTransitEvents(context.Background(), time.Date(2021, 5, 14, 0, 0, 0, 0, location), eq, -155.468094, 19.798484, location)
 
-	// Obtain the azimuth of the maximum and test whether or not it exists:
-	az, exists := response.Maximum["az"]
-	assert.True(t, exists)
-
-	// Obtain the declination of the maximum and test whether or not it exists:
-	dec, exists := response.Maximum["dec"]
-	assert.True(t, exists)
-
-	// Obtain the fraction of the maximum and test whether or not it exists:
-	fraction, exists := response.Maximum["fraction"]
-	assert.True(t, exists)
-
-	// Obtain the illumination of the maximum and test whether or not it exists:
-	illumination, exists := response.Maximum["illumination"]
-	assert.True(t, exists)
-
-	// Obtain the right ascension of the maximum and test whether or not it exists:
-	ra, exists := response.Maximum["ra"]
-	assert.True(t, exists)
-
-	// Assert on the correctness of the response:
 	assert.Nil(t, err)
-	assert.InDelta(t, R, maximum["R"], precision)
-	assert.Equal(t, LCT, maximum["LCT"])
-	assert.Equal(t, UTC, maximum["UTC"])
-	assert.InDelta(t, X, maximum["X"], precision)
-	assert.InDelta(t, age, maximum["age"], precision)
-	assert.InDelta(t, alt, maximum["alt"], precision)
-	assert.InDelta(t, angle, maximum["angle"], precision)
-	assert.InDelta(t, az, maximum["az"], precision)
-	assert.InDelta(t, dec, maximum["dec"], precision)
-	assert.InDelta(t, fraction, maximum["fraction"], precision)
-	assert.InDelta(t, illumination, maximum["illumination"], precision)
-	assert.InDelta(t, ra, maximum["ra"], precision)
-}
 
-func TestGetTransitRouteSet(t *testing.T) {
-	// Build our expected set section of body
-	set := gin.H{
-		"LCT":          "2021-05-14T20:54:51-10:00",
-		"R":            nil,
-		"UTC":          "2021-05-15T06:54:51Z",
-		"X":            nil,
-		"age":          3.0891483187381277,
-		"alt":          -1.8540744329511798,
-		"angle":        142.16654610640515,
-		"az":           81.44596647698675,
-		"dec":          7.407064,
-		"fraction":     0.10460841854965064,
-		"illumination": 10.51014934125243,
-		"ra":           88.792958,
-		"separation":   18.281831615702153,
+	// The rise, maximum and set of Betelgeuse on 2021-05-14 at Mauna Kea (as for the v2 reference values):
+	expected := []struct {
+		kind  string
+		local string
+		alt   float64
+		az    float64
+	}{
+		{"rise", "2021-05-14T08:35:25-10:00", 1.572780367844571, 82.69308816807742},
+		{"maximum", "2021-05-14T12:39:25-10:00", 58.549301613516384, 109.02539755895984},
+		{"set", "2021-05-14T20:54:51-10:00", -1.8540741884192464, 81.44596667383418},
 	}
 
-	// Convert the JSON response:
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-
-	// Obtain the Local Civil Time set of the set and test whether or not it exists:
-	LCT, exists := response.Set["LCT"]
-	assert.True(t, exists)
-
-	// Obtain the refraction of the set and test whether or not it exists:
-	R, exists := response.Set["R"]
-	assert.True(t, exists)
-
-	// Obtain the Universal Time set of the set and test whether or not it exists:
-	UTC, exists := response.Set["UTC"]
-	assert.True(t, exists)
-
-	// Obtain the airmass (X) of the set and test whether or not it exists:
-	X, exists := response.Set["X"]
-	assert.True(t, exists)
-
-	// Obtain the age at the the set and test whether or not it exists:
-	age, exists := response.Set["age"]
-	assert.True(t, exists)
-
-	// Obtain the altitude of the set and test whether or not it exists:
-	alt, exists := response.Set["alt"]
-	assert.True(t, exists)
-
-	// Obtain the angle of the set and test whether or not it exists:
-	angle, exists := response.Set["angle"]
-	assert.True(t, exists)
-
-	// Obtain the azimuth of the set and test whether or not it exists:
-	az, exists := response.Set["az"]
-	assert.True(t, exists)
-
-	// Obtain the declination of the set and test whether or not it exists:
-	dec, exists := response.Set["dec"]
-	assert.True(t, exists)
-
-	// Obtain the fraction of the set and test whether or not it exists:
-	fraction, exists := response.Set["fraction"]
-	assert.True(t, exists)
-
-	// Obtain the illumination of the set and test whether or not it exists:
-	illumination, exists := response.Set["illumination"]
-	assert.True(t, exists)
-
-	// Obtain the right ascension of the set and test whether or not it exists:
-	ra, exists := response.Set["ra"]
-	assert.True(t, exists)
-
-	// Assert on the correctness of the response:
-	assert.Nil(t, err)
-	assert.Equal(t, R, set["R"])
-	assert.Equal(t, LCT, set["LCT"])
-	assert.Equal(t, UTC, set["UTC"])
-	assert.Equal(t, X, set["X"])
-	assert.InDelta(t, age, set["age"], precision)
-	assert.InDelta(t, alt, set["alt"], precision)
-	assert.InDelta(t, angle, set["angle"], precision)
-	assert.InDelta(t, az, set["az"], precision)
-	assert.InDelta(t, dec, set["dec"], precision)
-	assert.InDelta(t, fraction, set["fraction"], precision)
-	assert.InDelta(t, illumination, set["illumination"], precision)
-	assert.InDelta(t, ra, set["ra"], precision)
-}
+	assert.Len(t, evs, len(expected))
 
-func TestGetTransitRouteAlwaysBelowHorizon(t *testing.T) {
-	// Convert the JSON response:
-	err := json.Unmarshal(x.Body.Bytes(), &response)
-
-	// Assert on the correctness of the response:
-	assert.Nil(t, err)
-
-	// Assert that the response is empty:
-	assert.Equal(t, 0, len(response.Set))
-	assert.Equal(t, 13, len(response.Maximum))
-	assert.Equal(t, 0, len(response.Rise))
-}
-
-func TestGetTransitRouteAlwaysAboveHorizon(t *testing.T) {
-	maximum := gin.H{
-		"LCT":          "2021-05-14T00:49:00Z",
-		"R":            0.026514740978677415,
-		"UTC":          "2021-05-14T00:49:00Z",
-		"X":            1.8613342171640765,
-		"age":          1.243379544011377,
-		"alt":          32.39141930592652,
-		"angle":        156.2582254528502,
-		"az":           0.004671803057548289,
-		"dec":          77.407064,
-		"fraction":     0.04210479858382027,
-		"illumination": 4.231535575044432,
-		"ra":           88.792958,
-		"separation":   54.33338732802028,
+	for i, event := range evs {
+		assert.Equal(t, expected[i].kind, event.Type)
+		assert.Equal(t, expected[i].local, event.Local.Format(time.RFC3339))
+		assert.True(t, event.UTC.Equal(event.Local))
+		assert.InDelta(t, expected[i].alt, event.Horizontal.Altitude, precision)
+		assert.InDelta(t, expected[i].az, event.Horizontal.Azimuth, precision)
+		assert.Equal(t, events.Equatorial{RightAscension: 88.7929583, Declination: 7.4070639}, event.Equatorial)
 	}
-
-	// Convert the JSON response:
-	err := json.Unmarshal(y.Body.Bytes(), &response)
-
-	// Obtain the Local Civil Time maximum of the maximum and test whether or not it exists:
-	LCT, exists := response.Maximum["LCT"]
-	assert.True(t, exists)
-
-	// Obtain the refraction of the maximum and test whether or not it exists:
-	R, exists := response.Maximum["R"]
-	assert.True(t, exists)
-
-	// Obtain the Universal Time maximum of the maximum and test whether or not it exists:
-	UTC, exists := response.Maximum["UTC"]
-	assert.True(t, exists)
-
-	// Obtain the airmass (X) of the maximum and test whether or not it exists:
-	X, exists := response.Maximum["X"]
-	assert.True(t, exists)
-
-	// Obtain the age at the the maximum and test whether or not it exists:
-	age, exists := response.Maximum["age"]
-	assert.True(t, exists)
-
-	// Obtain the altitude of the maximum and test whether or not it exists:
-	alt, exists := response.Maximum["alt"]
-	assert.True(t, exists)
-
-	// Obtain the angle of the maximum and test whether or not it exists:
-	angle, exists := response.Maximum["angle"]
-	assert.True(t, exists)
-
-	// Obtain the azimuth of the maximum and test whether or not it exists:
-	az, exists := response.Maximum["az"]
-	assert.True(t, exists)
-
-	// Obtain the declination of the maximum and test whether or not it exists:
-	dec, exists := response.Maximum["dec"]
-	assert.True(t, exists)
-
-	// Obtain the fraction of the maximum and test whether or not it exists:
-	fraction, exists := response.Maximum["fraction"]
-	assert.True(t, exists)
-
-	// Obtain the illumination of the maximum and test whether or not it exists:
-	illumination, exists := response.Maximum["illumination"]
-	assert.True(t, exists)
-
-	// Obtain the right ascension of the maximum and test whether or not it exists:
-	ra, exists := response.Maximum["ra"]
-	assert.True(t, exists)
-
-	// Assert on the correctness of the response:
-	assert.Nil(t, err)
-	assert.InDelta(t, R, maximum["R"], precision)
-	assert.Equal(t, LCT, maximum["LCT"])
-	assert.Equal(t, UTC, maximum["UTC"])
-	assert.InDelta(t, X, maximum["X"], precision)
-	assert.InDelta(t, age, maximum["age"], precision)
-	assert.InDelta(t, alt, maximum["alt"], precision)
-	assert.InDelta(t, angle, maximum["angle"], precision)
-	assert.InDelta(t, az, maximum["az"], precision)
-	assert.InDelta(t, dec, maximum["dec"], precision)
-	assert.InDelta(t, fraction, maximum["fraction"], precision)
-	assert.InDelta(t, illumination, maximum["illumination"], precision)
-	assert.InDelta(t, ra, maximum["ra"], precision)
 }
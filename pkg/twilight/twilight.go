@@ -1,71 +1,118 @@
 package twilight
 
 import (
-	"net/http"
-	"strconv"
+	"context"
 	"time"
 
-	"github.com/gin-gonic/gin"
 	"github.com/observerly/dusk/pkg/dusk"
-	"github.com/observerly/nocturnal/internal/query"
-	"github.com/observerly/nocturnal/internal/utils"
+	"github.com/observerly/nocturnal/pkg/computation"
+	"github.com/observerly/nocturnal/pkg/events"
+	"github.com/observerly/nocturnal/pkg/observer"
 )
 
-func GetTwilight(c *gin.Context) {
-	d, lon, lat := query.GetDefaultObserverParams(c)
+type Period struct {
+	// The datetime of dusk, when the Sun sets below the horizon of the twilight:
+	From time.Time `json:"from"`
+	// The datetime of dawn, when the Sun rises above the horizon of the twilight:
+	Until time.Time `json:"until"`
+	// The duration between dusk and dawn (in hours):
+	Duration float64 `json:"duration"`
+	// The IANA timezone of the observer, e.g., "Pacific/Honolulu":
+	Location string `json:"location"`
+	// The altitude of the Sun below the horizon that defines the twilight (in degrees):
+	Horizon int `json:"horizon"`
+}
+
+type Response struct {
+	Observer     observer.Observer `json:"observer"`
+	Astronomical Period            `json:"astronomical"`
+	Civil        Period            `json:"civil"`
+	Nautical     Period            `json:"nautical"`
+}
 
-	datetime, _ := utils.ParseDatetimeRFC3339(d)
+// getPeriod returns the twilight on the night of the observer's datetime, when the Sun is below the horizon:
+func getPeriod(ctx context.Context, o observer.Observer, name string, horizon int, get func(time.Time, float64, float64, float64) (*dusk.Twilight, *time.Location, error)) (Period, error) {
+	span := computation.Start(ctx, name)
 
-	longitude, _ := strconv.ParseFloat(lon, 64)
+	twilight, location, err := get(o.Datetime, o.Longitude, o.Latitude, 0)
 
-	latitude, _ := strconv.ParseFloat(lat, 64)
+	span.End()
 
-	observer := gin.H{
-		"datetime":  datetime,
-		"longitude": longitude,
-		"latitude":  latitude,
+	if err != nil {
+		return Period{}, err
 	}
 
-	// Civil Twilight:
+	return Period{
+		From:     twilight.From.Truncate(time.Second),
+		Until:    twilight.Until.Truncate(time.Second),
+		Duration: float64(twilight.Duration.Milliseconds()) * 0.001 / 3600,
+		Location: location.String(),
+		Horizon:  horizon,
+	}, nil
+}
 
-	civil, location, _ := dusk.GetLocalCivilTwilight(datetime, longitude, latitude, 0)
+// GetTwilightPeriods returns the civil, nautical and astronomical twilight on the night of the observer's datetime:
+func GetTwilightPeriods(ctx context.Context, o observer.Observer) (Response, error) {
+	civil, err := getPeriod(ctx, o, "civil_twilight", -6, dusk.GetLocalCivilTwilight)
 
-	ct := gin.H{
-		"from":     civil.From.Format(time.RFC3339),
-		"until":    civil.Until.Format(time.RFC3339),
-		"duration": float64(civil.Duration.Milliseconds()) * 0.001 / 3600,
-		"location": location.String(),
-		"horizon":  -6,
+	if err != nil {
+		return Response{}, err
 	}
 
-	// Nautical Twilight:
+	nautical, err := getPeriod(ctx, o, "nautical_twilight", -12, dusk.GetLocalNauticalTwilight)
 
-	nautical, location, _ := dusk.GetLocalNauticalTwilight(datetime, longitude, latitude, 0)
-
-	nt := gin.H{
-		"from":     nautical.From.Format(time.RFC3339),
-		"until":    nautical.Until.Format(time.RFC3339),
-		"duration": float64(nautical.Duration.Milliseconds()) * 0.001 / 3600,
-		"location": location.String(),
-		"horizon":  -12,
+	if err != nil {
+		return Response{}, err
 	}
 
-	// Astronomical Twilight:
+	astronomical, err := getPeriod(ctx, o, "astronomical_twilight", -18, dusk.GetLocalAstronomicalTwilight)
+
+	if err != nil {
+		return Response{}, err
+	}
 
-	astronomical, location, _ := dusk.GetLocalAstronomicalTwilight(datetime, longitude, latitude, 0)
+	return Response{
+		Observer:     o,
+		Astronomical: astronomical,
+		Civil:        civil,
+		Nautical:     nautical,
+	}, nil
+}
 
-	at := gin.H{
-		"from":     astronomical.From.Format(time.RFC3339),
-		"until":    astronomical.Until.Format(time.RFC3339),
-		"duration": float64(astronomical.Duration.Milliseconds()) * 0.001 / 3600,
-		"location": location.String(),
-		"horizon":  -18,
+// GetTwilightEvents returns the civil, nautical and astronomical dusk and dawn events (those that occur) of the Sun on the night of the
+// datetime, for the observer in the local timezone of the location:
+func GetTwilightEvents(ctx context.Context, datetime time.Time, longitude float64, latitude float64, location *time.Location) ([]events.Event, error) {
+	evs := []events.Event{}
+
+	for _, t := range []struct {
+		computation string
+		dusk        string
+		dawn        string
+		get         func(time.Time, float64, float64, float64) (*dusk.Twilight, *time.Location, error)
+	}{
+		{"civil_twilight", events.CIVIL_DUSK, events.CIVIL_DAWN, dusk.GetLocalCivilTwilight},
+		{"nautical_twilight", events.NAUTICAL_DUSK, events.NAUTICAL_DAWN, dusk.GetLocalNauticalTwilight},
+		{"astronomical_twilight", events.ASTRONOMICAL_DUSK, events.ASTRONOMICAL_DAWN, dusk.GetLocalAstronomicalTwilight},
+	} {
+		span := computation.Start(ctx, t.computation)
+
+		twilight, _, err := t.get(datetime, longitude, latitude, 0)
+
+		span.End()
+
+		if err != nil {
+			return nil, err
+		}
+
+		// The Sun does not reach the depression of a twilight during the polar day (or the summer at high latitudes):
+		if events.Occurs(twilight.From, datetime) {
+			evs = append(evs, events.NewEvent(t.dusk, twilight.From, dusk.GetSolarEquatorialPosition(twilight.From.UTC()), longitude, latitude, location))
+		}
+
+		if events.Occurs(twilight.Until, datetime) {
+			evs = append(evs, events.NewEvent(t.dawn, twilight.Until, dusk.GetSolarEquatorialPosition(twilight.Until.UTC()), longitude, latitude, location))
+		}
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"observer":     observer,
-		"astronomical": at,
-		"civil":        ct,
-		"nautical":     nt,
-	})
+	return evs, nil
 }
@@ -1,186 +1,102 @@
 package twilight
 
 import (
-	"encoding/json"
-	"net/http"
-	"net/http/httptest"
-	"os"
+	"context"
 	"testing"
+	"time"
 
-	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
-)
-
-func SetupTwilightRouter() *gin.Engine {
-	mode := os.Getenv("GIN_MODE")
-
-	if mode == "release" {
-		gin.SetMode(gin.ReleaseMode)
-	}
-
-	// Create gin router
-	r := gin.Default()
-
-	r.GET("/api/v2/twilight", GetTwilight)
-
-	return r
-}
-
-// Setup the Gin API router:
-var r = SetupTwilightRouter()
-
-// Setup the base response struct:
-var response map[string]map[string]interface{}
-
-func performRequest(r http.Handler, method, path string) *httptest.ResponseRecorder {
-	req, _ := http.NewRequest(method, path, nil)
-	w := httptest.NewRecorder()
-	r.ServeHTTP(w, req)
-	return w
-}
-
-// Perform a GET request with that handler.
-var w = performRequest(r, "GET", "/api/v2/twilight?datetime=2021-05-14T00:00:00.000Z&longitude=-155.468094&latitude=19.798484")
-
-func TestTwilightRouteStatusCode(t *testing.T) {
-	// Assert we encoded correctly, the request gives a 200:
-	assert.Equal(t, http.StatusOK, w.Code)
-}
-
-func TestGetTwilightRouteObserver(t *testing.T) {
-	// Build our expected observer section of body
-	observer := gin.H{
-		"datetime":  "2021-05-14T00:00:00Z",
-		"latitude":  19.798484,
-		"longitude": -155.468094,
-	}
 
-	// Convert the JSON response:
-	err := json.Unmarshal(w.Body.Bytes(), &response)
+	"github.com/observerly/nocturnal/pkg/events"
+	"github.com/observerly/nocturnal/pkg/observer"
+)
 
-	// Grab the observer & whether or not it exists
-	datetime, exists := response["observer"]["datetime"]
-	assert.True(t, exists)
+var precision = 0.0001
 
-	// Grab the observer & whether or not it exists
-	latitude, exists := response["observer"]["latitude"]
-	assert.True(t, exists)
+func TestGetTwilightPeriods(t *testing.T) {
+	o := observer.New(time.Date(2021, 5, 14, 0, 0, 0, 0, time.UTC), -155.468094, 19.798484, 0)
 
-	// Grab the observer & whether or not it exists
-	longitude, exists := response["observer"]["longitude"]
-	assert.True(t, exists)
+	res, err := GetTwilightPeriods(context.Background(), o)
 
-	// Assert on the correctness of the response:
 	assert.Nil(t, err)
-	assert.Equal(t, datetime, observer["datetime"])
-	assert.Equal(t, latitude, observer["latitude"])
-	assert.Equal(t, longitude, observer["longitude"])
-}
-
-func TestGetTwilightRouteAstronomicalTwilight(t *testing.T) {
-	// Build our expected twilight section of body
-	twilight := gin.H{
-		"duration": 8.563931944444445,
-		"from":     "2021-05-14T20:01:18-10:00",
-		"location": "Pacific/Honolulu",
-		"until":    "2021-05-15T04:35:08-10:00",
+	assert.Equal(t, o, res.Observer)
+
+	// The twilights of the night of 2021-05-14 at Mauna Kea:
+	expected := []struct {
+		period   Period
+		from     string
+		until    string
+		duration float64
+	}{
+		{res.Civil, "2021-05-14T19:11:19-10:00", "2021-05-15T05:25:03-10:00", 10.228770555555556},
+		{res.Nautical, "2021-05-14T19:36:08-10:00", "2021-05-15T05:00:17-10:00", 9.402505555555557},
+		{res.Astronomical, "2021-05-14T20:01:18-10:00", "2021-05-15T04:35:08-10:00", 8.563931944444445},
 	}
 
-	// Convert the JSON response:
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-
-	// Grab the twilight & whether or not it exists
-	duration, exists := response["astronomical"]["duration"]
-	assert.True(t, exists)
-
-	// Grab the twilight & whether or not it exists
-	from, exists := response["astronomical"]["from"]
-	assert.True(t, exists)
+	for _, e := range expected {
+		assert.Equal(t, "Pacific/Honolulu", e.period.Location)
+		assert.Equal(t, e.from, e.period.From.Format(time.RFC3339))
+		assert.Equal(t, e.until, e.period.Until.Format(time.RFC3339))
+		assert.InDelta(t, e.duration, e.period.Duration, precision)
+	}
+}
 
-	// Grab the twilight & whether or not it exists
-	location, exists := response["astronomical"]["location"]
-	assert.True(t, exists)
+func TestGetTwilightEvents(t *testing.T) {
+	location, _ := time.LoadLocation("Pacific/Honolulu")
 
-	// Grab the twilight & whether or not it exists
-	until, exists := response["astronomical"]["until"]
-	assert.True(t, exists)
+	evs, err := GetTwilightEvents(context.Background(), time.Date(2021, 5, 14, 0, 0, 0, 0, location), -155.468094, 19.798484, location)
 
-	// Assert on the correctness of the response:
 	assert.Nil(t, err)
-	assert.Equal(t, duration, twilight["duration"])
-	assert.Equal(t, from, twilight["from"])
-	assert.Equal(t, location, twilight["location"])
-	assert.Equal(t, until, twilight["until"])
-}
 
-func TestGetTwilightRouteCivilTwilight(t *testing.T) {
-	// Build our expected twilight section of body
-	twilight := gin.H{
-		"duration": 10.228770555555556,
-		"from":     "2021-05-14T19:11:19-10:00",
-		"location": "Pacific/Honolulu",
-		"until":    "2021-05-15T05:25:03-10:00",
+	// The twilights of the night of 2021-05-14 at Mauna Kea, in chronological order (as for the body of the Sun):
+	expected := []struct {
+		kind  string
+		local string
+		alt   float64
+	}{
+		{"civil_dusk", "2021-05-14T19:11:19-10:00", -8.922644447634488},
+		{"nautical_dusk", "2021-05-14T19:36:08-10:00", -11.05900846557276},
+		{"astronomical_dusk", "2021-05-14T20:01:18-10:00", -17.154757156472463},
+		{"astronomical_dawn", "2021-05-15T04:35:08-10:00", -14.426810296326554},
+		{"nautical_dawn", "2021-05-15T05:00:17-10:00", -10.378976608466736},
+		{"civil_dawn", "2021-05-15T05:25:03-10:00", -3.423341283725398},
 	}
 
-	// Convert the JSON response:
-	err := json.Unmarshal(w.Body.Bytes(), &response)
+	body := events.NewBody("sun", evs...)
 
-	// Grab the twilight & whether or not it exists
-	duration, exists := response["civil"]["duration"]
-	assert.True(t, exists)
+	assert.Len(t, body.Events, len(expected))
 
-	// Grab the twilight & whether or not it exists
-	from, exists := response["civil"]["from"]
-	assert.True(t, exists)
+	for i, event := range body.Events {
+		assert.Equal(t, expected[i].kind, event.Type)
+		assert.Equal(t, expected[i].local, event.Local.Format(time.RFC3339))
+		assert.True(t, event.UTC.Equal(event.Local))
+		assert.InDelta(t, expected[i].alt, event.Horizontal.Altitude, precision)
+	}
+}
 
-	// Grab the twilight & whether or not it exists
-	location, exists := response["civil"]["location"]
-	assert.True(t, exists)
+func TestGetTwilightEventsPolarDay(t *testing.T) {
+	location, _ := time.LoadLocation("Arctic/Longyearbyen")
 
-	// Grab the twilight & whether or not it exists
-	until, exists := response["civil"]["until"]
-	assert.True(t, exists)
+	evs, err := GetTwilightEvents(context.Background(), time.Date(2021, 6, 21, 0, 0, 0, 0, time.UTC), 15.63, 78.22, location)
 
-	// Assert on the correctness of the response:
+	// Assert that there is no twilight at Longyearbyen on the summer solstice, as the Sun never sets:
 	assert.Nil(t, err)
-	assert.Equal(t, duration, twilight["duration"])
-	assert.Equal(t, from, twilight["from"])
-	assert.Equal(t, location, twilight["location"])
-	assert.Equal(t, until, twilight["until"])
+	assert.Empty(t, evs)
 }
 
-func TestGetTwilightRouteNauticalTwilight(t *testing.T) {
-	// Build our expected twilight section of body
-	twilight := gin.H{
-		"duration": 9.402505555555557,
-		"from":     "2021-05-14T19:36:08-10:00",
-		"location": "Pacific/Honolulu",
-		"until":    "2021-05-15T05:00:17-10:00",
-	}
-
-	// Convert the JSON response:
-	err := json.Unmarshal(w.Body.Bytes(), &response)
+func TestGetTwilightEventsNeverRises(t *testing.T) {
+	location, _ := time.LoadLocation("Arctic/Longyearbyen")
 
-	// Grab the twilight & whether or not it exists
-	duration, exists := response["nautical"]["duration"]
-	assert.True(t, exists)
+	evs, err := GetTwilightEvents(context.Background(), time.Date(2021, 12, 21, 0, 0, 0, 0, time.UTC), 15.63, 78.22, location)
 
-	// Grab the twilight & whether or not it exists
-	from, exists := response["nautical"]["from"]
-	assert.True(t, exists)
+	assert.Nil(t, err)
 
-	// Grab the twilight & whether or not it exists
-	location, exists := response["nautical"]["location"]
-	assert.True(t, exists)
+	types := []string{}
 
-	// Grab the twilight & whether or not it exists
-	until, exists := response["nautical"]["until"]
-	assert.True(t, exists)
+	for _, event := range evs {
+		types = append(types, event.Type)
+	}
 
-	// Assert on the correctness of the response:
-	assert.Nil(t, err)
-	assert.Equal(t, duration, twilight["duration"])
-	assert.Equal(t, from, twilight["from"])
-	assert.Equal(t, location, twilight["location"])
-	assert.Equal(t, until, twilight["until"])
+	// Assert that there is no civil twilight at Longyearbyen on the winter solstice, as the Sun never rises above -6°:
+	assert.ElementsMatch(t, []string{"nautical_dusk", "nautical_dawn", "astronomical_dusk", "astronomical_dawn"}, types)
 }